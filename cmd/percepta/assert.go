@@ -1,135 +1,392 @@
-//go:build linux || darwin
-
 package main
 
 import (
 	"fmt"
+	"io"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/perceptumx/percepta/internal/assertions"
-	"github.com/perceptumx/percepta/internal/config"
 	perceptaErrors "github.com/perceptumx/percepta/internal/errors"
-	"github.com/perceptumx/percepta/internal/storage"
 	"github.com/perceptumx/percepta/internal/ui"
-	"github.com/perceptumx/percepta/pkg/percepta"
 	"github.com/spf13/cobra"
 )
 
 var assertCmd = &cobra.Command{
-	Use:   "assert <device> <assertion>",
+	Use:   "assert <device> <assertion>...",
 	Short: "Validate hardware state against expected behavior",
 	Long: `Validate hardware behavior using assertions.
 
 Captures an observation and evaluates the assertion expression. Returns exit
-code 0 if passed, 1 if failed.
+code 0 if passed, 1 if failed, 3 if inconclusive.
+
+--format json|junit|tap prints a machine-readable report (with every result
+field, observation IDs, firmware tag, timings and confidence) on stdout and
+moves the human-readable output to stderr. --report-file writes the report
+to a file instead, keeping the normal output.
+
+Several expressions (arguments, and lines of --file files) are all evaluated
+against one shared observation and reported in a combined table; the exit
+code is 1 if any of them failed.
+
+With --observation <id> or --latest [--firmware <tag>] the expression is
+evaluated against a stored observation instead: no camera or vision API is
+needed, so this also works on machines without a webcam.
+
+A result is INCONCLUSIVE when a matched signal's confidence is below the
+threshold. Thresholds are taken, most specific first, from a CONFIDENCE
+clause in the expression, --min-confidence, the device's min_confidence and
+the global assert.min_confidence config. --reobserve N captures up to N
+more observations while the result stays inconclusive.
+
+For flaky captures, --retries N makes up to N more attempts after a failed
+one, and --require K-of-N passes once K of up to N attempts passed. Every
+attempt observes afresh and is stored as its own observation; attempts stop
+as soon as the vote is decided, and the report lists how each one voted.
+
+--check parses the expressions (arguments and --file lines) without a device
+or camera, reporting each syntax error with its column and a suggested fix,
+and exits 1 if any is invalid, so assertion files can be validated in CI
+before a hardware run. --explain prints what each expression checks in plain
+English.
+
+Expressions combine statements or method-call predicates with && (and),
+|| (or), ! (not) and parentheses. Every clause is reported individually.
 
 Examples:
   # LED is ON
-  percepta assert my-board "led power is ON"
+  percepta assert my-board "LED.power ON"
 
   # LED blinks at specific rate
-  percepta assert my-board "led status blinks at 2Hz"
+  percepta assert my-board "LED.status BLINK 2Hz"
+
+  # Named color (hue range) or perceptual distance to an RGB value
+  percepta assert my-board "LED.status COLOR amber"
+  percepta assert my-board "LED.status COLOR RGB(0,0,255) WITHIN 10"
+
+  # Display contains text, optionally tolerating OCR misreads
+  percepta assert my-board 'Display.LCD "Ready"'
+  percepta assert my-board 'Display.LCD "Ready" SIMILAR 0.8'
+
+  # Readings parsed from display text
+  percepta assert my-board "Display.lcd VALUE temp BETWEEN 20 AND 25"
+  percepta assert my-board 'Display.lcd MATCHES /^IP \d+\.\d+/'
+
+  # Free-form question to the vision model, answered yes/no or with text
+  percepta assert my-board 'Vision "Does the screen show a WiFi icon?" EXPECT yes'
+  percepta assert my-board 'Vision "Which icon is next to the clock?" EXPECT "battery"'
+
+  # Boot timing: resets the device with boot.reset_command, or waits for Enter
+  percepta assert my-board "BootTime < 3000ms && BootTime.first_text < 1500ms"
+
+  # Combined conditions
+  percepta assert my-board "led('LED1').blinks() && led('LED1').color_rgb(0,0,255)"
+  percepta assert my-board "LED.power ON && !(LED.error ON || LED.warn ON)"
+
+  # Temporal: wait up to 10s for WiFi, then require no error for 30s
+  percepta assert my-board "EVENTUALLY 10s LED.wifi ON"
+  percepta assert my-board "ALWAYS 30s EVERY 5s LED.error OFF"
+
+  # State sequence: blinking for 2-30s, then steady, within 60s
+  percepta assert my-board "SEQUENCE WITHIN 60s LED.status BLINKING FOR 2s..30s THEN LED.status STEADY"
+
+  # Blink code on the error LED (records a high-rate per-frame timeline)
+  percepta assert my-board "LED.err CODE 3-2"
+
+  # Brightness and duty cycle of PWM-dimmed or blinking LEDs, fade effects
+  percepta assert my-board "LED.backlight BRIGHTNESS 50% WITHIN 5%"
+  percepta assert my-board "LED.status DUTY < 30% && LED.power BREATHING"
+
+  # Relations between LEDs, compared frame by frame
+  percepta assert my-board "LED.tx RATE == LED.rx"
+  percepta assert my-board "LED.heartbeat RATE == 2x LED.status"
+  percepta assert my-board "LED.a ON IFF LED.b OFF && LED.left ALTERNATES WITH LED.right"
+
+  # Require confident evidence, retrying weak observations twice
+  percepta assert my-board "CONFIDENCE >= 0.8 LED.wifi ON"
+  percepta assert my-board "LED.wifi ON" --min-confidence 0.8 --reobserve 2
+
+  # Retry a flaky check, or decide by majority of three captures
+  percepta assert my-board "LED.status COLOR green" --retries 2
+  percepta assert my-board "LED.status COLOR green" --require 2-of-3
+
+  # Several checks against one observation, from arguments and a file
+  percepta assert my-board "LED.power ON" "LED.error OFF"
+  percepta assert my-board --file checks.txt
+
+  # Re-check a new expression against stored observations
+  percepta assert my-board "LED.status BLINKING" --observation obs-20260115-103000
+  percepta assert my-board "LED.status BLINKING" --firmware v1.2 --latest
+
+  # CI reports: JUnit XML for Jenkins/GitLab, TAP or JSON
+  percepta assert my-board --file checks.txt --report-file report.xml
+  percepta assert my-board "LED.power ON" --format tap
 
-  # Display contains text
-  percepta assert my-board "display LCD shows 'Ready'"
+  # Snapshot test against the device's golden observation (see 'percepta golden')
+  percepta assert my-board --matches-golden
+  percepta assert my-board --matches-golden --update-golden
 
-  # Multiple conditions
-  percepta assert my-board "led power is ON" "led error is OFF"`,
-	Args: cobra.MinimumNArgs(2),
+  # Lint expressions without hardware, or describe what they check
+  percepta assert --check "LED.status BLINK 2"
+  percepta assert --check --file checks.txt
+  percepta assert --explain "LED.power ON && !(LED.error ON || LED.warn ON)"
+
+  # Run a suite file of named cases (device taken from the file if omitted)
+  percepta assert --suite checks.yaml
+  percepta assert my-board --suite checks.yaml`,
+	Args: validateAssertArgs,
 	RunE: runAssert,
 }
 
-func runAssert(cmd *cobra.Command, args []string) error {
-	deviceID := args[0]
-	assertionDSL := args[1]
+// exitInconclusive is returned when the evidence was too weak to pass or fail
+const exitInconclusive = 3
 
-	// Parse assertion DSL
-	assertion, err := assertions.Parse(assertionDSL)
-	if err != nil {
-		return fmt.Errorf("invalid assertion: %w", err)
-	}
+var (
+	assertSuiteFile     string
+	assertMinConfidence float64
+	assertReobserve     int
+	assertRetries       int
+	assertRequire       string
+)
 
-	// Load config and initialize Core
-	cfg, err := config.Load()
-	if err != nil {
-		return perceptaErrors.ConfigNotFound()
+func init() {
+	assertCmd.Flags().StringVar(&assertSuiteFile, "suite", "", "YAML/JSON suite file of named assertion cases")
+	assertCmd.Flags().Float64Var(&assertMinConfidence, "min-confidence", 0, "Report INCONCLUSIVE below this signal confidence (0-1)")
+	assertCmd.Flags().IntVar(&assertReobserve, "reobserve", 0, "Extra observations to capture while the result is INCONCLUSIVE")
+	assertCmd.Flags().IntVar(&assertRetries, "retries", 0, "Extra attempts, each on a fresh observation, after a failed one")
+	assertCmd.Flags().StringVar(&assertRequire, "require", "", "Pass once K of up to N attempts passed, e.g. 2-of-3")
+	assertCmd.MarkFlagsMutuallyExclusive("retries", "require")
+}
+
+// quorumRequested reports whether --retries or --require asks for several attempts
+func quorumRequested() bool {
+	return assertRetries != 0 || assertRequire != ""
+}
+
+// assertQuorum resolves --retries and --require into a vote over attempts
+func assertQuorum() (assertions.Quorum, error) {
+	switch {
+	case assertRetries < 0:
+		return assertions.Quorum{}, fmt.Errorf("--retries must not be negative")
+	case assertRetries > 0:
+		return assertions.RetryQuorum(assertRetries), nil
+	case assertRequire != "":
+		return assertions.ParseQuorum(assertRequire)
 	}
+	return assertions.Quorum{}, nil
+}
 
-	if len(cfg.Devices) == 0 {
-		return perceptaErrors.NoDevicesConfigured()
+func validateAssertArgs(cmd *cobra.Command, args []string) error {
+	if parseOnlyRequested() {
+		if err := validateCheckFlags(); err != nil {
+			return err
+		}
+		if len(assertFiles) > 0 {
+			return nil
+		}
+		return cobra.MinimumNArgs(1)(cmd, args)
+	}
+	if err := validateGoldenFlags(); err != nil {
+		return err
+	}
+	if assertMinConfidence < 0 || assertMinConfidence > 1 {
+		return fmt.Errorf("--min-confidence must be between 0 and 1, got %g", assertMinConfidence)
+	}
+	if _, err := assertQuorum(); err != nil {
+		return err
+	}
+	if assertMatchesGolden {
+		return cobra.ExactArgs(1)(cmd, args)
 	}
+	if assertSuiteFile != "" {
+		if len(assertFiles) > 0 {
+			return fmt.Errorf("--file cannot be combined with --suite")
+		}
+		return cobra.MaximumNArgs(1)(cmd, args)
+	}
+	if len(assertFiles) > 0 {
+		return cobra.MinimumNArgs(1)(cmd, args)
+	}
+	return cobra.MinimumNArgs(2)(cmd, args)
+}
 
-	deviceCfg, ok := cfg.Devices[deviceID]
-	if !ok {
-		return perceptaErrors.DeviceNotFound(deviceID)
+func runAssert(cmd *cobra.Command, args []string) error {
+	if parseOnlyRequested() {
+		return runAssertCheck(args)
+	}
+	if err := startAssertReport(); err != nil {
+		return err
+	}
+	if assertSuiteFile != "" {
+		return runAssertSuite(args)
+	}
+	if assertMatchesGolden {
+		return runAssertGolden(args[0])
 	}
 
-	cameraPath := "/dev/video0"
-	firmwareTag := ""
-	if deviceCfg.CameraID != "" {
-		cameraPath = deviceCfg.CameraID
+	deviceID := args[0]
+	expressions, err := assertExpressions(args[1:])
+	if err != nil {
+		return err
+	}
+	if len(expressions) > 1 {
+		return runAssertMany(deviceID, expressions)
 	}
-	firmwareTag = deviceCfg.Firmware
 
-	// Initialize SQLite storage
-	sqliteStorage, err := storage.NewSQLiteStorage()
+	// Parse assertion DSL
+	assertion, err := assertions.Parse(expressions[0])
 	if err != nil {
-		return perceptaErrors.StorageInitFailed(err)
+		return fmt.Errorf("invalid assertion: %w", err)
+	}
+
+	return runAssertOne(deviceID, assertion)
+}
+
+// runAssertOne evaluates a single assertion against a stored observation or
+// the live camera, then reports and exits with its outcome
+func runAssertOne(deviceID string, assertion assertions.Assertion) error {
+	if storedObservationRequested() {
+		return runAssertStored(deviceID, assertion)
 	}
-	defer sqliteStorage.Close()
 
-	perceptaCore, err := percepta.NewCore(cameraPath, sqliteStorage)
+	target, err := openAssertTarget(deviceID)
 	if err != nil {
-		return perceptaErrors.CameraNotFound(cameraPath)
+		return err
 	}
+	defer target.Close()
+	target.captureFor(assertion)
 
-	// Capture observation with spinner
-	spinner := ui.NewSpinner(fmt.Sprintf("Evaluating assertion on %s...", deviceID))
-	obs, err := perceptaCore.Observe(deviceID)
+	minConfidence := assertions.ResolveMinConfidence(assertMinConfidence, target.minConfidence)
+	quorum, err := assertQuorum()
 	if err != nil {
-		spinner.Stop(false)
-		return perceptaErrors.ObservationFailed(err)
+		return err
 	}
 
-	// Inject firmware tag and save
-	obs.FirmwareHash = firmwareTag
-	if err := sqliteStorage.Save(*obs); err != nil {
+	// Capture observation(s) and evaluate with spinner.
+	// Temporal operators keep observing until they are decided.
+	spinner := ui.NewSpinner(fmt.Sprintf("Evaluating assertion on %s...", deviceID))
+	checked := assertions.WithMinConfidence(assertions.WithTolerance(assertion, target.tolerance), minConfidence)
+	var result assertions.AssertionResult
+	runs := 1
+	if quorum.Voting() {
+		result, err = assertions.RunQuorum(checked, nil, target.observe, assertReobserve, quorum)
+	} else {
+		result, runs, err = assertions.RunConclusive(checked, nil, target.observe, assertReobserve)
+	}
+	if err != nil {
 		spinner.Stop(false)
-		return fmt.Errorf("failed to save observation: %w", err)
+		return err
 	}
-
-	// Evaluate assertion
-	result := assertion.Evaluate(obs)
 	spinner.Stop(result.Passed)
 
 	// Format and print result
-	printAssertionResult(assertion, result)
+	printAssertionResult(assertReport.out, assertion, result)
+	if runs > 1 {
+		fmt.Fprintf(assertReport.out, "\nEvaluated %d times (--reobserve %d)\n", runs, assertReobserve)
+	}
+	if err := writeSingleReport(deviceID, target.firmwareTag, assertion, result); err != nil {
+		return err
+	}
 
 	// Exit with appropriate code
-	if !result.Passed {
-		os.Exit(1)
-	}
+	exitForOutcome(result.Outcome())
 
 	return nil
 }
 
-func printAssertionResult(assertion assertions.Assertion, result assertions.AssertionResult) {
-	// Header with pass/fail indicator
-	if result.Passed {
-		fmt.Printf("✅ PASS: %s\n", assertion.String())
-	} else {
-		fmt.Printf("❌ FAIL: %s\n", assertion.String())
+// exitForOutcome exits non-zero for failing (1) and inconclusive (3) outcomes
+func exitForOutcome(outcome assertions.Outcome) {
+	switch outcome {
+	case assertions.OutcomeFail:
+		os.Exit(1)
+	case assertions.OutcomeInconclusive:
+		os.Exit(exitInconclusive)
 	}
+}
+
+func printAssertionResult(w io.Writer, assertion assertions.Assertion, result assertions.AssertionResult) {
+	// Header with pass/fail/inconclusive indicator
+	fmt.Fprintf(w, "%s: %s\n", outcomeLabel(result.Outcome()), assertion.String())
 
 	// Details section
-	fmt.Printf("\nExpected: %s\n", result.Expected)
-	fmt.Printf("Actual:   %s\n", result.Actual)
+	fmt.Fprintf(w, "\nExpected: %s\n", result.Expected)
+	fmt.Fprintf(w, "Actual:   %s\n", result.Actual)
 
 	// Confidence indicator
-	fmt.Printf("Confidence: %.2f\n", result.Confidence)
+	fmt.Fprintf(w, "Confidence: %.2f\n", result.Confidence)
+
+	// Deciding observation (the decisive sample for temporal operators)
+	if result.ObservationID != "" {
+		fmt.Fprintf(w, "Observation: %s (%s)\n", result.ObservationID, result.ObservedAt.Format(time.RFC3339))
+	}
 
 	// Additional message if present
 	if result.Message != "" {
-		fmt.Printf("\nDetails: %s\n", result.Message)
+		fmt.Fprintf(w, "\nDetails: %s\n", result.Message)
+	}
+
+	// Per-clause breakdown for compound expressions
+	if len(result.Children) > 0 {
+		fmt.Fprintf(w, "\nClauses:\n")
+		printClauseTree(w, result.Children, 1)
+	}
+
+	// How each attempt of a --retries/--require run voted
+	if len(result.Attempts) > 0 {
+		fmt.Fprintf(w, "\nAttempts (%s required):\n", result.Quorum)
+		for i, r := range result.Attempts {
+			fmt.Fprintf(w, "  %d. %s %s [%.2f]", i+1, outcomeLabel(r.Outcome()), r.ObservationID, r.Confidence)
+			if !r.Passed {
+				fmt.Fprintf(w, " — %s", r.Actual)
+			}
+			fmt.Fprintln(w)
+		}
+	}
+
+	// An EVENTUALLY that never held ran out of time
+	if result.Op == assertions.OpEventually && result.Outcome() == assertions.OutcomeFail {
+		fmt.Fprintf(w, "\n%v\n", perceptaErrors.AssertionTimeout(assertion.String()))
+	}
+}
+
+func outcomeLabel(outcome assertions.Outcome) string {
+	switch outcome {
+	case assertions.OutcomePass:
+		return "✅ PASS"
+	case assertions.OutcomeInconclusive:
+		return "⚠️  INCONCLUSIVE"
+	}
+	return "❌ FAIL"
+}
+
+func outcomeMark(outcome assertions.Outcome) string {
+	switch outcome {
+	case assertions.OutcomePass:
+		return "✓"
+	case assertions.OutcomeInconclusive:
+		return "?"
+	}
+	return "✗"
+}
+
+// formatVotes lists how each attempt of a quorum run voted, e.g. "✗ ✓ ✓ (2-of-3)"
+func formatVotes(result assertions.AssertionResult) string {
+	marks := make([]string, len(result.Attempts))
+	for i, r := range result.Attempts {
+		marks[i] = outcomeMark(r.Outcome())
+	}
+	return fmt.Sprintf("%s (%s)", strings.Join(marks, " "), result.Quorum)
+}
+
+func printClauseTree(w io.Writer, results []assertions.AssertionResult, depth int) {
+	indent := strings.Repeat("  ", depth)
+	for _, r := range results {
+		fmt.Fprintf(w, "%s%s %s [%.2f]", indent, outcomeMark(r.Outcome()), r.Expected, r.Confidence)
+		if !r.Passed && len(r.Children) == 0 {
+			fmt.Fprintf(w, " — %s", r.Actual)
+		}
+		fmt.Fprintln(w)
+		printClauseTree(w, r.Children, depth+1)
 	}
 }
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/perceptumx/percepta/internal/assertions"
+)
+
+// Parse-only flags: validate or describe expressions without a device
+var (
+	assertCheck   bool
+	assertExplain bool
+)
+
+func init() {
+	assertCmd.Flags().BoolVar(&assertCheck, "check", false, "Only parse the expressions (arguments and --file lines) and report syntax errors; no device or camera")
+	assertCmd.Flags().BoolVar(&assertExplain, "explain", false, "Print what each expression checks, in plain English; no device or camera")
+}
+
+// parseOnlyRequested reports whether --check or --explain replaces evaluation
+func parseOnlyRequested() bool {
+	return assertCheck || assertExplain
+}
+
+func validateCheckFlags() error {
+	switch {
+	case assertSuiteFile != "":
+		return fmt.Errorf("--check and --explain cannot be combined with --suite")
+	case assertMatchesGolden || assertUpdateGolden:
+		return fmt.Errorf("--check and --explain cannot be combined with --matches-golden")
+	case storedObservationRequested():
+		return fmt.Errorf("--check and --explain take no observation; drop --observation, --latest and --firmware")
+	case quorumRequested() || assertReobserve > 0:
+		return fmt.Errorf("--check and --explain evaluate nothing; drop --retries, --require and --reobserve")
+	}
+	return nil
+}
+
+// expressionSource is where a checked expression came from
+type expressionSource struct {
+	where string // "argument 2" or "checks.txt:14"
+	dsl   string
+}
+
+// runAssertCheck parses every expression, reporting each syntax error with
+// its position and suggested fix, and with --explain describes the valid
+// ones. It exits 1 if any expression is invalid.
+func runAssertCheck(args []string) error {
+	var sources []expressionSource
+	for i, dsl := range args {
+		sources = append(sources, expressionSource{where: fmt.Sprintf("argument %d", i+1), dsl: dsl})
+	}
+
+	checked, invalid := len(args), 0
+	for _, source := range sources {
+		pe := assertions.Check(source.dsl)
+		if pe != nil {
+			invalid++
+			printParseError(source, pe)
+			continue
+		}
+		if assertExplain {
+			explainExpression(source.dsl)
+		}
+	}
+
+	for _, path := range assertFiles {
+		count, diagnostics, err := assertions.CheckFile(path)
+		if err != nil {
+			return err
+		}
+		if count == 0 {
+			return fmt.Errorf("%s: no assertions found", path)
+		}
+		checked += count
+		invalid += len(diagnostics)
+		for _, d := range diagnostics {
+			printParseError(expressionSource{where: fmt.Sprintf("%s:%d", path, d.Line), dsl: d.Expression}, d.Err)
+		}
+		if assertExplain && len(diagnostics) == 0 {
+			expressions, err := assertions.LoadExpressions(path)
+			if err != nil {
+				return err
+			}
+			for _, dsl := range expressions {
+				explainExpression(dsl)
+			}
+		}
+	}
+
+	if invalid > 0 {
+		fmt.Printf("❌ %d of %d expression(s) invalid\n", invalid, checked)
+		exitForOutcome(assertions.OutcomeFail)
+	}
+	if assertCheck {
+		fmt.Printf("✅ %d expression(s) OK\n", checked)
+	}
+	return nil
+}
+
+// printParseError shows the error with a caret under its column and the
+// suggested fix, e.g.
+//
+//	argument 1: expected a blink rate like 2Hz at column 18
+//	  LED.status BLINK 2
+//	                   ^
+//	  did you mean LED.status BLINK 2Hz?
+func printParseError(source expressionSource, pe *assertions.ParseError) {
+	fmt.Printf("%s: %s\n", source.where, pe.Message)
+	if pe.Column > 0 && pe.Column <= len(source.dsl)+1 {
+		caret := utf8.RuneCountInString(source.dsl[:pe.Column-1])
+		fmt.Printf("  %s\n  %s^\n", source.dsl, strings.Repeat(" ", caret))
+	}
+	if pe.Suggestion != "" {
+		fmt.Printf("  did you mean %s?\n", pe.Suggestion)
+	}
+	fmt.Println()
+}
+
+func explainExpression(dsl string) {
+	assertion, err := assertions.Parse(dsl)
+	if err != nil {
+		return
+	}
+	fmt.Printf("%s\n", strings.TrimSpace(dsl))
+	for _, line := range strings.Split(assertions.Explain(assertion), "\n") {
+		fmt.Printf("  %s\n", line)
+	}
+	fmt.Println()
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/perceptumx/percepta/internal/assertions"
+	"github.com/perceptumx/percepta/internal/core"
+	"github.com/perceptumx/percepta/internal/diff"
+	perceptaErrors "github.com/perceptumx/percepta/internal/errors"
+	"github.com/perceptumx/percepta/internal/storage"
+)
+
+// Golden snapshot flags: compare with, or re-bless, the device's golden observation
+var (
+	assertMatchesGolden bool
+	assertUpdateGolden  bool
+)
+
+func init() {
+	assertCmd.Flags().BoolVar(&assertMatchesGolden, "matches-golden", false, "Pass only if the observation shows no changes from the device's golden beyond tolerance")
+	assertCmd.Flags().BoolVar(&assertUpdateGolden, "update-golden", false, "With --matches-golden, make the observation the new golden instead of failing on changes")
+}
+
+func validateGoldenFlags() error {
+	if assertUpdateGolden && !assertMatchesGolden {
+		return fmt.Errorf("--update-golden requires --matches-golden")
+	}
+	if !assertMatchesGolden {
+		return nil
+	}
+	if assertSuiteFile != "" || len(assertFiles) > 0 {
+		return fmt.Errorf("--matches-golden cannot be combined with --suite or --file")
+	}
+	if assertUpdateGolden && assertReobserve > 0 {
+		return fmt.Errorf("--reobserve cannot be used with --update-golden")
+	}
+	if assertUpdateGolden && quorumRequested() {
+		return fmt.Errorf("--retries and --require cannot be used with --update-golden")
+	}
+	return nil
+}
+
+// runAssertGolden checks an observation (live, or stored with --observation
+// or --latest) against the device's golden
+func runAssertGolden(deviceID string) error {
+	sqliteStorage, err := storage.NewSQLiteStorage()
+	if err != nil {
+		return perceptaErrors.StorageInitFailed(err)
+	}
+	golden, goldenObs, err := loadGolden(sqliteStorage, deviceID)
+	sqliteStorage.Close()
+
+	if assertUpdateGolden && errors.Is(err, storage.ErrNoGolden) {
+		// Without a golden yet, update mode simply blesses a new one
+		return updateGolden(deviceID, nil)
+	}
+	if err != nil {
+		return err
+	}
+	if assertUpdateGolden {
+		return updateGolden(deviceID, goldenObs)
+	}
+
+	fmt.Fprintf(assertReport.out, "Golden: %s (set %s)\n", describeStoredObservation(goldenObs), golden.SetAt.Format("2006-01-02 15:04:05"))
+	return runAssertOne(deviceID, &assertions.GoldenAssertion{Golden: goldenObs})
+}
+
+// updateGolden makes a fresh (or the selected stored) observation the
+// device's golden, listing what changed from the previous one, and writes
+// the --format report
+func updateGolden(deviceID string, previous *core.Observation) error {
+	var obs *core.Observation
+	if storedObservationRequested() {
+		stored, err := loadStoredObservation(deviceID)
+		if err != nil {
+			return err
+		}
+		obs = stored
+	} else {
+		target, err := openAssertTarget(deviceID)
+		if err != nil {
+			return err
+		}
+		defer target.Close()
+		if obs, err = target.observe(); err != nil {
+			return err
+		}
+	}
+
+	if previous != nil {
+		profile, err := configuredTolerance(deviceID)
+		if err != nil {
+			return err
+		}
+		result := diff.CompareWithTolerance(previous, obs, profile)
+		sort.SliceStable(result.Changes, func(i, j int) bool { return result.Changes[i].Name < result.Changes[j].Name })
+		if result.HasChanges() {
+			fmt.Fprintf(assertReport.out, "Changes from previous golden %s:\n", previous.ID)
+			for _, change := range result.Changes {
+				fmt.Fprintf(assertReport.out, "  %s\n", change)
+			}
+			fmt.Fprintln(assertReport.out)
+		} else {
+			fmt.Fprintf(assertReport.out, "No changes from previous golden %s\n", previous.ID)
+		}
+	}
+
+	sqliteStorage, err := storage.NewSQLiteStorage()
+	if err != nil {
+		return perceptaErrors.StorageInitFailed(err)
+	}
+	defer sqliteStorage.Close()
+	if _, err := sqliteStorage.SetGolden(deviceID, obs.ID); err != nil {
+		return err
+	}
+	fmt.Fprintf(assertReport.out, "⭐ Golden for %s updated to %s\n", deviceID, describeStoredObservation(obs))
+
+	// The report records the blessed observation as a passing golden check
+	assertion := &assertions.GoldenAssertion{Golden: obs}
+	result := assertion.Evaluate(obs)
+	result.ObservationID, result.ObservedAt = obs.ID, obs.Timestamp
+	result.Message = fmt.Sprintf("Golden for %s updated to %s", deviceID, obs.ID)
+	return writeSingleReport(deviceID, obs.FirmwareHash, assertion, result)
+}
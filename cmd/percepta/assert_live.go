@@ -0,0 +1,212 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/perceptumx/percepta/internal/assertions"
+	"github.com/perceptumx/percepta/internal/config"
+	"github.com/perceptumx/percepta/internal/core"
+	perceptaErrors "github.com/perceptumx/percepta/internal/errors"
+	"github.com/perceptumx/percepta/internal/readings"
+	"github.com/perceptumx/percepta/internal/storage"
+	"github.com/perceptumx/percepta/internal/tolerance"
+	"github.com/perceptumx/percepta/pkg/percepta"
+)
+
+// assertTarget bundles the storage and capture pipeline for one configured device
+type assertTarget struct {
+	deviceID      string
+	firmwareTag   string
+	minConfidence float64 // Device threshold, falling back to the global one
+	tolerance     tolerance.Profile
+	patterns      map[string][]readings.Pattern // Display value patterns by display name
+	deviceCfg     config.DeviceConfig
+	timelineLEDs  []string               // LEDs needing a high-rate capture (blink-code and fade assertions)
+	codeLEDs      []string               // LEDs whose blink code is checked
+	bootTiming    bool                   // BootTime assertions need a capture from reset
+	boot          *core.BootTimingSignal // Measured once, then attached to later observations
+	questions     []string               // Free-form questions of Vision assertions, asked on every observation
+	storage       *storage.SQLiteStorage
+	core          *percepta.Core
+}
+
+// openAssertTarget loads the device config and initializes storage and camera
+func openAssertTarget(deviceID string) (*assertTarget, error) {
+	// Load config and initialize Core
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, perceptaErrors.ConfigNotFound()
+	}
+
+	if len(cfg.Devices) == 0 {
+		return nil, perceptaErrors.NoDevicesConfigured()
+	}
+
+	deviceCfg, ok := cfg.Devices[deviceID]
+	if !ok {
+		return nil, perceptaErrors.DeviceNotFound(deviceID)
+	}
+
+	profile, err := deviceTolerance(deviceID, deviceCfg)
+	if err != nil {
+		return nil, err
+	}
+	patterns, err := displayPatterns(deviceID, deviceCfg)
+	if err != nil {
+		return nil, err
+	}
+
+	cameraPath := "/dev/video0"
+	if deviceCfg.CameraID != "" {
+		cameraPath = deviceCfg.CameraID
+	}
+
+	// Initialize SQLite storage
+	sqliteStorage, err := storage.NewSQLiteStorage()
+	if err != nil {
+		return nil, perceptaErrors.StorageInitFailed(err)
+	}
+
+	perceptaCore, err := newCore(cfg, deviceCfg, cameraPath, sqliteStorage)
+	if err != nil {
+		sqliteStorage.Close()
+		return nil, err
+	}
+
+	return &assertTarget{
+		deviceID:      deviceID,
+		firmwareTag:   deviceCfg.Firmware,
+		minConfidence: assertions.ResolveMinConfidence(deviceCfg.MinConfidence, cfg.Assert.MinConfidence),
+		tolerance:     profile,
+		patterns:      patterns,
+		deviceCfg:     deviceCfg,
+		storage:       sqliteStorage,
+		core:          perceptaCore,
+	}, nil
+}
+
+// captureFor makes every observation record the per-frame timelines that the
+// assertions' blink-code and fade checks need and answer their Vision
+// questions, and times the boot when they check boot timing
+func (t *assertTarget) captureFor(all ...assertions.Assertion) {
+	seen := make(map[string]bool)
+	asked := make(map[string]bool)
+	for _, a := range all {
+		t.bootTiming = t.bootTiming || assertions.NeedsBootTiming(a)
+		for _, name := range assertions.TimelineLEDs(a) {
+			if !seen[strings.ToLower(name)] {
+				seen[strings.ToLower(name)] = true
+				t.timelineLEDs = append(t.timelineLEDs, name)
+			}
+		}
+		t.codeLEDs = append(t.codeLEDs, assertions.BlinkCodeLEDs(a)...)
+		for _, question := range assertions.VisionQuestions(a) {
+			if !asked[question] {
+				asked[question] = true
+				t.questions = append(t.questions, question)
+			}
+		}
+	}
+	warnUncalibrated(t.deviceCfg, all...)
+}
+
+// warnUncalibrated warns on stderr about LEDs and displays the assertions
+// refer to that the device's signal map does not name. Devices that were
+// never calibrated get no warning: the vision model names their signals.
+func warnUncalibrated(deviceCfg config.DeviceConfig, all ...assertions.Assertion) {
+	var leds, displays []string
+	for name := range deviceCfg.Regions {
+		leds = append(leds, name)
+	}
+	for name, d := range deviceCfg.Displays {
+		if d.Region != nil {
+			displays = append(displays, name)
+		}
+	}
+	if len(leds) == 0 && len(displays) == 0 {
+		return
+	}
+	sort.Strings(leds)
+	sort.Strings(displays)
+
+	warned := make(map[string]bool)
+	for _, a := range all {
+		for _, u := range assertions.UnknownSignals(a, leds, displays) {
+			if key := strings.ToLower(u.Kind + "." + u.Name); !warned[key] {
+				warned[key] = true
+				fmt.Fprintf(os.Stderr, "⚠️  %s\n", u)
+			}
+		}
+	}
+}
+
+// observe captures a fresh observation, tags it with the firmware and saves it
+func (t *assertTarget) observe() (*core.Observation, error) {
+	var obs *core.Observation
+	var err error
+	if t.bootTiming && t.boot == nil {
+		// Resetting the device again for every re-observation would restart
+		// the sequence under test, so the boot is timed once
+		opts, optsErr := bootOptions(t.deviceID, t.deviceCfg, "")
+		if optsErr != nil {
+			return nil, optsErr
+		}
+		opts.Timelines = t.timelines()
+		obs, err = t.core.ObserveBoot(t.deviceID, opts)
+		if err == nil {
+			if boot, ok := bootTiming(obs); ok {
+				t.boot = &boot
+			}
+		}
+	} else if opts := t.timelines(); opts != nil {
+		obs, err = t.core.ObserveWithTimelines(t.deviceID, *opts)
+	} else {
+		obs, err = t.core.Observe(t.deviceID)
+	}
+	if err != nil {
+		return nil, perceptaErrors.ObservationFailed(err)
+	}
+	if _, ok := bootTiming(obs); !ok && t.boot != nil {
+		obs.Signals = append(obs.Signals, *t.boot)
+	}
+	if err := t.core.AnswerQuestions(obs, t.questions); err != nil {
+		return nil, perceptaErrors.ObservationFailed(err)
+	}
+
+	// Inject firmware tag and configured display readings, then save
+	obs.FirmwareHash = t.firmwareTag
+	readings.Apply(obs, t.patterns)
+	if err := t.storage.Save(*obs); err != nil {
+		return nil, fmt.Errorf("failed to save observation: %w", err)
+	}
+
+	return obs, nil
+}
+
+// timelines returns the timeline capture the assertions need, or nil when
+// none checks a blink code or fade
+func (t *assertTarget) timelines() *percepta.TimelineOptions {
+	if len(t.timelineLEDs) == 0 {
+		return nil
+	}
+	opts := timelineOptions(t.deviceCfg, t.timelineLEDs)
+	opts.Codes = t.codeLEDs
+	return &opts
+}
+
+func (t *assertTarget) Close() {
+	t.storage.Close()
+}
+
+// bootTiming returns the observation's boot timing signal, if any
+func bootTiming(obs *core.Observation) (core.BootTimingSignal, bool) {
+	for _, signal := range obs.Signals {
+		if boot, ok := signal.(core.BootTimingSignal); ok {
+			return boot, true
+		}
+	}
+	return core.BootTimingSignal{}, false
+}
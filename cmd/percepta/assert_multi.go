@@ -0,0 +1,111 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/perceptumx/percepta/internal/assertions"
+)
+
+// assertFiles are text files of assertion expressions, one per line
+var assertFiles []string
+
+func init() {
+	assertCmd.Flags().StringArrayVar(&assertFiles, "file", nil, "Text file of assertion expressions, one per line (repeatable)")
+}
+
+// assertExpressions collects the positional expressions followed by those
+// read from --file, in order
+func assertExpressions(positional []string) ([]string, error) {
+	expressions := append([]string(nil), positional...)
+	for _, path := range assertFiles {
+		loaded, err := assertions.LoadExpressions(path)
+		if err != nil {
+			return nil, err
+		}
+		expressions = append(expressions, loaded...)
+	}
+	return expressions, nil
+}
+
+// runAssertMany evaluates several expressions against one shared observation
+// and prints a combined table
+func runAssertMany(deviceID string, expressions []string) error {
+	suite, err := assertions.ExpressionSuite(deviceID, expressions)
+	if err != nil {
+		return err
+	}
+
+	var result assertions.SuiteResult
+	var firmware string
+	if storedObservationRequested() {
+		obs, err := loadStoredObservation(deviceID)
+		if err != nil {
+			return err
+		}
+		printStoredObservation(assertReport.out, obs)
+		if result, err = runSuiteStored(suite, deviceID, obs); err != nil {
+			return err
+		}
+		firmware = obs.FirmwareHash
+	} else {
+		fmt.Fprintf(assertReport.out, "Evaluating %d assertions on %s (one observation)\n\n", len(expressions), deviceID)
+		if result, firmware, err = runSuiteLive(suite, deviceID); err != nil {
+			return err
+		}
+	}
+
+	printAssertionTable(assertReport.out, suite.Cases[0].Compiled(), result.Cases[0])
+	if err := writeSuiteReport(deviceID, firmware, suite, result); err != nil {
+		return err
+	}
+
+	exitForOutcome(result.Outcome())
+
+	return nil
+}
+
+// printAssertionTable prints one row per assertion: outcome, expression,
+// confidence and, for assertions that did not pass, what was observed
+func printAssertionTable(w io.Writer, compiled []assertions.Assertion, c assertions.CaseResult) {
+	width := len("ASSERTION")
+	for _, a := range compiled {
+		width = max(width, utf8.RuneCountInString(a.String()))
+	}
+
+	fmt.Fprintf(w, "%-14s %-*s  %s  %s\n", "RESULT", width, "ASSERTION", "CONF", "DETAILS")
+	passed, failed, inconclusive := 0, 0, 0
+	for i, a := range compiled {
+		if i >= len(c.Results) {
+			// Observation failed before this assertion was evaluated
+			fmt.Fprintf(w, "%-14s %-*s  %4s  %v\n", "✗ ERROR", width, a.String(), "-", c.Err)
+			failed++
+			continue
+		}
+
+		r := c.Results[i]
+		details := ""
+		switch r.Outcome() {
+		case assertions.OutcomePass:
+			passed++
+		case assertions.OutcomeFail:
+			failed++
+			details = r.Message
+		case assertions.OutcomeInconclusive:
+			inconclusive++
+			details = r.Message
+		}
+		row := fmt.Sprintf("%-14s %-*s  %.2f  %s", outcomeMark(r.Outcome())+" "+string(r.Outcome()), width, a.String(), r.Confidence, details)
+		fmt.Fprintln(w, strings.TrimRight(row, " "))
+		if len(r.Attempts) > 0 {
+			fmt.Fprintf(w, "%-14s %-*s  votes: %s\n", "", width, "", formatVotes(r))
+		}
+	}
+
+	fmt.Fprintf(w, "\nSummary: %d passed, %d failed, %d inconclusive (%d assertions)\n", passed, failed, inconclusive, len(compiled))
+	if c.ObservationID != "" {
+		fmt.Fprintf(w, "Observation: %s\n", c.ObservationID)
+	}
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/perceptumx/percepta/internal/assertions"
+	"github.com/perceptumx/percepta/internal/report"
+)
+
+// Report flags: machine-readable results for CI
+var (
+	assertFormat     string
+	assertReportFile string
+)
+
+func init() {
+	assertCmd.Flags().StringVar(&assertFormat, "format", "text", "Output format: text, json, junit or tap")
+	assertCmd.Flags().StringVar(&assertReportFile, "report-file", "", "Also write the report to this file (format from --format, else the .json/.xml/.tap extension)")
+}
+
+// assertReport is the resolved --format/--report-file for the current run
+var assertReport struct {
+	format  report.Format
+	stdout  io.Writer // Report destination when no file is given; nil for text output
+	out     io.Writer // Human-readable output
+	started time.Time
+}
+
+// startAssertReport validates the report flags and starts the run's clock.
+// A report on stdout moves the human-readable output to stderr, so stdout
+// carries nothing but the report.
+func startAssertReport() error {
+	assertReport.started = time.Now()
+	assertReport.stdout = nil
+	assertReport.out = os.Stdout
+
+	format, err := report.ParseFormat(assertFormat)
+	if err != nil {
+		return err
+	}
+
+	if assertReportFile != "" {
+		if format == report.FormatText {
+			if format = report.FormatForPath(assertReportFile); format == "" {
+				return fmt.Errorf("cannot tell the report format of %s: use --format json, junit or tap", assertReportFile)
+			}
+		}
+		assertReport.format = format
+		return nil
+	}
+
+	assertReport.format = format
+	if format != report.FormatText {
+		assertReport.stdout = os.Stdout
+		assertReport.out = os.Stderr
+	}
+	return nil
+}
+
+// writeAssertReport writes the run's report, if one was requested
+func writeAssertReport(r *report.Report) error {
+	if assertReportFile == "" {
+		if assertReport.stdout == nil {
+			return nil
+		}
+		return r.Write(assertReport.stdout, assertReport.format)
+	}
+
+	f, err := os.Create(assertReportFile)
+	if err != nil {
+		return fmt.Errorf("failed to create report file: %w", err)
+	}
+	if err := r.Write(f, assertReport.format); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write %s report: %w", assertReport.format, err)
+	}
+	return f.Close()
+}
+
+// writeSuiteReport reports every case of a suite run
+func writeSuiteReport(deviceID, firmware string, suite *assertions.Suite, result assertions.SuiteResult) error {
+	compiled := make([][]assertions.Assertion, len(suite.Cases))
+	for i := range suite.Cases {
+		compiled[i] = suite.Cases[i].Compiled()
+	}
+	r := report.New(deviceID, firmware, result, compiled, assertReport.started, time.Since(assertReport.started))
+	r.Suite = assertSuiteFile
+	return writeAssertReport(r)
+}
+
+// writeSingleReport reports a single assertion run
+func writeSingleReport(deviceID, firmware string, assertion assertions.Assertion, result assertions.AssertionResult) error {
+	return writeAssertReport(report.Single(deviceID, firmware, assertion, result, assertReport.started, time.Since(assertReport.started)))
+}
@@ -0,0 +1,171 @@
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/perceptumx/percepta/internal/assertions"
+	"github.com/perceptumx/percepta/internal/config"
+	"github.com/perceptumx/percepta/internal/core"
+	perceptaErrors "github.com/perceptumx/percepta/internal/errors"
+	"github.com/perceptumx/percepta/internal/readings"
+	"github.com/perceptumx/percepta/internal/storage"
+	"github.com/perceptumx/percepta/internal/tolerance"
+)
+
+// Stored-observation flags: evaluate against history instead of the camera
+var (
+	assertObservationID string
+	assertFirmware      string
+	assertLatest        bool
+)
+
+func init() {
+	assertCmd.Flags().StringVar(&assertObservationID, "observation", "", "Evaluate against a stored observation by ID (no camera or API calls)")
+	assertCmd.Flags().StringVar(&assertFirmware, "firmware", "", "With --latest, use the latest stored observation for this firmware tag")
+	assertCmd.Flags().BoolVar(&assertLatest, "latest", false, "Evaluate against the device's latest stored observation (no camera or API calls)")
+}
+
+// storedObservationRequested reports whether assertions should run offline
+func storedObservationRequested() bool {
+	return assertObservationID != "" || assertLatest || assertFirmware != ""
+}
+
+func validateStoredFlags() error {
+	if assertObservationID != "" && (assertLatest || assertFirmware != "") {
+		return fmt.Errorf("--observation cannot be combined with --latest or --firmware")
+	}
+	if assertFirmware != "" && !assertLatest {
+		return fmt.Errorf("--firmware selects a stored observation and requires --latest")
+	}
+	if assertReobserve > 0 {
+		return fmt.Errorf("--reobserve needs a live camera and cannot be used with stored observations")
+	}
+	if quorumRequested() {
+		return fmt.Errorf("--retries and --require need a live camera and cannot be used with stored observations")
+	}
+	return nil
+}
+
+// loadStoredObservation loads the observation selected by --observation or --latest [--firmware]
+func loadStoredObservation(deviceID string) (*core.Observation, error) {
+	if err := validateStoredFlags(); err != nil {
+		return nil, err
+	}
+
+	sqliteStorage, err := storage.NewSQLiteStorage()
+	if err != nil {
+		return nil, perceptaErrors.StorageInitFailed(err)
+	}
+	defer sqliteStorage.Close()
+
+	var obs *core.Observation
+	switch {
+	case assertObservationID != "":
+		obs, err = sqliteStorage.GetByID(assertObservationID)
+	case assertFirmware != "":
+		obs, err = sqliteStorage.GetLatestForFirmware(deviceID, assertFirmware)
+	default:
+		obs, err = sqliteStorage.GetLatest(deviceID)
+	}
+	if err != nil {
+		return nil, perceptaErrors.StoredObservationNotFound(err)
+	}
+
+	if obs.DeviceID != deviceID {
+		return nil, fmt.Errorf("observation %s was captured on device '%s', not '%s'", obs.ID, obs.DeviceID, deviceID)
+	}
+
+	// Parse readings with the current display patterns, which may be newer than the observation
+	if cfg, err := config.Load(); err == nil {
+		patterns, err := displayPatterns(deviceID, cfg.Devices[deviceID])
+		if err != nil {
+			return nil, err
+		}
+		readings.Apply(obs, patterns)
+	}
+
+	return obs, nil
+}
+
+// configuredMinConfidence resolves the device and global thresholds from config.
+// Offline evaluation does not require the device to be configured.
+func configuredMinConfidence(deviceID string) float64 {
+	cfg, err := config.Load()
+	if err != nil {
+		return 0
+	}
+	return assertions.ResolveMinConfidence(cfg.Devices[deviceID].MinConfidence, cfg.Assert.MinConfidence)
+}
+
+// deviceTolerance merges a device's configured tolerances over the defaults
+func deviceTolerance(deviceID string, deviceCfg config.DeviceConfig) (tolerance.Profile, error) {
+	profile := tolerance.Merge(tolerance.Overrides{
+		BlinkPercent:     deviceCfg.Tolerance.BlinkPercent,
+		ColorMetric:      tolerance.Metric(deviceCfg.Tolerance.ColorMetric),
+		ColorThreshold:   deviceCfg.Tolerance.ColorThreshold,
+		OCRFuzziness:     deviceCfg.Tolerance.OCRFuzziness,
+		BrightnessPoints: deviceCfg.Tolerance.BrightnessPoints,
+	})
+	if err := profile.Validate(); err != nil {
+		return profile, fmt.Errorf("invalid tolerance for device '%s': %w", deviceID, err)
+	}
+	return profile, nil
+}
+
+// displayPatterns compiles the device's per-display value patterns
+func displayPatterns(deviceID string, deviceCfg config.DeviceConfig) (map[string][]readings.Pattern, error) {
+	patterns := make(map[string][]readings.Pattern, len(deviceCfg.Displays))
+	for name, display := range deviceCfg.Displays {
+		compiled, err := readings.Compile(display.Values)
+		if err != nil {
+			return nil, fmt.Errorf("invalid values for display '%s' of device '%s': %w", name, deviceID, err)
+		}
+		patterns[name] = compiled
+	}
+	return patterns, nil
+}
+
+// configuredTolerance resolves the device's tolerance profile from config.
+// Like configuredMinConfidence, an unconfigured device gets the defaults.
+func configuredTolerance(deviceID string) (tolerance.Profile, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return tolerance.Default(), nil
+	}
+	return deviceTolerance(deviceID, cfg.Devices[deviceID])
+}
+
+func printStoredObservation(w io.Writer, obs *core.Observation) {
+	fmt.Fprintf(w, "Using stored observation %s\n\n", describeStoredObservation(obs))
+}
+
+// runAssertStored evaluates an assertion against a stored observation
+func runAssertStored(deviceID string, assertion assertions.Assertion) error {
+	obs, err := loadStoredObservation(deviceID)
+	if err != nil {
+		return err
+	}
+	printStoredObservation(assertReport.out, obs)
+
+	if assertions.IsTemporal(assertion) {
+		fmt.Fprintln(assertReport.out, "Note: temporal operators are checked against this single stored observation")
+		fmt.Fprintln(assertReport.out)
+	}
+
+	profile, err := configuredTolerance(deviceID)
+	if err != nil {
+		return err
+	}
+
+	minConfidence := assertions.ResolveMinConfidence(assertMinConfidence, configuredMinConfidence(deviceID))
+	result := assertions.EvaluateSnapshot(assertions.WithMinConfidence(assertions.WithTolerance(assertion, profile), minConfidence), obs)
+
+	printAssertionResult(assertReport.out, assertion, result)
+	if err := writeSingleReport(deviceID, obs.FirmwareHash, assertion, result); err != nil {
+		return err
+	}
+	exitForOutcome(result.Outcome())
+
+	return nil
+}
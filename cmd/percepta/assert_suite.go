@@ -0,0 +1,160 @@
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/perceptumx/percepta/internal/assertions"
+	"github.com/perceptumx/percepta/internal/core"
+	"github.com/perceptumx/percepta/internal/ui"
+)
+
+// runAssertSuite executes every case in a suite file against one device
+func runAssertSuite(args []string) error {
+	suite, err := assertions.LoadSuite(assertSuiteFile)
+	if err != nil {
+		return fmt.Errorf("invalid suite: %w", err)
+	}
+
+	// Device argument overrides the suite's device
+	deviceID := suite.Device
+	if len(args) > 0 {
+		deviceID = args[0]
+	}
+	if deviceID == "" {
+		return fmt.Errorf("no device given: pass one as an argument or set 'device' in %s", assertSuiteFile)
+	}
+
+	if storedObservationRequested() {
+		return runAssertSuiteStored(suite, deviceID)
+	}
+
+	fmt.Fprintf(assertReport.out, "Suite: %s (%d cases) on %s\n", assertSuiteFile, len(suite.Cases), deviceID)
+	if suite.Setup != "" {
+		fmt.Fprintf(assertReport.out, "Setup: %s\n", suite.Setup)
+	}
+	fmt.Fprintln(assertReport.out)
+
+	result, firmware, err := runSuiteLive(suite, deviceID)
+	if err != nil {
+		return err
+	}
+
+	printSuiteResult(assertReport.out, suite, result)
+	if err := writeSuiteReport(deviceID, firmware, suite, result); err != nil {
+		return err
+	}
+
+	exitForOutcome(result.Outcome())
+
+	return nil
+}
+
+// runSuiteLive captures observations from the device's camera and evaluates
+// every case, returning the results and the device's firmware tag. Flags
+// override the suite's settings, which override device and global config.
+func runSuiteLive(suite *assertions.Suite, deviceID string) (assertions.SuiteResult, string, error) {
+	target, err := openAssertTarget(deviceID)
+	if err != nil {
+		return assertions.SuiteResult{}, "", err
+	}
+	defer target.Close()
+	for i := range suite.Cases {
+		target.captureFor(suite.Cases[i].Compiled()...)
+	}
+
+	suite.MinConfidence = assertions.ResolveMinConfidence(assertMinConfidence, suite.MinConfidence, target.minConfidence)
+	if assertReobserve > 0 {
+		suite.Reobserve = assertReobserve
+	}
+	if quorumRequested() {
+		if suite.Quorum, err = assertQuorum(); err != nil {
+			return assertions.SuiteResult{}, "", err
+		}
+	}
+	suite.Tolerance = &target.tolerance
+
+	result := suite.Run(deviceID, func(c *assertions.SuiteCase, resample bool) (*core.Observation, error) {
+		// Only a case's first observation announces its setup and shows a spinner
+		if resample {
+			return target.observe()
+		}
+
+		label := "shared observation"
+		if c != nil {
+			label = c.Name
+			if c.Setup != "" {
+				fmt.Fprintf(assertReport.out, "[%s] setup: %s\n", c.Name, c.Setup)
+			}
+		}
+
+		spinner := ui.NewSpinner(fmt.Sprintf("Observing %s (%s)...", deviceID, label))
+		obs, err := target.observe()
+		spinner.Stop(err == nil)
+		return obs, err
+	})
+	return result, target.firmwareTag, nil
+}
+
+// runAssertSuiteStored evaluates every case against one stored observation
+func runAssertSuiteStored(suite *assertions.Suite, deviceID string) error {
+	obs, err := loadStoredObservation(deviceID)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(assertReport.out, "Suite: %s (%d cases) on %s\n", assertSuiteFile, len(suite.Cases), deviceID)
+	printStoredObservation(assertReport.out, obs)
+
+	result, err := runSuiteStored(suite, deviceID, obs)
+	if err != nil {
+		return err
+	}
+	printSuiteResult(assertReport.out, suite, result)
+	if err := writeSuiteReport(deviceID, obs.FirmwareHash, suite, result); err != nil {
+		return err
+	}
+
+	exitForOutcome(result.Outcome())
+
+	return nil
+}
+
+// runSuiteStored evaluates every case against a stored observation using the
+// device's configured threshold and tolerances
+func runSuiteStored(suite *assertions.Suite, deviceID string, obs *core.Observation) (assertions.SuiteResult, error) {
+	suite.MinConfidence = assertions.ResolveMinConfidence(assertMinConfidence, suite.MinConfidence, configuredMinConfidence(deviceID))
+	profile, err := configuredTolerance(deviceID)
+	if err != nil {
+		return assertions.SuiteResult{}, err
+	}
+	suite.Tolerance = &profile
+
+	return suite.RunStored(deviceID, obs), nil
+}
+
+func printSuiteResult(w io.Writer, suite *assertions.Suite, result assertions.SuiteResult) {
+	fmt.Fprintln(w)
+	for i, c := range result.Cases {
+		fmt.Fprintf(w, "%s: %s\n", outcomeLabel(c.Outcome()), c.Name)
+
+		if c.Err != nil {
+			fmt.Fprintf(w, "    error: %v\n", c.Err)
+			continue
+		}
+
+		compiled := suite.Cases[i].Compiled()
+		for j, r := range c.Results {
+			fmt.Fprintf(w, "    %s %s [%.2f]\n", outcomeMark(r.Outcome()), compiled[j].String(), r.Confidence)
+			if !r.Passed {
+				fmt.Fprintf(w, "        %s\n", r.Message)
+			}
+			if len(r.Attempts) > 0 {
+				fmt.Fprintf(w, "        votes: %s\n", formatVotes(r))
+			}
+		}
+	}
+
+	passed, failed, inconclusive := result.Counts()
+	fmt.Fprintf(w, "\nSummary: %d passed, %d failed, %d inconclusive (%d cases)\n", passed, failed, inconclusive, len(result.Cases))
+}
@@ -4,6 +4,7 @@ import (
 	"bufio"
 	"fmt"
 	"os"
+	"sort"
 	"strings"
 
 	"github.com/perceptumx/percepta/internal/config"
@@ -27,7 +28,10 @@ Examples:
   percepta device list
 
   # Set firmware version
-  percepta device set-firmware my-esp32 v1.0.0`,
+  percepta device set-firmware my-esp32 v1.0.0
+
+  # Name the LEDs and displays by their position in the camera frame
+  percepta device calibrate my-esp32`,
 }
 
 var deviceListCmd = &cobra.Command{
@@ -35,7 +39,8 @@ var deviceListCmd = &cobra.Command{
 	Short: "List all configured devices",
 	Long: `Displays all devices configured in ~/.config/percepta/config.yaml.
 
-Shows device name, type, camera path, and firmware tag for each device.`,
+Shows device name, type, camera path, firmware tag and calibrated signals
+for each device.`,
 	RunE: runDeviceList,
 }
 
@@ -102,12 +107,31 @@ func runDeviceList(cmd *cobra.Command, args []string) error {
 		if dev.Firmware != "" {
 			fmt.Printf("  Firmware: %s\n", dev.Firmware)
 		}
+		if signals := calibratedSignals(dev); len(signals) > 0 {
+			fmt.Printf("  Signals: %s\n", strings.Join(signals, ", "))
+		}
 		fmt.Println()
 	}
 
 	return nil
 }
 
+// calibratedSignals lists the device's signal map as LED.name and
+// Display.name, sorted
+func calibratedSignals(dev config.DeviceConfig) []string {
+	var signals []string
+	for name := range dev.Regions {
+		signals = append(signals, "LED."+name)
+	}
+	for name, d := range dev.Displays {
+		if d.Region != nil {
+			signals = append(signals, "Display."+name)
+		}
+	}
+	sort.Strings(signals)
+	return signals
+}
+
 func runDeviceAdd(cmd *cobra.Command, args []string) error {
 	deviceName := args[0]
 
@@ -0,0 +1,339 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"os"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/perceptumx/percepta/internal/camera"
+	"github.com/perceptumx/percepta/internal/config"
+	perceptaErrors "github.com/perceptumx/percepta/internal/errors"
+	"github.com/perceptumx/percepta/internal/vision"
+	"github.com/spf13/cobra"
+)
+
+var (
+	calibrateOutput string
+	calibrateWarmup int
+)
+
+// signalNamePattern is what an LED or display name may contain to be usable
+// in assertions (LED.<name>, Display.<name>)
+var signalNamePattern = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
+
+var deviceCalibrateCmd = &cobra.Command{
+	Use:   "calibrate <device>",
+	Short: "Name the device's LEDs and displays by their position in the frame",
+	Long: `Capture a reference frame, propose the bright spots in it as LEDs, and
+save the names you give them, and the displays you mark, as the device's
+signal map.
+
+Power the device up so that the LEDs you want to name are lit. Candidates are
+outlined in the annotated frame written to --output and listed in reading
+order with their position and color. Name each one (power, status, wifi...),
+press Enter to keep the name already calibrated there or to skip it, or type
+'-' to drop that calibrated LED. Then mark displays as x,y,w,h pixel
+rectangles.
+
+With a signal map, the local and hybrid vision providers measure the named
+LED regions, Claude reports signals under the calibrated names instead of
+LED1, LED2..., and 'percepta assert' warns about names the map lacks.
+Calibrated LEDs that are unlit in the new reference frame are kept.
+
+Examples:
+  percepta device calibrate my-esp32
+  percepta device calibrate my-esp32 --output /tmp/board.jpg`,
+	Args: cobra.ExactArgs(1),
+	RunE: runDeviceCalibrate,
+}
+
+func init() {
+	deviceCalibrateCmd.Flags().StringVar(&calibrateOutput, "output", "", "where to write the annotated reference frame (default: <device>-calibration.jpg)")
+	deviceCalibrateCmd.Flags().IntVar(&calibrateWarmup, "warmup", 5, "frames to discard while the camera settles its exposure")
+	deviceCmd.AddCommand(deviceCalibrateCmd)
+}
+
+func runDeviceCalibrate(cmd *cobra.Command, args []string) error {
+	deviceName := args[0]
+
+	cfg, err := config.Load()
+	if err != nil {
+		return perceptaErrors.ConfigNotFound()
+	}
+	deviceCfg, ok := cfg.Devices[deviceName]
+	if !ok {
+		return perceptaErrors.DeviceNotFound(deviceName)
+	}
+	cameraPath := "/dev/video0"
+	if deviceCfg.CameraID != "" {
+		cameraPath = deviceCfg.CameraID
+	}
+
+	frame, err := captureReference(cameraPath, calibrateWarmup)
+	if err != nil {
+		return err
+	}
+	size, _, err := image.DecodeConfig(bytes.NewReader(frame))
+	if err != nil {
+		return fmt.Errorf("frame decode failed: %w", err)
+	}
+	bounds := image.Rect(0, 0, size.Width, size.Height)
+
+	blobs, err := vision.FindBlobs(frame)
+	if err != nil {
+		return err
+	}
+	output := calibrateOutput
+	if output == "" {
+		output = deviceName + "-calibration.jpg"
+	}
+	boxes := make([]image.Rectangle, len(blobs))
+	for i, b := range blobs {
+		boxes[i] = b.Region
+	}
+	annotated, err := vision.DrawBoxes(frame, boxes, color.RGBA{R: 255, G: 0, B: 255, A: 255})
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(output, annotated, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", output, err)
+	}
+
+	fmt.Printf("Reference frame: %dx%d, candidates outlined in %s\n\n", size.Width, size.Height, output)
+	scanner := bufio.NewScanner(os.Stdin)
+
+	// Name the candidate LEDs
+	regions := make(map[string]config.Region, len(deviceCfg.Regions))
+	for name, r := range deviceCfg.Regions {
+		regions[name] = r
+	}
+	named := make(map[string]bool)
+	if len(blobs) == 0 {
+		fmt.Println("No lit LEDs found. Power the device up, or dim the room, and calibrate again.")
+	} else {
+		fmt.Printf("Found %d candidate LED(s):\n", len(blobs))
+		for i, b := range blobs {
+			fmt.Printf("  %2d. at %d,%d (%dx%d)  %s, %d%% brightness\n", i+1, b.Region.Min.X, b.Region.Min.Y, b.Region.Dx(), b.Region.Dy(), b.ColorName, b.Brightness)
+		}
+		fmt.Println()
+	}
+	for i, b := range blobs {
+		existing := overlappingRegions(regions, b.Region)
+		prompt := fmt.Sprintf("Name for #%d (Enter to skip): ", i+1)
+		if len(existing) > 0 {
+			prompt = fmt.Sprintf("Name for #%d [%s]: ", i+1, existing[0])
+		}
+		var name string
+		for {
+			if name, err = readName(scanner, prompt); err != nil {
+				return err
+			}
+			if name == "" && len(existing) > 0 {
+				name = existing[0]
+			}
+			if name == "" || name == "-" {
+				break
+			}
+			if named[name] {
+				fmt.Printf("  '%s' already names another LED in this calibration\n", name)
+				continue
+			}
+			r, ok := regions[name]
+			if !ok || slices.Contains(existing, name) {
+				break
+			}
+			answer, err := readLine(scanner, fmt.Sprintf("  '%s' is calibrated at %d,%d,%d,%d; move it here? [y/N]: ", name, r.X, r.Y, r.W, r.H))
+			if err != nil {
+				return err
+			}
+			if strings.EqualFold(answer, "y") || strings.EqualFold(answer, "yes") {
+				break
+			}
+		}
+
+		switch {
+		case name == "-":
+			for _, old := range existing {
+				delete(regions, old)
+			}
+		case name == "":
+			// Skipped
+		default:
+			for _, old := range existing {
+				delete(regions, old)
+			}
+			regions[name] = regionOf(b.Region)
+			named[name] = true
+		}
+	}
+
+	// Mark displays
+	displays := make(map[string]config.DisplayConfig, len(deviceCfg.Displays))
+	for name, d := range deviceCfg.Displays {
+		displays[name] = d
+	}
+	fmt.Println()
+	for {
+		name, err := readName(scanner, "Display name to mark (Enter to finish): ")
+		if err != nil {
+			return err
+		}
+		if name == "" {
+			break
+		}
+		for {
+			d := displays[name]
+			current := ""
+			if d.Region != nil {
+				current = fmt.Sprintf(" [%d,%d,%d,%d]", d.Region.X, d.Region.Y, d.Region.W, d.Region.H)
+			}
+			text, err := readLine(scanner, fmt.Sprintf("Region of '%s' as x,y,w,h%s: ", name, current))
+			if err != nil {
+				return err
+			}
+			if text == "" && d.Region != nil {
+				break
+			}
+			region, err := parseRegion(text, bounds)
+			if err != nil {
+				fmt.Printf("  %v\n", err)
+				continue
+			}
+			d.Region = &region
+			displays[name] = d
+			break
+		}
+	}
+
+	// Summary
+	fmt.Printf("\nSignal map for '%s':\n", deviceName)
+	names := make([]string, 0, len(regions))
+	for name := range regions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		r := regions[name]
+		note := ""
+		if !named[name] {
+			note = "  (kept: not lit in the reference frame)"
+		}
+		fmt.Printf("  LED.%s: %d,%d,%d,%d%s\n", name, r.X, r.Y, r.W, r.H, note)
+	}
+	names = names[:0]
+	for name, d := range displays {
+		if d.Region != nil {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		r := displays[name].Region
+		fmt.Printf("  Display.%s: %d,%d,%d,%d\n", name, r.X, r.Y, r.W, r.H)
+	}
+
+	deviceCfg.Regions = regions
+	deviceCfg.Displays = displays
+	if len(displays) == 0 {
+		deviceCfg.Displays = nil
+	}
+	cfg.Devices[deviceName] = deviceCfg
+	if err := saveConfig(cfg); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+
+	fmt.Printf("\n✓ Calibration saved for '%s'\n", deviceName)
+	return nil
+}
+
+// readLine prompts for one line of input. Input ending before calibration is
+// done is an error, so a closed stdin neither loops nor saves a partial map.
+func readLine(scanner *bufio.Scanner, prompt string) (string, error) {
+	fmt.Print(prompt)
+	if !scanner.Scan() {
+		if err := scanner.Err(); err != nil {
+			return "", fmt.Errorf("failed to read input: %w", err)
+		}
+		return "", fmt.Errorf("input ended before calibration finished; nothing was saved")
+	}
+	return strings.TrimSpace(scanner.Text()), nil
+}
+
+// readName prompts until the answer is empty or a valid signal name
+func readName(scanner *bufio.Scanner, prompt string) (string, error) {
+	for {
+		name, err := readLine(scanner, prompt)
+		if err != nil || name == "" || signalNamePattern.MatchString(name) {
+			return name, err
+		}
+		fmt.Printf("  '%s' is not a valid name: use letters, digits, '_' and '-'\n", name)
+	}
+}
+
+// captureReference opens the camera and returns the frame after discarding
+// warmup frames, which auto exposure may still be adjusting
+func captureReference(cameraPath string, warmup int) ([]byte, error) {
+	cam := camera.NewCamera(cameraPath)
+	if err := cam.Open(); err != nil {
+		if camera.IsFileCamera(cameraPath) {
+			return nil, err
+		}
+		return nil, perceptaErrors.CameraNotFound(cameraPath)
+	}
+	defer cam.Close()
+
+	var frame []byte
+	var err error
+	for i := 0; i <= max(warmup, 0); i++ {
+		if frame, err = cam.CaptureFrame(); err != nil {
+			return nil, fmt.Errorf("frame capture failed: %w", err)
+		}
+	}
+	return frame, nil
+}
+
+// overlappingRegions lists the calibrated LEDs whose region overlaps rect,
+// sorted by name
+func overlappingRegions(regions map[string]config.Region, rect image.Rectangle) []string {
+	var names []string
+	for name, r := range regions {
+		if rect.Overlaps(image.Rect(r.X, r.Y, r.X+r.W, r.Y+r.H)) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+func regionOf(rect image.Rectangle) config.Region {
+	return config.Region{X: rect.Min.X, Y: rect.Min.Y, W: rect.Dx(), H: rect.Dy()}
+}
+
+// parseRegion reads "x,y,w,h" and checks that the rectangle lies in the frame
+func parseRegion(text string, frame image.Rectangle) (config.Region, error) {
+	parts := strings.Split(text, ",")
+	if len(parts) != 4 {
+		return config.Region{}, fmt.Errorf("expected four numbers x,y,w,h, e.g. 40,120,200,64")
+	}
+	var v [4]int
+	for i, p := range parts {
+		n, err := strconv.Atoi(strings.TrimSpace(p))
+		if err != nil {
+			return config.Region{}, fmt.Errorf("'%s' is not a whole number of pixels", strings.TrimSpace(p))
+		}
+		v[i] = n
+	}
+	rect := image.Rect(v[0], v[1], v[0]+v[2], v[1]+v[3])
+	if v[2] <= 0 || v[3] <= 0 || !rect.In(frame) {
+		return config.Region{}, fmt.Errorf("the region must have a positive size and lie within the %dx%d frame", frame.Dx(), frame.Dy())
+	}
+	return regionOf(rect), nil
+}
@@ -30,7 +30,10 @@ Examples:
 Exit codes:
   0 - No differences detected (behavior identical)
   1 - Differences detected
-  2 - Error (device not found, firmware tag missing, etc.)`,
+  2 - Error (device not found, firmware tag missing, etc.)
+
+Blink-rate, color and display-text differences within the device's
+tolerance profile (devices.<id>.tolerance in config) are not reported.`,
 	Args: cobra.ExactArgs(1),
 	RunE: runDiff,
 }
@@ -76,8 +79,12 @@ func runDiff(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to get observation for firmware '%s': %w", diffToFlag, err)
 	}
 
-	// Compare observations
-	result := diff.Compare(fromObs, toObs)
+	// Compare observations, ignoring noise within the device's tolerances
+	profile, err := configuredTolerance(deviceID)
+	if err != nil {
+		return err
+	}
+	result := diff.CompareWithTolerance(fromObs, toObs, profile)
 
 	// Print results
 	printDiffResult(result)
@@ -110,20 +117,7 @@ func printDiffResult(result *diff.DiffResult) {
 
 	// Print each change with appropriate indicator
 	for _, change := range result.Changes {
-		switch change.Type {
-		case diff.ChangeAdded:
-			fmt.Printf("+ %s: %s (ADDED)\n", change.Name, change.ToState)
-
-		case diff.ChangeRemoved:
-			fmt.Printf("- %s: %s (REMOVED)\n", change.Name, change.FromState)
-
-		case diff.ChangeModified:
-			if change.Details != "" {
-				fmt.Printf("~ %s: %s (%s)\n", change.Name, change.Details, "MODIFIED")
-			} else {
-				fmt.Printf("~ %s: %s → %s (MODIFIED)\n", change.Name, change.FromState, change.ToState)
-			}
-		}
+		fmt.Println(change)
 	}
 
 	// Summary
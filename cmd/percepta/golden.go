@@ -0,0 +1,174 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/perceptumx/percepta/internal/core"
+	"github.com/perceptumx/percepta/internal/diff"
+	perceptaErrors "github.com/perceptumx/percepta/internal/errors"
+	"github.com/perceptumx/percepta/internal/storage"
+	"github.com/spf13/cobra"
+)
+
+var goldenCmd = &cobra.Command{
+	Use:   "golden",
+	Short: "Manage golden observations for snapshot assertions",
+	Long: `Mark a stored observation as a device's golden snapshot.
+
+'percepta assert <device> --matches-golden' captures a fresh observation and
+passes only if it shows no changes from the golden beyond the device's
+tolerance profile. Each device has at most one golden.
+
+Examples:
+  # Bless the device's latest observation (or a specific one)
+  percepta golden set my-board
+  percepta golden set my-board obs-20260115-103000
+
+  # Bless the latest observation of a firmware tag
+  percepta golden set my-board --firmware v1.2
+
+  # Inspect or remove the golden
+  percepta golden show my-board
+  percepta golden clear my-board`,
+}
+
+var goldenSetCmd = &cobra.Command{
+	Use:   "set <device> [observation-id]",
+	Short: "Mark a stored observation as the device's golden",
+	Long: `Marks a stored observation as the device's golden, replacing any previous one.
+
+Without an observation ID the device's latest stored observation is used,
+or with --firmware the latest one for that firmware tag.`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: runGoldenSet,
+}
+
+var goldenShowCmd = &cobra.Command{
+	Use:   "show <device>",
+	Short: "Show the device's golden observation",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runGoldenShow,
+}
+
+var goldenClearCmd = &cobra.Command{
+	Use:   "clear <device>",
+	Short: "Remove the device's golden (the observation itself is kept)",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runGoldenClear,
+}
+
+var goldenFirmware string
+
+func init() {
+	goldenCmd.AddCommand(goldenSetCmd)
+	goldenCmd.AddCommand(goldenShowCmd)
+	goldenCmd.AddCommand(goldenClearCmd)
+
+	goldenSetCmd.Flags().StringVar(&goldenFirmware, "firmware", "", "Use the latest stored observation for this firmware tag")
+}
+
+func runGoldenSet(cmd *cobra.Command, args []string) error {
+	deviceID := args[0]
+	if len(args) == 2 && goldenFirmware != "" {
+		return fmt.Errorf("--firmware cannot be combined with an observation ID")
+	}
+
+	sqliteStorage, err := storage.NewSQLiteStorage()
+	if err != nil {
+		return perceptaErrors.StorageInitFailed(err)
+	}
+	defer sqliteStorage.Close()
+
+	var obs *core.Observation
+	switch {
+	case len(args) == 2:
+		obs, err = sqliteStorage.GetByID(args[1])
+	case goldenFirmware != "":
+		obs, err = sqliteStorage.GetLatestForFirmware(deviceID, goldenFirmware)
+	default:
+		obs, err = sqliteStorage.GetLatest(deviceID)
+	}
+	if err != nil {
+		return perceptaErrors.StoredObservationNotFound(err)
+	}
+
+	if _, err := sqliteStorage.SetGolden(deviceID, obs.ID); err != nil {
+		return err
+	}
+	fmt.Printf("⭐ Golden for %s set to %s\n", deviceID, describeStoredObservation(obs))
+	return nil
+}
+
+func runGoldenShow(cmd *cobra.Command, args []string) error {
+	deviceID := args[0]
+
+	sqliteStorage, err := storage.NewSQLiteStorage()
+	if err != nil {
+		return perceptaErrors.StorageInitFailed(err)
+	}
+	defer sqliteStorage.Close()
+
+	golden, obs, err := loadGolden(sqliteStorage, deviceID)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Golden for %s: %s\n", deviceID, describeStoredObservation(obs))
+	fmt.Printf("Set: %s\n", golden.SetAt.Format("2006-01-02 15:04:05"))
+	fmt.Println()
+	if len(obs.Signals) == 0 {
+		fmt.Println("No signals")
+		return nil
+	}
+	fmt.Printf("Signals (%d):\n", len(obs.Signals))
+	for _, line := range diff.Describe(obs) {
+		fmt.Printf("  %s\n", line)
+	}
+	return nil
+}
+
+func runGoldenClear(cmd *cobra.Command, args []string) error {
+	deviceID := args[0]
+
+	sqliteStorage, err := storage.NewSQLiteStorage()
+	if err != nil {
+		return perceptaErrors.StorageInitFailed(err)
+	}
+	defer sqliteStorage.Close()
+
+	if err := sqliteStorage.ClearGolden(deviceID); err != nil {
+		if errors.Is(err, storage.ErrNoGolden) {
+			return perceptaErrors.GoldenNotSet(deviceID, err)
+		}
+		return err
+	}
+	fmt.Printf("Golden for %s cleared\n", deviceID)
+	return nil
+}
+
+// loadGolden returns the device's golden and the observation it marks
+func loadGolden(sqliteStorage *storage.SQLiteStorage, deviceID string) (*storage.Golden, *core.Observation, error) {
+	golden, err := sqliteStorage.GetGolden(deviceID)
+	if errors.Is(err, storage.ErrNoGolden) {
+		return nil, nil, perceptaErrors.GoldenNotSet(deviceID, err)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	obs, err := sqliteStorage.GetByID(golden.ObservationID)
+	if err != nil {
+		return nil, nil, perceptaErrors.StoredObservationNotFound(err)
+	}
+	return golden, obs, nil
+}
+
+// describeStoredObservation names an observation with its firmware and capture time
+func describeStoredObservation(obs *core.Observation) string {
+	firmware := obs.FirmwareHash
+	if firmware == "" {
+		firmware = "untagged"
+	}
+	return fmt.Sprintf("%s (firmware %s, captured %s)", obs.ID, firmware, obs.Timestamp.Format("2006-01-02 15:04:05"))
+}
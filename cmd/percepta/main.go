@@ -35,7 +35,10 @@ func main() {
 }
 
 func init() {
+	rootCmd.AddCommand(observeCmd)
+	rootCmd.AddCommand(assertCmd)
 	rootCmd.AddCommand(diffCmd)
+	rootCmd.AddCommand(goldenCmd)
 	rootCmd.AddCommand(deviceCmd)
 	rootCmd.AddCommand(knowledgeCmd)
 	rootCmd.AddCommand(generateCmd)
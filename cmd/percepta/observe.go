@@ -1,23 +1,42 @@
-//go:build linux || darwin
-
 package main
 
 import (
+	"bufio"
+	"errors"
 	"fmt"
+	"image"
+	"os"
+	"os/exec"
+	"runtime"
+	"sort"
+	"strings"
 	"time"
 
+	"github.com/mattn/go-isatty"
+	"github.com/perceptumx/percepta/internal/camera"
 	"github.com/perceptumx/percepta/internal/config"
 	"github.com/perceptumx/percepta/internal/core"
 	perceptaErrors "github.com/perceptumx/percepta/internal/errors"
+	"github.com/perceptumx/percepta/internal/readings"
 	"github.com/perceptumx/percepta/internal/storage"
+	"github.com/perceptumx/percepta/internal/timeline"
 	"github.com/perceptumx/percepta/internal/ui"
+	"github.com/perceptumx/percepta/internal/vision"
 	"github.com/perceptumx/percepta/pkg/percepta"
 	"github.com/spf13/cobra"
 )
 
 var (
-	observeFrames   int
-	observeInterval int
+	observeFrames    int
+	observeInterval  int
+	observeBlinkCode []string
+	observeBlinkRate []string
+	observeCapture   time.Duration
+	observeBoot      bool
+	observeResetCmd  string
+	observeDiscover  bool
+	observeSaveLEDs  bool
+	observeRecord    string
 )
 
 var observeCmd = &cobra.Command{
@@ -38,6 +57,23 @@ Examples:
   # Observe with more frames for dynamic displays
   percepta observe my-esp32 --frames 10 --interval 500
 
+  # Record a per-frame timeline of the 'err' LED and decode its blink code
+  percepta observe my-esp32 --blink-code err --capture 15s
+
+  # Measure the blink rate and duty cycle of the 'status' LED frame by frame
+  percepta observe my-esp32 --blink-rate status --capture 5s
+
+  # Time the boot: press Enter as you power on, or let a command reset the board
+  percepta observe my-esp32 --boot
+  percepta observe my-esp32 --boot --reset-cmd "esptool.py --port /dev/ttyUSB0 run"
+
+  # Find the LEDs locally, check the vision model's count and save their regions
+  percepta observe my-esp32 --discover --frames 10
+  percepta observe my-esp32 --save-regions --frames 10
+
+  # Record the frames for hardware-free replays (camera_id: file://./recordings/bench)
+  percepta observe my-esp32 --record ./recordings/bench
+
   # Save observation to file
   percepta observe my-esp32 --output observation.json`,
 	Args: cobra.ExactArgs(1),
@@ -47,6 +83,22 @@ Examples:
 func init() {
 	observeCmd.Flags().IntVar(&observeFrames, "frames", 0, "number of frames to capture (default: 5)")
 	observeCmd.Flags().IntVar(&observeInterval, "interval", 0, "milliseconds between frames (default: 200)")
+	observeCmd.Flags().StringSliceVar(&observeBlinkCode, "blink-code", nil, "record a high-rate timeline of this LED and decode its blink code (repeatable)")
+	observeCmd.Flags().StringSliceVar(&observeBlinkRate, "blink-rate", nil, "record a high-rate timeline of this LED and measure its blink rate and duty cycle (repeatable)")
+	observeCmd.Flags().DurationVar(&observeCapture, "capture", 0, "length of the high-rate capture for --blink-code, --blink-rate or --boot (default: device blink_code.capture_ms or 10s, boot.capture_ms or 15s)")
+	observeCmd.Flags().BoolVar(&observeBoot, "boot", false, "time the boot from a start trigger and detect its milestones")
+	observeCmd.Flags().StringVar(&observeResetCmd, "reset-cmd", "", "shell command that resets the device for --boot (default: device boot.reset_command, else wait for Enter)")
+	observeCmd.Flags().BoolVar(&observeDiscover, "discover", false, "find the LEDs in the captured frames locally, propose their regions and check the vision model's LED count")
+	observeCmd.Flags().BoolVar(&observeSaveLEDs, "save-regions", false, "with --discover (implied), save the regions of discovered LEDs not yet in the device config")
+	observeCmd.Flags().StringVar(&observeRecord, "record", "", "save the captured frames to this directory as a session a file:// camera can replay")
+	observeCmd.MarkFlagsMutuallyExclusive("boot", "blink-code")
+	observeCmd.MarkFlagsMutuallyExclusive("boot", "blink-rate")
+	observeCmd.MarkFlagsMutuallyExclusive("boot", "discover")
+	observeCmd.MarkFlagsMutuallyExclusive("blink-code", "discover")
+	observeCmd.MarkFlagsMutuallyExclusive("blink-rate", "discover")
+	observeCmd.MarkFlagsMutuallyExclusive("boot", "save-regions")
+	observeCmd.MarkFlagsMutuallyExclusive("blink-code", "save-regions")
+	observeCmd.MarkFlagsMutuallyExclusive("blink-rate", "save-regions")
 }
 
 func runObserve(cmd *cobra.Command, args []string) error {
@@ -76,6 +128,11 @@ func runObserve(cmd *cobra.Command, args []string) error {
 	}
 	firmwareTag = deviceCfg.Firmware
 
+	patterns, err := displayPatterns(deviceID, deviceCfg)
+	if err != nil {
+		return err
+	}
+
 	// Initialize SQLite storage
 	sqliteStorage, err := storage.NewSQLiteStorage()
 	if err != nil {
@@ -83,36 +140,53 @@ func runObserve(cmd *cobra.Command, args []string) error {
 	}
 	defer sqliteStorage.Close()
 
-	// Initialize Core with storage
-	perceptaCore, err := percepta.NewCore(cameraPath, sqliteStorage)
+	// Initialize Core with storage and the configured vision provider
+	perceptaCore, err := newCore(cfg, deviceCfg, cameraPath, sqliteStorage)
 	if err != nil {
-		return perceptaErrors.CameraNotFound(cameraPath)
+		return err
+	}
+	if observeRecord != "" {
+		perceptaCore.RecordTo(observeRecord)
 	}
 
 	// Capture observation with spinner
-	spinner := ui.NewSpinner(fmt.Sprintf("Capturing frames from %s...", deviceID))
+	var spinner *ui.Spinner
 	var obs *core.Observation
-	if observeFrames > 0 || observeInterval > 0 {
-		frameCount := observeFrames
-		if frameCount <= 0 {
-			frameCount = 5
+	var discovered []vision.DiscoveredLED
+	if observeBoot {
+		var opts percepta.BootOptions
+		if opts, err = bootOptions(deviceID, deviceCfg, observeResetCmd); err != nil {
+			return err
+		}
+		if observeCapture > 0 {
+			opts.Duration = observeCapture
 		}
-		interval := time.Duration(observeInterval) * time.Millisecond
-		if interval <= 0 {
-			interval = 200 * time.Millisecond
+		// The trigger may prompt for Enter, so the spinner starts once it returns
+		trigger := opts.Trigger
+		opts.Trigger = func() error {
+			defer func() { spinner = ui.NewSpinner(fmt.Sprintf("Timing boot of %s...", deviceID)) }()
+			return trigger()
 		}
-		obs, err = perceptaCore.ObserveWithOptions(deviceID, frameCount, interval)
+		obs, err = perceptaCore.ObserveBoot(deviceID, opts)
+	} else if observeDiscover || observeSaveLEDs {
+		spinner = ui.NewSpinner(fmt.Sprintf("Capturing frames from %s...", deviceID))
+		frameCount, interval := burstOptions()
+		obs, discovered, err = perceptaCore.ObserveDiscovering(deviceID, frameCount, interval)
 	} else {
-		obs, err = perceptaCore.Observe(deviceID)
+		spinner = ui.NewSpinner(fmt.Sprintf("Capturing frames from %s...", deviceID))
+		obs, err = captureObservation(perceptaCore, deviceID, deviceCfg)
 	}
 	if err != nil {
-		spinner.Stop(false)
+		if spinner != nil {
+			spinner.Stop(false)
+		}
 		return perceptaErrors.ObservationFailed(err)
 	}
 	spinner.Stop(true)
 
-	// Inject firmware tag
+	// Inject firmware tag and configured display readings
 	obs.FirmwareHash = firmwareTag
+	readings.Apply(obs, patterns)
 
 	// Save observation with firmware tag
 	if err := sqliteStorage.Save(*obs); err != nil {
@@ -121,10 +195,299 @@ func runObserve(cmd *cobra.Command, args []string) error {
 
 	// Format output
 	printObservation(obs, perceptaCore.ObservationCount())
+	printBlinkCodeFailures(obs, observeBlinkCode, blinkCodeScheme(deviceCfg.BlinkCode))
+	if observeRecord != "" {
+		fmt.Printf("\nFrames recorded to %s (replay with camera_id: %s)\n", observeRecord, camera.FileURL(observeRecord))
+	}
+	if observeDiscover || observeSaveLEDs {
+		check := vision.CheckLEDCount(discovered, observedLEDs(obs))
+		printDiscovery(check, cfg.Vision.Provider)
+		if observeSaveLEDs {
+			return saveDiscoveredRegions(cfg, deviceID, check.LEDs)
+		}
+	}
+
+	return nil
+}
+
+// captureObservation runs the regular capture, with blink-code or blink-rate
+// timelines or a custom frame count when the flags ask for them
+func captureObservation(perceptaCore *percepta.Core, deviceID string, deviceCfg config.DeviceConfig) (*core.Observation, error) {
+	if len(observeBlinkCode) > 0 || len(observeBlinkRate) > 0 {
+		opts := timelineOptions(deviceCfg, append(append([]string(nil), observeBlinkCode...), observeBlinkRate...))
+		opts.Codes = observeBlinkCode
+		if observeCapture > 0 {
+			opts.Duration = observeCapture
+		}
+		return perceptaCore.ObserveWithTimelines(deviceID, opts)
+	}
+	if observeFrames > 0 || observeInterval > 0 {
+		frameCount, interval := burstOptions()
+		return perceptaCore.ObserveWithOptions(deviceID, frameCount, interval)
+	}
+	return perceptaCore.Observe(deviceID)
+}
+
+// burstOptions returns the --frames and --interval flags, with defaults
+func burstOptions() (int, time.Duration) {
+	frameCount := observeFrames
+	if frameCount <= 0 {
+		frameCount = 5
+	}
+	interval := time.Duration(observeInterval) * time.Millisecond
+	if interval <= 0 {
+		interval = 200 * time.Millisecond
+	}
+	return frameCount, interval
+}
 
+func observedLEDs(obs *core.Observation) []core.LEDSignal {
+	var leds []core.LEDSignal
+	for _, signal := range obs.Signals {
+		if led, ok := signal.(core.LEDSignal); ok {
+			leds = append(leds, led)
+		}
+	}
+	return leds
+}
+
+// printDiscovery lists the LEDs found locally and, when a vision model read
+// the frames, the LEDs it missed or may have hallucinated
+func printDiscovery(check vision.LEDCountCheck, provider string) {
+	fmt.Printf("\nLED discovery (%d found locally):\n", len(check.LEDs))
+	if len(check.LEDs) == 0 {
+		fmt.Println("  No lit LEDs found; LEDs unlit in every frame cannot be discovered")
+	}
+	for _, led := range check.LEDs {
+		fmt.Printf("  %s", led)
+		if led.Reported != "" {
+			fmt.Printf(" → LED '%s'", led.Reported)
+		}
+		fmt.Println()
+	}
+
+	// The local provider measures configured regions: there is no model to check
+	if strings.EqualFold(provider, vision.ProviderLocal) {
+		return
+	}
+	for _, led := range check.Missed {
+		fmt.Printf("⚠️  %s was found locally but not reported by vision\n", led.Name)
+	}
+	for _, led := range check.Unconfirmed {
+		color := ""
+		if led.ColorName != "" {
+			color = " (" + led.ColorName + ")"
+		}
+		fmt.Printf("⚠️  LED '%s'%s was reported lit but not found locally; it may be hallucinated\n", led.Name, color)
+	}
+	if check.OK() && len(check.LEDs) > 0 {
+		fmt.Println("✓ Vision's lit LED count matches local discovery")
+	}
+}
+
+// saveDiscoveredRegions adds the regions of discovered LEDs that overlap no
+// configured region to the device config, named after the LED the vision
+// model matched them with where that name is free
+func saveDiscoveredRegions(cfg *config.Config, deviceID string, discovered []vision.DiscoveredLED) error {
+	deviceCfg := cfg.Devices[deviceID]
+	if deviceCfg.Regions == nil {
+		deviceCfg.Regions = make(map[string]config.Region)
+	}
+
+	var saved []string
+	for _, led := range discovered {
+		if len(overlappingRegions(deviceCfg.Regions, led.Region)) > 0 {
+			continue
+		}
+		// The vision model may report names assertions cannot refer to ("Power LED")
+		name := led.Reported
+		if !signalNamePattern.MatchString(name) {
+			name = led.Name
+		}
+		for n := len(deviceCfg.Regions) + 1; hasRegion(deviceCfg.Regions, name); n++ {
+			name = fmt.Sprintf("LED%d", n)
+		}
+		deviceCfg.Regions[name] = regionOf(led.Region)
+		saved = append(saved, name)
+	}
+	if len(saved) == 0 {
+		fmt.Println("\nNo new LED regions to save")
+		return nil
+	}
+
+	cfg.Devices[deviceID] = deviceCfg
+	if err := saveConfig(cfg); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+	fmt.Printf("\n✓ Saved %d LED region(s) to '%s': %s\n", len(saved), deviceID, strings.Join(saved, ", "))
+	fmt.Println("  Rename them with 'percepta device calibrate' or in the config file")
 	return nil
 }
 
+// hasRegion reports whether the device has an LED region of that name, in any case
+func hasRegion(regions map[string]config.Region, name string) bool {
+	for existing := range regions {
+		if strings.EqualFold(existing, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// containsFold reports whether names holds name, in any case
+func containsFold(names []string, name string) bool {
+	for _, n := range names {
+		if strings.EqualFold(n, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// timelineOptions builds high-rate capture settings for the given LEDs from device config
+func timelineOptions(deviceCfg config.DeviceConfig, leds []string) percepta.TimelineOptions {
+	opts := percepta.TimelineOptions{
+		LEDs:     make(map[string]image.Rectangle, len(leds)),
+		FPS:      deviceCfg.BlinkCode.FPS,
+		Duration: time.Duration(deviceCfg.BlinkCode.CaptureMs) * time.Millisecond,
+		Scheme:   blinkCodeScheme(deviceCfg.BlinkCode),
+	}
+	if opts.FPS <= 0 {
+		opts.FPS = 20
+	}
+	if opts.Duration <= 0 {
+		opts.Duration = 10 * time.Second
+	}
+	for _, name := range leds {
+		var rect image.Rectangle
+		if r, ok := deviceCfg.Regions[name]; ok {
+			rect = image.Rect(r.X, r.Y, r.X+r.W, r.Y+r.H)
+		}
+		opts.LEDs[name] = rect
+	}
+	return opts
+}
+
+// bootOptions builds boot capture settings from device config. resetCmd
+// overrides the configured reset command; without either, the capture starts
+// when Enter is pressed.
+func bootOptions(deviceID string, deviceCfg config.DeviceConfig, resetCmd string) (percepta.BootOptions, error) {
+	if resetCmd == "" {
+		resetCmd = deviceCfg.Boot.ResetCommand
+	}
+	trigger, err := bootTrigger(deviceID, resetCmd)
+	if err != nil {
+		return percepta.BootOptions{}, err
+	}
+	opts := percepta.BootOptions{
+		Trigger:  trigger,
+		LEDs:     make(map[string]image.Rectangle, len(deviceCfg.Regions)),
+		FPS:      deviceCfg.Boot.FPS,
+		Duration: time.Duration(deviceCfg.Boot.CaptureMs) * time.Millisecond,
+	}
+	if opts.FPS <= 0 {
+		opts.FPS = 20
+	}
+	if opts.Duration <= 0 {
+		opts.Duration = 15 * time.Second
+	}
+	for name, rect := range ledRegions(deviceCfg) {
+		opts.LEDs[name] = rect
+	}
+	return opts, nil
+}
+
+// ledRegions converts the device's configured LED regions to pixel rectangles
+func ledRegions(deviceCfg config.DeviceConfig) map[string]image.Rectangle {
+	regions := make(map[string]image.Rectangle, len(deviceCfg.Regions))
+	for name, r := range deviceCfg.Regions {
+		regions[name] = image.Rect(r.X, r.Y, r.X+r.W, r.Y+r.H)
+	}
+	return regions
+}
+
+// signalMap is the device's calibrated LED and display regions
+func signalMap(deviceCfg config.DeviceConfig) vision.SignalMap {
+	signals := vision.SignalMap{LEDs: ledRegions(deviceCfg), Displays: map[string]image.Rectangle{}}
+	for name, d := range deviceCfg.Displays {
+		if r := d.Region; r != nil {
+			signals.Displays[name] = image.Rect(r.X, r.Y, r.X+r.W, r.Y+r.H)
+		}
+	}
+	return signals
+}
+
+// newCore initializes Core with the vision provider from the config, which
+// for the local provider measures the device's LED regions and for Claude
+// names signals after the device's signal map
+func newCore(cfg *config.Config, deviceCfg config.DeviceConfig, cameraPath string, storage core.StorageDriver) (*percepta.Core, error) {
+	visionDriver, err := vision.NewDriver(cfg.Vision.Provider, signalMap(deviceCfg))
+	if err != nil {
+		return nil, perceptaErrors.VisionInitFailed(err)
+	}
+	return percepta.NewCoreWithVision(cameraPath, visionDriver, storage), nil
+}
+
+// bootTrigger runs the reset command, or waits for Enter when there is none.
+// It prompts on stderr so that report formats on stdout stay parseable.
+// Without a reset command stdin must be a terminal: in CI nobody can press
+// Enter, and a closed stdin would start the capture at once.
+func bootTrigger(deviceID, resetCmd string) (func() error, error) {
+	if resetCmd != "" {
+		return func() error {
+			fmt.Fprintf(os.Stderr, "Resetting device: %s\n", resetCmd)
+			if out, err := shellCommand(resetCmd).CombinedOutput(); err != nil {
+				return fmt.Errorf("reset command failed: %w: %s", err, strings.TrimSpace(string(out)))
+			}
+			return nil
+		}, nil
+	}
+	if fd := os.Stdin.Fd(); !isatty.IsTerminal(fd) && !isatty.IsCygwinTerminal(fd) {
+		return nil, perceptaErrors.BootTriggerMissing(deviceID)
+	}
+	return func() error {
+		fmt.Fprint(os.Stderr, "Press Enter as you power on or reset the device... ")
+		_, err := bufio.NewReader(os.Stdin).ReadString('\n')
+		return err
+	}, nil
+}
+
+// shellCommand runs a configured command line through the platform's shell
+func shellCommand(line string) *exec.Cmd {
+	if runtime.GOOS == "windows" {
+		return exec.Command("cmd", "/C", line)
+	}
+	return exec.Command("sh", "-c", line)
+}
+
+func blinkCodeScheme(cfg config.BlinkCodeConfig) timeline.Scheme {
+	return timeline.Scheme{
+		Name:        cfg.Scheme,
+		ShortMaxMs:  cfg.ShortMaxMs,
+		GroupGapMs:  cfg.GroupGapMs,
+		RepeatGapMs: cfg.RepeatGapMs,
+	}.WithDefaults()
+}
+
+// printBlinkCodeFailures explains why the timeline of an LED asked for with
+// --blink-code yielded no blink code
+func printBlinkCodeFailures(obs *core.Observation, leds []string, scheme timeline.Scheme) {
+	for _, signal := range obs.Signals {
+		led, ok := signal.(core.LEDSignal)
+		if !ok || len(led.Timeline) == 0 || led.BlinkCode != "" || !containsFold(leds, led.Name) {
+			continue
+		}
+		_, err := timeline.Decode(led.Timeline, scheme)
+		if err == nil {
+			err = fmt.Errorf("on/off levels could not be told apart (check the LED region)")
+		}
+		fmt.Printf("⚠️  LED '%s': no blink code: %v\n", led.Name, err)
+		if errors.Is(err, timeline.ErrIncompleteCode) {
+			fmt.Println("   Try a longer --capture so the code repeats at least once")
+		}
+	}
+}
+
 func printObservation(obs *core.Observation, count int) {
 	fmt.Printf("✅ Observation captured: %s\n", obs.ID)
 	fmt.Printf("Device: %s\n", obs.DeviceID)
@@ -148,10 +511,18 @@ func printObservation(obs *core.Observation, count int) {
 			if s.BlinkHz > 0 {
 				fmt.Printf(" (blinking at %.2f Hz)", s.BlinkHz)
 			}
-			if s.Color.R > 0 || s.Color.G > 0 || s.Color.B > 0 {
+			if s.ColorName != "" {
+				fmt.Printf(" [%s RGB(%d,%d,%d)]", s.ColorName, s.Color.R, s.Color.G, s.Color.B)
+			} else if s.HasColor() {
 				fmt.Printf(" [RGB(%d,%d,%d)]", s.Color.R, s.Color.G, s.Color.B)
 			}
+			if s.BlinkCode != "" {
+				fmt.Printf(" [code %s from %d frames]", s.BlinkCode, len(s.Timeline))
+			}
 			fmt.Printf(" [confidence: %.2f]\n", s.Confidence)
+			if s.BlinkWarning != "" {
+				fmt.Printf("      ⚠️  blink rate: %s\n", s.BlinkWarning)
+			}
 
 		case core.DisplaySignal:
 			if s.Changed && len(s.History) > 0 {
@@ -165,12 +536,38 @@ func printObservation(obs *core.Observation, count int) {
 				fmt.Printf("  %d. Display '%s': \"%s\" [confidence: %.2f]\n",
 					i+1, s.Name, s.Text, s.Confidence)
 			}
+			if len(s.Values) > 0 {
+				fmt.Printf("      values: %s\n", formatDisplayValues(s.Values))
+			}
 
 		case core.BootTimingSignal:
-			fmt.Printf("  %d. Boot timing: %dms [confidence: %.2f]\n",
-				i+1, s.DurationMs, s.Confidence)
+			if s.Incomplete {
+				fmt.Printf("  %d. Boot timing: not steady after %dms [confidence: %.2f]\n",
+					i+1, s.DurationMs, s.Confidence)
+			} else {
+				fmt.Printf("  %d. Boot timing: %dms [confidence: %.2f]\n",
+					i+1, s.DurationMs, s.Confidence)
+			}
+			for _, m := range s.Milestones {
+				fmt.Printf("      @%dms: %s\n", m.OffsetMs, m.Name)
+			}
 		}
 	}
 
 	fmt.Printf("\nStored in memory (%d total observations)\n", count)
 }
+
+// formatDisplayValues lists readings sorted by key, e.g. "temp=23.4C v=3.29"
+func formatDisplayValues(values map[string]core.DisplayValue) string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	parts := make([]string, len(keys))
+	for i, key := range keys {
+		parts[i] = fmt.Sprintf("%s=%s%s", key, values[key].Text, values[key].Unit)
+	}
+	return strings.Join(parts, " ")
+}
@@ -531,6 +531,79 @@ func TestLEDAssertion_Color_NoColorInfo(t *testing.T) {
 	}
 }
 
+func TestLEDAssertion_Color_ReportedBlack(t *testing.T) {
+	black := core.RGB{}
+	obs := &core.Observation{
+		Signals: []core.Signal{
+			core.LEDSignal{Name: "LED1", On: true, ColorName: "black", Confidence: 0.88},
+		},
+	}
+
+	result := (&LEDAssertion{Name: "LED1", Expected: LEDState{Color: &black}}).Evaluate(obs)
+	if !result.Passed {
+		t.Errorf("Expected a reported black LED to match RGB(0,0,0), got: %s", result.Message)
+	}
+}
+
+func TestLEDAssertion_NamedColor(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected string
+		led      core.LEDSignal
+		want     bool
+	}{
+		{"drifted blue", "blue", core.LEDSignal{Color: core.RGB{R: 40, G: 60, B: 230}}, true},
+		{"cyan is not blue", "blue", core.LEDSignal{Color: core.RGB{G: 200, B: 255}}, false},
+		{"amber hue", "amber", core.LEDSignal{Color: core.RGB{R: 250, G: 180, B: 20}}, true},
+		{"reported name", "amber", core.LEDSignal{ColorName: "amber", Color: core.RGB{R: 255, G: 191}}, true},
+		{"reported name without RGB", "blue", core.LEDSignal{ColorName: "teal"}, false},
+		{"white is achromatic", "white", core.LEDSignal{Color: core.RGB{R: 235, G: 240, B: 250}}, true},
+		{"washed-out red is not white", "white", core.LEDSignal{Color: core.RGB{R: 250, G: 120, B: 120}}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.led.Name, tt.led.On, tt.led.Confidence = "status", true, 0.9
+			obs := &core.Observation{Signals: []core.Signal{tt.led}}
+
+			assertion, err := Parse("LED.status COLOR " + tt.expected)
+			if err != nil {
+				t.Fatalf("Parse failed: %v", err)
+			}
+
+			result := assertion.Evaluate(obs)
+			if result.Passed != tt.want {
+				t.Errorf("Expected passed=%v, got %v: %s", tt.want, result.Passed, result.Message)
+			}
+		})
+	}
+}
+
+func TestLEDAssertion_PerceptualColor(t *testing.T) {
+	obs := &core.Observation{
+		Signals: []core.Signal{
+			core.LEDSignal{Name: "status", On: true, Color: core.RGB{R: 40, G: 60, B: 230}, Confidence: 0.9},
+		},
+	}
+
+	within, err := Parse("LED.status COLOR RGB(0,0,255) WITHIN 15")
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if result := within.Evaluate(obs); !result.Passed {
+		t.Errorf("Expected drift within ΔE 15 to pass, got: %s", result.Message)
+	}
+
+	strict, err := Parse("led('status').color_rgb(0,0,255,5)")
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	result := strict.Evaluate(obs)
+	if result.Passed || !strings.Contains(result.Message, "ΔE") {
+		t.Errorf("Expected failure reporting ΔE, got passed=%v: %s", result.Passed, result.Message)
+	}
+}
+
 func TestLEDAssertion_NotFound(t *testing.T) {
 	on := true
 	obs := &core.Observation{
@@ -671,6 +744,143 @@ func TestLEDAssertion_String(t *testing.T) {
 	}
 }
 
+// observation builds a test observation from its ID and signals
+func observation(id string, signals ...core.Signal) *core.Observation {
+	return &core.Observation{ID: id, Signals: signals}
+}
+
+func codeObservation(code string, frames int) *core.Observation {
+	led := core.LEDSignal{Name: "err", On: true, BlinkCode: code, Confidence: 0.9}
+	for i := 0; i < frames; i++ {
+		led.Timeline = append(led.Timeline, core.LEDSample{OffsetMs: int64(i * 50), On: i%2 == 0})
+	}
+	return &core.Observation{
+		ID:      "code",
+		Signals: []core.Signal{led, core.LEDSignal{Name: "power", On: true, Confidence: 0.9}},
+	}
+}
+
+func TestLEDAssertion_BlinkCode(t *testing.T) {
+	code := "3-2"
+	assertion := &LEDAssertion{Name: "err", Expected: LEDState{Code: &code}}
+
+	tests := []struct {
+		name    string
+		obs     *core.Observation
+		want    Outcome
+		wantMsg string
+	}{
+		{"match", codeObservation("3-2", 100), OutcomePass, "matches expected state"},
+		{"mismatch", codeObservation("3-1", 100), OutcomeFail, "Expected blink code 3-2, got 3-1"},
+		{"undecodable", codeObservation("", 100), OutcomeFail, "none could be decoded from 100 frames"},
+		{"no timeline", codeObservation("", 0), OutcomeInconclusive, "needs a high-rate capture"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := assertion.Evaluate(tt.obs)
+			if result.Outcome() != tt.want {
+				t.Errorf("Expected %s, got %s: %s", tt.want, result.Outcome(), result.Message)
+			}
+			if !strings.Contains(result.Message, tt.wantMsg) {
+				t.Errorf("Expected message containing %q, got %q", tt.wantMsg, result.Message)
+			}
+		})
+	}
+}
+
+func TestParse_LED_Code(t *testing.T) {
+	for _, dsl := range []string{"LED.err CODE 3-2", "led('err').code('3-2')"} {
+		assertion, err := Parse(dsl)
+		if err != nil {
+			t.Fatalf("Parse(%q) failed: %v", dsl, err)
+		}
+		ledAssert, ok := assertion.(*LEDAssertion)
+		if !ok {
+			t.Fatalf("Expected *LEDAssertion, got %T", assertion)
+		}
+		if ledAssert.Expected.Code == nil || *ledAssert.Expected.Code != "3-2" {
+			t.Errorf("Expected code 3-2 from %q, got %v", dsl, ledAssert.Expected.Code)
+		}
+		if assertion.String() != "LED.err CODE 3-2" {
+			t.Errorf("Unexpected String(): %q", assertion.String())
+		}
+	}
+
+	for _, dsl := range []string{"LED.err CODE 3-", "LED.err CODE 0-2", "led('err').code('x')"} {
+		if _, err := Parse(dsl); err == nil {
+			t.Errorf("Expected Parse(%q) to fail", dsl)
+		}
+	}
+}
+
+func TestParse_LED_ColorName(t *testing.T) {
+	tests := []struct {
+		dsl  string
+		want string
+	}{
+		{"LED.status COLOR blue", "LED.status COLOR blue"},
+		{"LED.status COLOR Violet", "LED.status COLOR purple"},
+		{"led('status').color('amber')", "LED.status COLOR amber"},
+		{"led('status').color('amber', 12)", "LED.status COLOR amber WITHIN 12"},
+		{"LED.status COLOR RGB(0,0,255) WITHIN 10", "LED.status RGB(0,0,255) WITHIN 10"},
+	}
+
+	for _, tt := range tests {
+		assertion, err := Parse(tt.dsl)
+		if err != nil {
+			t.Fatalf("Parse(%q) failed: %v", tt.dsl, err)
+		}
+		if got := assertion.String(); got != tt.want {
+			t.Errorf("Parse(%q).String() = %q, want %q", tt.dsl, got, tt.want)
+		}
+		if _, err := Parse(assertion.String()); err != nil {
+			t.Errorf("Expected String() output %q to parse back: %v", assertion.String(), err)
+		}
+	}
+
+	for _, dsl := range []string{"LED.status COLOR teal", "led('status').color('teal')", "led('status').color(3)"} {
+		if _, err := Parse(dsl); err == nil {
+			t.Errorf("Expected Parse(%q) to fail", dsl)
+		}
+	}
+}
+
+func TestBlinkCodeLEDs(t *testing.T) {
+	assertion, err := Parse("LED.err CODE 3-2 && !LED.power OFF || EVENTUALLY 10s (led('ERR').code('1-1') || led('net').code('2'))")
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	names := BlinkCodeLEDs(WithMinConfidence(assertion, 0.5))
+	if len(names) != 2 || names[0] != "err" || names[1] != "net" {
+		t.Errorf("Expected [err net], got %v", names)
+	}
+	if got := BlinkCodeLEDs(&LEDAssertion{Name: "power"}); got != nil {
+		t.Errorf("Expected no blink-code LEDs, got %v", got)
+	}
+}
+
+func TestSignalNames(t *testing.T) {
+	assertion, err := Parse(`LED.power ON && LED.Heartbeat RATE == 2x LED.status && (Display.lcd "Ready" || Display.LCD VALUE temp < 30) && ALWAYS 5s LED.POWER BRIGHTNESS > 50% && LED.status OFF`)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	leds, displays := SignalNames(WithMinConfidence(assertion, 0.5))
+	if strings.Join(leds, " ") != "power Heartbeat status" {
+		t.Errorf("Expected [power Heartbeat status], got %v", leds)
+	}
+	if strings.Join(displays, " ") != "lcd" {
+		t.Errorf("Expected [lcd], got %v", displays)
+	}
+
+	vision, _ := Parse(`Vision "Is the WiFi icon shown?" EXPECT yes`)
+	if leds, displays := SignalNames(vision); leds != nil || displays != nil {
+		t.Errorf("Expected no signal names, got %v and %v", leds, displays)
+	}
+}
+
 // Timing Assertion Tests
 
 func TestTimingAssertion_Pass(t *testing.T) {
@@ -771,6 +981,107 @@ func TestTimingAssertion_String(t *testing.T) {
 	}
 }
 
+func TestTimingAssertion_Incomplete(t *testing.T) {
+	obs := &core.Observation{
+		Signals: []core.Signal{
+			core.BootTimingSignal{DurationMs: 2000, Confidence: 0.9, Incomplete: true},
+		},
+	}
+
+	result := (&TimingAssertion{MaxDurationMs: 3000}).Evaluate(obs)
+	if result.Passed {
+		t.Error("Expected a boot that never settled to fail, even within the limit")
+	}
+	if !strings.Contains(result.Message, "had not settled") {
+		t.Errorf("Expected the message to explain the boot never settled, got: %s", result.Message)
+	}
+}
+
+func bootObservation() *core.Observation {
+	return &core.Observation{
+		Signals: []core.Signal{
+			core.BootTimingSignal{
+				DurationMs: 2400,
+				Confidence: 0.9,
+				Milestones: []core.BootMilestone{
+					{Name: core.MilestoneFirstLED, OffsetMs: 120},
+					{Name: "led.power", OffsetMs: 120},
+					{Name: core.MilestoneFirstText, OffsetMs: 1450},
+					{Name: core.MilestoneSteady, OffsetMs: 2400},
+				},
+			},
+		},
+	}
+}
+
+func TestTimingAssertion_Milestones(t *testing.T) {
+	tests := []struct {
+		dsl  string
+		want bool
+	}{
+		{"BootTime < 3000ms", true},
+		{"BootTime.first_led < 200ms", true},
+		{"BootTime.first_text < 1500ms", true},
+		{"BootTime.first_text < 1000ms", false},
+		{"BootTime.FIRST_TEXT < 1500ms", true},
+		{"BootTime.led.power < 120ms", true},
+		{"BootTime.steady < 2000ms", false},
+		{"BootTime.led.status < 5000ms", false}, // Never lit
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.dsl, func(t *testing.T) {
+			assertion, err := Parse(tt.dsl)
+			if err != nil {
+				t.Fatalf("Parse failed: %v", err)
+			}
+			result := assertion.Evaluate(bootObservation())
+			if result.Passed != tt.want {
+				t.Errorf("Expected passed=%v, got %v: %s", tt.want, result.Passed, result.Message)
+			}
+		})
+	}
+
+	assertion, _ := Parse("BootTime.led.status < 5000ms")
+	result := assertion.Evaluate(bootObservation())
+	if result.Message != "Milestone 'led.status' was not reached during the 2400 ms capture (reached: first_led, led.power, first_text, steady)" {
+		t.Errorf("Unexpected message: %q", result.Message)
+	}
+	assertion, _ = Parse("BootTime.first_text < 1000ms")
+	result = assertion.Evaluate(bootObservation())
+	if result.Actual != "Milestone 'first_text' at 1450 ms" || result.Confidence != 0.9 {
+		t.Errorf("Unexpected result: %q [%.2f]", result.Actual, result.Confidence)
+	}
+}
+
+func TestTimingAssertion_MilestoneString(t *testing.T) {
+	assertion, err := Parse("BootTime.first_text < 1500ms")
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if assertion.String() != "BootTime.first_text < 1500 ms" {
+		t.Errorf("Unexpected String(): %q", assertion.String())
+	}
+	if _, err := Parse(assertion.String()); err != nil {
+		t.Errorf("Expected String() to parse again: %v", err)
+	}
+}
+
+func TestNeedsBootTiming(t *testing.T) {
+	assertion, err := Parse("LED.power ON && (BootTime.first_text < 1500ms || Display.main \"READY\")")
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if !NeedsBootTiming(WithMinConfidence(assertion, 0.5)) {
+		t.Error("Expected a nested BootTime clause to need boot timing")
+	}
+
+	assertion, _ = Parse("LED.power ON")
+	if NeedsBootTiming(assertion) {
+		t.Error("Expected an LED assertion not to need boot timing")
+	}
+}
+
 // DisplayAssertion Tests
 
 func TestDisplayAssertion_Pass(t *testing.T) {
@@ -1004,3 +1315,20 @@ func TestParse_InvalidTimingSyntax(t *testing.T) {
 		t.Error("Expected error for invalid timing syntax")
 	}
 }
+
+func TestParse_TimingMilestone(t *testing.T) {
+	assertion, err := Parse("BootTime.led.power < 250ms")
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	timing := assertion.(*TimingAssertion)
+	if timing.Milestone != "led.power" || timing.MaxDurationMs != 250 {
+		t.Errorf("Expected led.power < 250, got %+v", timing)
+	}
+
+	for _, dsl := range []string{"BootTime. < 250ms", "BootTime.first_text 250ms", "BootTime.first_text < 2.5s"} {
+		if _, err := Parse(dsl); err == nil {
+			t.Errorf("Expected Parse(%q) to fail", dsl)
+		}
+	}
+}
@@ -0,0 +1,243 @@
+package assertions
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/perceptumx/percepta/internal/core"
+	"github.com/perceptumx/percepta/internal/timeline"
+	"github.com/perceptumx/percepta/internal/tolerance"
+)
+
+// Level is the LED quantity an LEDLevelAssertion compares, in percent
+type Level string
+
+const (
+	LevelBrightness Level = "BRIGHTNESS" // Intensity while lit, e.g. a PWM-dimmed backlight
+	LevelDuty       Level = "DUTY"       // Share of a blink period spent lit
+)
+
+// Effect is the brightness effect an LEDFadeAssertion looks for
+type Effect string
+
+const (
+	EffectBreathing Effect = "BREATHING"  // Brightness rises and falls gradually
+	EffectFadeIn    Effect = "FADING IN"  // Brightness only rises, gradually
+	EffectFadeOut   Effect = "FADING OUT" // Brightness only falls, gradually
+)
+
+// fadeMinSwing is the brightness change, in percentage points, that counts as
+// a rise or fall; smaller wobbles are camera noise
+const fadeMinSwing = 20
+
+// LEDLevelAssertion compares an LED's brightness or blink duty cycle, in
+// percent, e.g. LED.backlight BRIGHTNESS 50% or LED.status DUTY < 30%.
+// == allows the profile's brightness tolerance unless WITHIN is given.
+type LEDLevelAssertion struct {
+	Name    string
+	Level   Level
+	Op      Comparison
+	Percent float64  // Operand; the lower bound for BETWEEN
+	Upper   float64  // Upper bound for BETWEEN (inclusive)
+	Within  *float64 // Points allowed either side for ==; nil uses the profile
+
+	profile *tolerance.Profile
+}
+
+func (a *LEDLevelAssertion) Evaluate(obs *core.Observation) AssertionResult {
+	led := findLED(obs, a.Name)
+	if led == nil {
+		return AssertionResult{
+			Passed:        false,
+			Expected:      a.String(),
+			Actual:        "LED not found in observation",
+			Confidence:    0.0,
+			Message:       fmt.Sprintf("LED '%s' not found in observation", a.Name),
+			SignalMissing: true,
+		}
+	}
+
+	result := AssertionResult{Expected: a.String(), Confidence: led.Confidence}
+	value, ok := a.measure(led)
+	if !ok {
+		result.Inconclusive = true
+		if a.Level == LevelBrightness {
+			result.Actual = fmt.Sprintf("LED '%s' is ON, brightness not measured", led.Name)
+			result.Message = "The observation has no brightness for this LED"
+		} else {
+			result.Actual = fmt.Sprintf("LED '%s' is blinking at %.2f Hz, duty cycle not measured", led.Name, led.BlinkHz)
+			result.Message = "Measuring a duty cycle needs per-frame states; the observation has none"
+		}
+		return result
+	}
+
+	result.Actual = fmt.Sprintf("LED '%s' %s %s%%", led.Name, a.noun(), formatPercent(value))
+	if !a.holds(value) {
+		result.Message = fmt.Sprintf("Expected %s %s, got %s%%", a.noun(), a.condition(), formatPercent(value))
+		if a.Op == CompareEQ {
+			result.Message += fmt.Sprintf(" (outside ±%s points)", formatPercent(a.allowedPoints()))
+		}
+		return result
+	}
+	result.Passed = true
+	result.Message = fmt.Sprintf("LED '%s' %s is %s", led.Name, a.noun(), a.condition())
+	return result
+}
+
+// measure returns the LED's brightness or duty cycle. An OFF LED is 0% bright
+// and a steady one has a 0% or 100% duty cycle; ok is false when a lit or
+// blinking LED was not measured.
+func (a *LEDLevelAssertion) measure(led *core.LEDSignal) (float64, bool) {
+	if a.Level == LevelBrightness {
+		if !led.On {
+			return 0, true
+		}
+		return float64(led.Brightness), led.Brightness > 0
+	}
+
+	if led.DutyCycle > 0 {
+		return led.DutyCycle, true
+	}
+	samples := frameStates(led)
+	if led.BlinkHz == 0 && timeline.Transitions(samples) == 0 {
+		if led.On {
+			return 100, true
+		}
+		return 0, true
+	}
+	return timeline.DutyCycle(samples)
+}
+
+func (a *LEDLevelAssertion) holds(v float64) bool {
+	switch a.Op {
+	case CompareBetween:
+		return v >= a.Percent && v <= a.Upper
+	case CompareLT:
+		return v < a.Percent
+	case CompareLE:
+		return v <= a.Percent
+	case CompareGT:
+		return v > a.Percent
+	case CompareGE:
+		return v >= a.Percent
+	}
+	profile := tolerance.Profile{BrightnessPoints: a.allowedPoints()}
+	return profile.BrightnessMatches(a.Percent, v)
+}
+
+// allowedPoints is the number of percentage points == allows
+func (a *LEDLevelAssertion) allowedPoints() float64 {
+	if a.Within != nil {
+		return *a.Within
+	}
+	return profileOrDefault(a.profile).BrightnessPoints
+}
+
+func (a *LEDLevelAssertion) noun() string {
+	if a.Level == LevelDuty {
+		return "duty cycle"
+	}
+	return "brightness"
+}
+
+// condition formats the comparison, e.g. "50%", "BETWEEN 40% AND 60%" or "> 20%"
+func (a *LEDLevelAssertion) condition() string {
+	switch a.Op {
+	case CompareBetween:
+		return fmt.Sprintf("BETWEEN %s%% AND %s%%", formatPercent(a.Percent), formatPercent(a.Upper))
+	case CompareEQ:
+		if a.Within != nil {
+			return fmt.Sprintf("%s%% WITHIN %s%%", formatPercent(a.Percent), formatPercent(*a.Within))
+		}
+		return formatPercent(a.Percent) + "%"
+	}
+	return fmt.Sprintf("%s %s%%", a.Op, formatPercent(a.Percent))
+}
+
+func (a *LEDLevelAssertion) String() string {
+	return fmt.Sprintf("LED.%s %s %s", a.Name, a.Level, a.condition())
+}
+
+// LEDFadeAssertion checks for a gradual brightness effect over the capture,
+// e.g. LED.power BREATHING. It needs per-frame brightness: a high-rate
+// timeline, or the multi-frame states when vision reported brightness.
+type LEDFadeAssertion struct {
+	Name   string
+	Effect Effect
+}
+
+func (a *LEDFadeAssertion) Evaluate(obs *core.Observation) AssertionResult {
+	led := findLED(obs, a.Name)
+	if led == nil {
+		return AssertionResult{
+			Passed:        false,
+			Expected:      a.String(),
+			Actual:        "LED not found in observation",
+			Confidence:    0.0,
+			Message:       fmt.Sprintf("LED '%s' not found in observation", a.Name),
+			SignalMissing: true,
+		}
+	}
+
+	result := AssertionResult{Expected: a.String(), Confidence: led.Confidence}
+	samples := frameStates(led)
+	measured := false
+	for _, s := range samples {
+		measured = measured || s.Brightness > 0
+	}
+	if !measured {
+		if !led.On {
+			result.Actual = fmt.Sprintf("LED '%s' is OFF", led.Name)
+			result.Message = fmt.Sprintf("Expected '%s' %s, but it stayed dark", led.Name, a.describe())
+			return result
+		}
+		result.Inconclusive = true
+		result.Actual = fmt.Sprintf("LED '%s' has no per-frame brightness", led.Name)
+		result.Message = "Detecting fades needs per-frame brightness; the observation has none"
+		return result
+	}
+
+	fade := timeline.Fades(samples, fadeMinSwing)
+	result.Actual = fmt.Sprintf("LED '%s' brightness %d%%-%d%% over %d frames: %d rise(s), %d fall(s)", led.Name, fade.Min, fade.Max, len(samples), fade.Rises, fade.Falls)
+
+	var holds bool
+	switch a.Effect {
+	case EffectFadeIn:
+		holds = fade.Rises > 0 && fade.Falls == 0
+	case EffectFadeOut:
+		holds = fade.Falls > 0 && fade.Rises == 0
+	default:
+		holds = fade.Rises > 0 && fade.Falls > 0
+	}
+
+	switch {
+	case !holds:
+		result.Message = fmt.Sprintf("Expected '%s' %s (swings of at least %d points)", led.Name, a.describe(), fadeMinSwing)
+	case !fade.Gradual():
+		result.Message = fmt.Sprintf("LED '%s' jumps between levels without intermediate brightness: blinking, not %s", led.Name, a.describe())
+	default:
+		result.Passed = true
+		result.Message = fmt.Sprintf("LED '%s' is %s", led.Name, a.describe())
+	}
+	return result
+}
+
+func (a *LEDFadeAssertion) describe() string {
+	switch a.Effect {
+	case EffectFadeIn:
+		return "fading in"
+	case EffectFadeOut:
+		return "fading out"
+	}
+	return "breathing"
+}
+
+func (a *LEDFadeAssertion) String() string {
+	return fmt.Sprintf("LED.%s %s", a.Name, a.Effect)
+}
+
+// formatPercent renders a percentage with at most one decimal
+func formatPercent(v float64) string {
+	return strconv.FormatFloat(math.Round(v*10)/10, 'f', -1, 64)
+}
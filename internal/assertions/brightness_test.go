@@ -0,0 +1,215 @@
+package assertions
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/perceptumx/percepta/internal/core"
+	"github.com/perceptumx/percepta/internal/tolerance"
+)
+
+// brightnessFrames builds per-frame samples 200ms apart; non-zero levels are lit
+func brightnessFrames(levels ...uint8) []core.LEDSample {
+	samples := make([]core.LEDSample, len(levels))
+	for i, b := range levels {
+		samples[i] = core.LEDSample{OffsetMs: int64(i) * 200, On: b > 0, Brightness: b}
+	}
+	return samples
+}
+
+func TestLEDLevelAssertion(t *testing.T) {
+	obs := observation("brightness",
+		core.LEDSignal{Name: "backlight", On: true, Brightness: 48, Confidence: 0.9},
+		core.LEDSignal{Name: "status", On: true, Brightness: 90, BlinkHz: 1, DutyCycle: 25, Confidence: 0.9, Frames: brightnessFrames(90, 0, 0, 0, 90)},
+		core.LEDSignal{Name: "activity", On: true, BlinkHz: 2, Confidence: 0.9, Frames: brightnessFrames(80, 0, 80, 0, 80)},
+		core.LEDSignal{Name: "standby", On: false, Confidence: 0.95},
+		core.LEDSignal{Name: "link", On: true, Confidence: 0.95},
+	)
+
+	tests := []struct {
+		dsl  string
+		want bool
+	}{
+		{"LED.backlight BRIGHTNESS 50%", true},
+		{"LED.backlight BRIGHTNESS 60%", false}, // 12 points off
+		{"LED.backlight BRIGHTNESS 45% WITHIN 5%", true},
+		{"LED.backlight BRIGHTNESS 40% WITHIN 5%", false},
+		{"LED.backlight BRIGHTNESS < 60%", true},
+		{"LED.backlight BRIGHTNESS >= 50%", false},
+		{"LED.backlight BRIGHTNESS BETWEEN 40% AND 60%", true},
+		{"LED.standby BRIGHTNESS 0%", true},
+		{"LED.standby BRIGHTNESS > 10%", false},
+		{"LED.status DUTY 25%", true},
+		{"LED.status DUTY > 40%", false},
+		{"LED.activity DUTY 50%", true}, // Measured from the frames
+		{"LED.link DUTY 100%", true},
+		{"LED.standby DUTY 0%", true},
+		{"led('backlight').brightness(50)", true},
+		{"led('backlight').brightness(60, 15)", true},
+		{"led('status').duty(75)", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.dsl, func(t *testing.T) {
+			assertion, err := Parse(tt.dsl)
+			if err != nil {
+				t.Fatalf("Parse failed: %v", err)
+			}
+			result := assertion.Evaluate(obs)
+			if result.Passed != tt.want {
+				t.Errorf("Expected passed=%v, got %v: %s (%s)", tt.want, result.Passed, result.Message, result.Actual)
+			}
+		})
+	}
+}
+
+func TestLEDLevelAssertion_Messages(t *testing.T) {
+	obs := observation("brightness",
+		core.LEDSignal{Name: "backlight", On: true, Brightness: 48, Confidence: 0.9},
+		core.LEDSignal{Name: "link", On: true, Confidence: 0.95},
+	)
+	assertion, _ := Parse("LED.backlight BRIGHTNESS 60%")
+	result := assertion.Evaluate(obs)
+	if result.Actual != "LED 'backlight' brightness 48%" || result.Confidence != 0.9 {
+		t.Errorf("Unexpected result: %q [%.2f]", result.Actual, result.Confidence)
+	}
+	if result.Message != "Expected brightness 60%, got 48% (outside ±10 points)" {
+		t.Errorf("Unexpected message: %q", result.Message)
+	}
+
+	// A lit LED without a measured brightness cannot be judged
+	assertion, _ = Parse("LED.link BRIGHTNESS 50%")
+	if result := assertion.Evaluate(obs); !result.Inconclusive {
+		t.Errorf("Expected INCONCLUSIVE without a brightness, got %+v", result)
+	}
+
+	unmeasured := observation("unmeasured", core.LEDSignal{Name: "status", On: true, BlinkHz: 1, Confidence: 0.9})
+	assertion, _ = Parse("LED.status DUTY 50%")
+	if result := assertion.Evaluate(unmeasured); !result.Inconclusive || !strings.Contains(result.Actual, "duty cycle not measured") {
+		t.Errorf("Expected INCONCLUSIVE without per-frame states, got %+v", result)
+	}
+
+	assertion, _ = Parse("LED.heater BRIGHTNESS 50%")
+	if result := assertion.Evaluate(obs); !result.SignalMissing {
+		t.Errorf("Expected missing LED to be flagged, got %+v", result)
+	}
+}
+
+func TestLEDLevelAssertion_WithTolerance(t *testing.T) {
+	obs := observation("brightness", core.LEDSignal{Name: "backlight", On: true, Brightness: 48, Confidence: 0.9})
+	assertion, _ := Parse("LED.backlight BRIGHTNESS 60%") // 48% measured
+	loose := tolerance.Default()
+	loose.BrightnessPoints = 15
+	if result := WithTolerance(assertion, loose).Evaluate(obs); !result.Passed {
+		t.Errorf("Expected a 15-point tolerance to accept 48%% for 60%%: %s", result.Message)
+	}
+
+	// An explicit WITHIN overrides the profile
+	assertion, _ = Parse("LED.backlight BRIGHTNESS 60% WITHIN 5%")
+	if result := WithTolerance(assertion, loose).Evaluate(obs); result.Passed {
+		t.Error("Expected WITHIN 5% to take precedence over the profile")
+	}
+}
+
+func TestLEDFadeAssertion(t *testing.T) {
+	obs := observation("brightness",
+		core.LEDSignal{Name: "backlight", On: true, Brightness: 48, Confidence: 0.9},
+		core.LEDSignal{Name: "power", On: true, Brightness: 55, Confidence: 0.8, Frames: brightnessFrames(10, 35, 70, 95, 70, 35, 10, 35)},
+		core.LEDSignal{Name: "boot", On: true, Brightness: 50, Confidence: 0.8, Frames: brightnessFrames(5, 25, 50, 75, 100)},
+		core.LEDSignal{Name: "activity", On: true, BlinkHz: 2, Confidence: 0.9, Frames: brightnessFrames(80, 0, 80, 0, 80)},
+		core.LEDSignal{Name: "standby", On: false, Confidence: 0.95},
+	)
+
+	tests := []struct {
+		dsl  string
+		want bool
+	}{
+		{"LED.power BREATHING", true},
+		{"LED.power FADING IN", false},
+		{"LED.boot FADING IN", true},
+		{"LED.boot fading out", false},
+		{"LED.boot BREATHING", false},
+		{"LED.activity BREATHING", false}, // Blinks between off and on
+		{"LED.standby BREATHING", false},
+		{"led('power').breathing()", true},
+		{"led('boot').fading('in')", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.dsl, func(t *testing.T) {
+			assertion, err := Parse(tt.dsl)
+			if err != nil {
+				t.Fatalf("Parse failed: %v", err)
+			}
+			result := assertion.Evaluate(obs)
+			if result.Passed != tt.want {
+				t.Errorf("Expected passed=%v, got %v: %s (%s)", tt.want, result.Passed, result.Message, result.Actual)
+			}
+		})
+	}
+
+	assertion, _ := Parse("LED.activity BREATHING")
+	if result := assertion.Evaluate(obs); !strings.Contains(result.Message, "blinking, not breathing") {
+		t.Errorf("Expected blinking to be told apart from breathing, got %q", result.Message)
+	}
+	assertion, _ = Parse("LED.backlight BREATHING")
+	if result := assertion.Evaluate(obs); !result.Inconclusive {
+		t.Errorf("Expected INCONCLUSIVE without per-frame brightness, got %+v", result)
+	}
+}
+
+func TestTimelineLEDs(t *testing.T) {
+	assertion, err := Parse("LED.err CODE 3-2 && LED.power BREATHING && LED.backlight BRIGHTNESS 50% && led('Power').fading('in')")
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	names := TimelineLEDs(WithMinConfidence(assertion, 0.5))
+	if len(names) != 2 || names[0] != "err" || names[1] != "power" {
+		t.Errorf("Expected [err power], got %v", names)
+	}
+}
+
+func TestParse_LEDLevel(t *testing.T) {
+	for _, dsl := range []string{
+		"LED.backlight BRIGHTNESS 50%",
+		"LED.backlight BRIGHTNESS 50% WITHIN 5%",
+		"LED.backlight BRIGHTNESS > 20%",
+		"LED.backlight BRIGHTNESS BETWEEN 40% AND 60%",
+		"LED.status DUTY <= 12.5%",
+		"LED.power BREATHING",
+		"LED.boot FADING IN",
+		"LED.boot FADING OUT && LED.power ON",
+	} {
+		assertion, err := Parse(dsl)
+		if err != nil {
+			t.Fatalf("Parse(%q) failed: %v", dsl, err)
+		}
+		if assertion.String() != dsl {
+			t.Errorf("Expected %q to round-trip, got %q", dsl, assertion.String())
+		}
+	}
+
+	assertion, err := Parse("LED.backlight brightness == 50 %")
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if level, ok := assertion.(*LEDLevelAssertion); !ok || level.Level != LevelBrightness || level.Op != CompareEQ || level.Percent != 50 {
+		t.Errorf("Unexpected level assertion: %+v", assertion)
+	}
+
+	for _, dsl := range []string{
+		"LED.backlight BRIGHTNESS 150%",
+		"LED.backlight BRIGHTNESS 50",
+		"LED.backlight BRIGHTNESS > 50% WITHIN 5%",
+		"LED.backlight BRIGHTNESS BETWEEN 60% AND 40%",
+		"LED.power FADING",
+		"led('a').brightness(101)",
+		"led('a').duty(50, -1)",
+		"led('a').fading('up')",
+		"led('a').breathing(1)",
+	} {
+		if _, err := Parse(dsl); err == nil {
+			t.Errorf("Expected Parse(%q) to fail", dsl)
+		}
+	}
+}
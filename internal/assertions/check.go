@@ -0,0 +1,314 @@
+package assertions
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/perceptumx/percepta/internal/tolerance"
+)
+
+// ParseError is a syntax error in an assertion expression, located by column
+// and, where a small edit makes the expression valid, with a suggested fix
+type ParseError struct {
+	Column     int    // 1-based byte column in the expression; 0 when not tied to a position
+	Message    string // Error text, which for token errors already names the column
+	Suggestion string // Corrected statement or keyword, e.g. "LED.status BLINK 2Hz"; "" when none
+}
+
+func (e *ParseError) Error() string {
+	if e.Suggestion == "" {
+		return e.Message
+	}
+	return fmt.Sprintf("%s (did you mean %s?)", e.Message, e.Suggestion)
+}
+
+// errorAt builds a ParseError at byte offset pos of the expression
+func errorAt(pos int, format string, args ...interface{}) error {
+	return &ParseError{Column: pos + 1, Message: fmt.Sprintf(format, args...)}
+}
+
+// asParseError returns err as a *ParseError, locating errors without a
+// position at byte offset pos
+func asParseError(err error, pos int) *ParseError {
+	var pe *ParseError
+	if errors.As(err, &pe) {
+		return pe
+	}
+	return &ParseError{Column: pos + 1, Message: err.Error()}
+}
+
+// Check parses the expression without evaluating it, returning nil when it is
+// valid. Errors carry the column and, where possible, a suggested fix.
+func Check(dsl string) *ParseError {
+	if _, err := Parse(dsl); err != nil {
+		return asParseError(err, -1)
+	}
+	return nil
+}
+
+// Diagnostic is an invalid line of an assertion file
+type Diagnostic struct {
+	Line       int    // 1-based line number
+	Expression string // The line as written
+	Err        *ParseError
+}
+
+// CheckFile checks every expression of an assertion file, in the format read
+// by LoadExpressions. It returns the number of expressions and a Diagnostic
+// for each invalid one, reporting all of them rather than stopping at the first.
+func CheckFile(path string) (int, []Diagnostic, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to read assertion file: %w", err)
+	}
+	defer f.Close()
+
+	checked := 0
+	var diagnostics []Diagnostic
+	scanner := bufio.NewScanner(f)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimRight(scanner.Text(), "\r")
+		if dsl := strings.TrimSpace(text); dsl == "" || strings.HasPrefix(dsl, "#") {
+			continue
+		}
+		checked++
+		if pe := Check(text); pe != nil {
+			diagnostics = append(diagnostics, Diagnostic{Line: line, Expression: text, Err: pe})
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return 0, nil, fmt.Errorf("failed to read assertion file: %w", err)
+	}
+	return checked, diagnostics, nil
+}
+
+// Keywords that begin an assertion, for "did you mean" on unknown ones
+var primaryKeywords = []string{"LED", "Display", "BootTime", "Vision", "EVENTUALLY", "ALWAYS", "SEQUENCE", "CONFIDENCE"}
+
+// Method names of led(...) and display(...) predicates
+var (
+	ledMethods     = []string{"is_on", "is_off", "blinks", "color", "color_rgb", "code", "brightness", "duty", "breathing", "fading", "same_rate", "rate_ratio", "in_phase", "alternates", "iff"}
+	displayMethods = []string{"shows", "contains", "changed", "value", "matches"}
+)
+
+// statementKeywords are the words of legacy statements after the subject
+var statementKeywords = []string{
+	"ON", "OFF", "BLINKING", "STEADY", "BLINK", "COLOR", "RGB", "CODE", "WITHIN",
+	"BRIGHTNESS", "DUTY", "BREATHING", "FADING", "IN", "OUT", "BETWEEN", "AND",
+	"RATE", "IFF", "PHASE", "WITH", "ALTERNATES",
+	"CHANGED", "SIMILAR", "VALUE", "MATCHES", "EXPECT",
+}
+
+// keywordAliases map words people write for the keyword the DSL uses
+var keywordAliases = map[string]string{
+	"COLOUR":    "COLOR",
+	"SOLID":     "STEADY",
+	"FLASHING":  "BLINKING",
+	"LIT":       "ON",
+	"DARK":      "OFF",
+	"BRIGHT":    "BRIGHTNESS",
+	"DUTYCYCLE": "DUTY",
+}
+
+// UnknownSignal is an LED or display an assertion refers to that a device's
+// calibrated signal map does not name
+type UnknownSignal struct {
+	Kind    string // "LED" or "Display"
+	Name    string
+	Closest string // Nearest calibrated name of the same kind, "" if none is close
+}
+
+func (u UnknownSignal) String() string {
+	msg := fmt.Sprintf("%s.%s is not in the device's signal map", u.Kind, u.Name)
+	if u.Closest != "" {
+		msg += fmt.Sprintf(" (did you mean %s.%s?)", u.Kind, u.Closest)
+	}
+	return msg
+}
+
+// UnknownSignals checks the signals the assertion refers to against a
+// device's calibrated LED and display names, ignoring case
+func UnknownSignals(a Assertion, leds, displays []string) []UnknownSignal {
+	usedLEDs, usedDisplays := SignalNames(a)
+	var unknown []UnknownSignal
+	check := func(kind string, used, calibrated []string) {
+		for _, name := range used {
+			if slices.ContainsFunc(calibrated, func(c string) bool { return strings.EqualFold(c, name) }) {
+				continue
+			}
+			closest, _ := closestWord(name, calibrated)
+			unknown = append(unknown, UnknownSignal{Kind: kind, Name: name, Closest: closest})
+		}
+	}
+	check("LED", usedLEDs, leds)
+	check("Display", usedDisplays, displays)
+	return unknown
+}
+
+// closestWord returns the candidate nearest to word, ignoring case, when it is
+// within a third of the word's length (at least one edit)
+func closestWord(word string, candidates []string) (string, bool) {
+	best, bestDistance := "", -1
+	for _, c := range candidates {
+		d := tolerance.EditDistance(strings.ToLower(word), strings.ToLower(c))
+		if bestDistance < 0 || d < bestDistance {
+			best, bestDistance = c, d
+		}
+	}
+	if bestDistance < 0 || bestDistance > max(1, len(word)/3) {
+		return "", false
+	}
+	return best, true
+}
+
+var (
+	bareNumber    = regexp.MustCompile(`^\d+(\.\d+)?$`)
+	hertzValue    = regexp.MustCompile(`(?i)^\d+(\.\d+)?Hz$`)
+	rgbTriple     = regexp.MustCompile(`^\(?(\d+,\s*\d+,\s*\d+)\)?$`)
+	separatedCode = regexp.MustCompile(`^[1-9]\d*([,./_][1-9]\d*)+$`)
+)
+
+// word is a whitespace-separated word of a statement and its byte span
+type word struct {
+	text       string
+	start, end int
+}
+
+// statementWords splits a statement on whitespace outside quotes
+func statementWords(raw string) []word {
+	var words []word
+	start, quote := -1, byte(0)
+	for i := 0; i <= len(raw); i++ {
+		if i < len(raw) && quote != 0 {
+			if raw[i] == quote {
+				quote = 0
+			}
+			continue
+		}
+		space := i == len(raw) || raw[i] == ' ' || raw[i] == '\t'
+		switch {
+		case space && start >= 0:
+			words = append(words, word{text: raw[start:i], start: start, end: i})
+			start = -1
+		case !space && start < 0:
+			start = i
+		}
+		if i < len(raw) && (raw[i] == '"' || raw[i] == '\'') {
+			quote = raw[i]
+		}
+	}
+	return words
+}
+
+// wordFixes lists replacements for one word of a statement that might make
+// it valid: the nearest keyword or color name, a missing unit or keyword, a
+// reformatted value, or dropping the word (the empty replacement)
+func wordFixes(words []word, i int) []string {
+	w := words[i].text
+	upper := strings.ToUpper(w)
+	var fixes []string
+
+	if alias, ok := keywordAliases[upper]; ok {
+		fixes = append(fixes, alias)
+	}
+	if kw, ok := closestWord(w, statementKeywords); ok && kw != upper {
+		fixes = append(fixes, kw)
+	}
+	if color, ok := closestWord(w, tolerance.ColorNames()); ok {
+		if !strings.EqualFold(color, w) {
+			fixes = append(fixes, color)
+		}
+		if !strings.EqualFold(words[i-1].text, "COLOR") {
+			fixes = append(fixes, "COLOR "+color)
+		}
+	}
+	if (strings.EqualFold(w, "yes") || strings.EqualFold(w, "no")) && !strings.EqualFold(words[i-1].text, "EXPECT") {
+		fixes = append(fixes, "EXPECT "+strings.ToLower(w))
+	}
+	if (upper == "ALTERNATES" || upper == "PHASE") && (i+1 == len(words) || !strings.EqualFold(words[i+1].text, "WITH")) {
+		fixes = append(fixes, w+" WITH")
+	}
+
+	switch {
+	case bareNumber.MatchString(w):
+		fixes = append(fixes, w+"Hz", w+"%", w+"ms", w+"x", "BLINK "+w+"Hz")
+	case hertzValue.MatchString(w) && !strings.EqualFold(words[i-1].text, "BLINK"):
+		fixes = append(fixes, "BLINK "+w)
+	case rgbTriple.MatchString(w):
+		fixes = append(fixes, "RGB("+rgbTriple.FindStringSubmatch(w)[1]+")", "COLOR RGB("+rgbTriple.FindStringSubmatch(w)[1]+")")
+	case separatedCode.MatchString(w):
+		fixes = append(fixes, regexp.MustCompile(`[,./_]`).ReplaceAllString(w, "-"))
+	}
+	if !strings.HasPrefix(w, `"`) && !strings.HasPrefix(words[0].text, "LED.") {
+		fixes = append(fixes, `"`+strings.Trim(w, `'`)+`"`)
+	}
+	if len(words) > 2 {
+		// Dropping a word must leave more than the subject, which alone is a
+		// different check
+		fixes = append(fixes, "")
+	}
+	return fixes
+}
+
+// suggestStatement looks for a corrected form of an invalid statement that
+// differs in at most two words, trying single-word fixes first. It returns
+// the corrected statement and the byte offset in raw of the first word fixed.
+func suggestStatement(raw string) (string, int, bool) {
+	words := statementWords(raw)
+	if len(words) == 0 {
+		return "", 0, false
+	}
+
+	// "LED status ON" is missing the dot after the subject
+	if len(words) > 1 && !strings.Contains(words[0].text, ".") {
+		switch words[0].text {
+		case "LED", "Display":
+			fixed := raw[:words[0].end] + "." + raw[words[1].start:]
+			if _, err := parseStatementText(fixed); err == nil {
+				return fixed, words[0].end, true
+			}
+		}
+	}
+
+	// apply replaces word i of the statement, dropping it (and the space
+	// before it) for an empty replacement
+	apply := func(text string, ws []word, i int, replacement string) string {
+		if replacement == "" {
+			return text[:ws[i-1].end] + text[ws[i].end:]
+		}
+		return text[:ws[i].start] + replacement + text[ws[i].end:]
+	}
+
+	type candidate struct {
+		text   string
+		offset int
+	}
+	var first []candidate
+	for i := 1; i < len(words); i++ {
+		for _, fix := range wordFixes(words, i) {
+			fixed := apply(raw, words, i, fix)
+			if _, err := parseStatementText(fixed); err == nil {
+				return fixed, words[i].start, true
+			}
+			first = append(first, candidate{fixed, words[i].start})
+		}
+	}
+
+	for _, c := range first {
+		ws := statementWords(c.text)
+		for i := 1; i < len(ws); i++ {
+			for _, fix := range wordFixes(ws, i) {
+				fixed := apply(c.text, ws, i, fix)
+				if _, err := parseStatementText(fixed); err == nil {
+					return fixed, min(c.offset, ws[i].start), true
+				}
+			}
+		}
+	}
+	return "", 0, false
+}
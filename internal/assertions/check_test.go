@@ -0,0 +1,152 @@
+package assertions
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCheck_Suggestions(t *testing.T) {
+	tests := []struct {
+		dsl        string
+		column     int
+		suggestion string
+	}{
+		{"LED.status BLINK 2", 18, "LED.status BLINK 2Hz"},
+		{"LED.status BLINKS 2Hz", 12, "LED.status BLINK 2Hz"},
+		{"LED.status 2Hz", 12, "LED.status BLINK 2Hz"},
+		{"LED.power IS ON", 11, "LED.power ON"},
+		{"LED.status COLOR gren", 18, "LED.status COLOR green"},
+		{"LED.status COLOUR green", 12, "LED.status COLOR green"},
+		{"LED.err CODE 3,2", 14, "LED.err CODE 3-2"},
+		{"LED.backlight BRIGHTNESS 50", 26, "LED.backlight BRIGHTNESS 50%"},
+		{"LED.a ALTERNATES LED.b", 7, "LED.a ALTERNATES WITH LED.b"},
+		{"LED status ON", 4, "LED.status ON"},
+		{"Display.lcd Ready", 13, `Display.lcd "Ready"`},
+		{"BootTime < 3000", 12, "BootTime < 3000ms"},
+		{`Vision "Is the WiFi icon shown?" yes`, 34, `Vision "Is the WiFi icon shown?" EXPECT yes`},
+		{"Led.power ON", 1, "LED"},
+		{"EVENTUALY 10s LED.wifi ON", 1, "EVENTUALLY"},
+		{"LED.power ON && LED.status BLINK 2", 34, "LED.status BLINK 2Hz"},
+		{"  LED.status BLINK 2", 20, "LED.status BLINK 2Hz"},
+		{`led("x").blink()`, 10, "blinks"},
+		{`display("lcd").show("Ready")`, 16, "shows"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.dsl, func(t *testing.T) {
+			pe := Check(tt.dsl)
+			if pe == nil {
+				t.Fatal("Expected a parse error")
+			}
+			if pe.Column != tt.column || pe.Suggestion != tt.suggestion {
+				t.Errorf("Expected column %d and suggestion %q, got %d and %q (%s)", tt.column, tt.suggestion, pe.Column, pe.Suggestion, pe.Message)
+			}
+		})
+	}
+}
+
+func TestCheck_Valid(t *testing.T) {
+	for _, dsl := range []string{
+		"LED.status BLINK 2Hz",
+		"LED.power ON && !(LED.error ON || LED.warn ON)",
+		"SEQUENCE WITHIN 60s LED.status BLINKING FOR 2s..30s THEN LED.status STEADY",
+	} {
+		if pe := Check(dsl); pe != nil {
+			t.Errorf("Check(%q) = %v, want nil", dsl, pe)
+		}
+	}
+}
+
+func TestCheck_Errors(t *testing.T) {
+	pe := Check("LED.power ON &&")
+	if pe == nil || pe.Column != 16 || !strings.Contains(pe.Message, "unexpected end") {
+		t.Errorf("Expected a column-16 error at end of input, got %+v", pe)
+	}
+
+	pe = Check("LED.power ON && )")
+	if pe == nil || pe.Column != 17 {
+		t.Errorf("Expected a column-17 error at the stray parenthesis, got %+v", pe)
+	}
+
+	pe = Check("LED.a RATE = LED.b")
+	if pe == nil || pe.Suggestion != "==" {
+		t.Errorf("Expected '=' to suggest '==', got %+v", pe)
+	}
+
+	pe = Check("   ")
+	if pe == nil || pe.Column != 0 {
+		t.Errorf("Expected an empty expression error without a column, got %+v", pe)
+	}
+
+	// Nothing close enough to suggest
+	pe = Check("LED.power WIBBLE WOBBLE FROB")
+	if pe == nil || pe.Suggestion != "" {
+		t.Errorf("Expected no suggestion, got %+v", pe)
+	}
+}
+
+func TestParseError_Error(t *testing.T) {
+	pe := &ParseError{Column: 18, Message: "unknown LED state format: BLINK 2", Suggestion: "LED.status BLINK 2Hz"}
+	if got := pe.Error(); got != "unknown LED state format: BLINK 2 (did you mean LED.status BLINK 2Hz?)" {
+		t.Errorf("Unexpected Error(): %q", got)
+	}
+	pe.Suggestion = ""
+	if got := pe.Error(); got != "unknown LED state format: BLINK 2" {
+		t.Errorf("Unexpected Error(): %q", got)
+	}
+
+	// Parse errors are ParseErrors, so existing callers get suggestions too
+	if _, err := Parse("LED.status BLINK 2"); err == nil || !strings.Contains(err.Error(), "did you mean LED.status BLINK 2Hz?") {
+		t.Errorf("Expected Parse to suggest a fix, got %v", err)
+	}
+}
+
+func TestCheckFile(t *testing.T) {
+	path := writeSuiteFile(t, "checks.txt", `# Power and status
+LED.power ON
+
+LED.status BLINK 2
+  Display.lcd Ready
+EVENTUALLY 10s LED.wifi ON
+`)
+	checked, diagnostics, err := CheckFile(path)
+	if err != nil {
+		t.Fatalf("CheckFile failed: %v", err)
+	}
+	if checked != 4 || len(diagnostics) != 2 {
+		t.Fatalf("Expected 4 expressions with 2 invalid, got %d and %+v", checked, diagnostics)
+	}
+	if d := diagnostics[0]; d.Line != 4 || d.Expression != "LED.status BLINK 2" || d.Err.Column != 18 {
+		t.Errorf("Unexpected first diagnostic: %+v (%+v)", d, d.Err)
+	}
+	// Columns count from the start of the line, indentation included
+	if d := diagnostics[1]; d.Line != 5 || d.Err.Column != 15 || d.Err.Suggestion != `Display.lcd "Ready"` {
+		t.Errorf("Unexpected second diagnostic: %+v (%+v)", d, d.Err)
+	}
+
+	if _, _, err := CheckFile(path + ".missing"); err == nil {
+		t.Error("Expected an error for a missing file")
+	}
+}
+
+func TestUnknownSignals(t *testing.T) {
+	assertion, _ := Parse(`LED.Power ON && LED.stauts BLINKING && LED.a ON IFF LED.fan OFF && Display.oled "Ready"`)
+
+	unknown := UnknownSignals(assertion, []string{"power", "status", "a"}, []string{"lcd"})
+	if len(unknown) != 3 {
+		t.Fatalf("Expected 3 unknown signals, got %+v", unknown)
+	}
+	if u := unknown[0]; u.Name != "stauts" || u.Closest != "status" || u.String() != "LED.stauts is not in the device's signal map (did you mean LED.status?)" {
+		t.Errorf("Unexpected misspelt LED: %+v (%s)", u, u)
+	}
+	if u := unknown[1]; u.Kind != "LED" || u.Name != "fan" || u.Closest != "" {
+		t.Errorf("Expected the relation's other LED without a suggestion, got %+v", u)
+	}
+	if u := unknown[2]; u.Kind != "Display" || u.Name != "oled" || u.String() != "Display.oled is not in the device's signal map" {
+		t.Errorf("Unexpected display: %+v (%s)", u, u)
+	}
+
+	if got := UnknownSignals(assertion, []string{"POWER", "status", "stauts", "A", "fan"}, []string{"OLED"}); got != nil {
+		t.Errorf("Expected every signal to be calibrated, got %+v", got)
+	}
+}
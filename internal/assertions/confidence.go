@@ -0,0 +1,127 @@
+package assertions
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/perceptumx/percepta/internal/core"
+)
+
+// ConfidenceAssertion requires every signal matched by its operand to be observed
+// with at least Min confidence. Weaker evidence makes the result INCONCLUSIVE
+// instead of passing or failing.
+//
+// Syntax: CONFIDENCE >= <min> <expr>
+type ConfidenceAssertion struct {
+	Operand Assertion
+	Min     float64
+}
+
+func (a *ConfidenceAssertion) Evaluate(obs *core.Observation) AssertionResult {
+	result := WithMinConfidence(a.Operand, a.Min).Evaluate(obs)
+	result.Expected = a.String()
+	return result
+}
+
+func (a *ConfidenceAssertion) String() string {
+	return fmt.Sprintf("CONFIDENCE >= %s %s", strconv.FormatFloat(a.Min, 'f', -1, 64), wrapOperand(a.Operand, precedenceNot))
+}
+
+// WithMinConfidence returns a copy of the assertion tree whose leaves report
+// INCONCLUSIVE when their matched signal's confidence is below min.
+// Subtrees under an explicit CONFIDENCE clause keep their own threshold.
+func WithMinConfidence(a Assertion, min float64) Assertion {
+	if min <= 0 {
+		return a
+	}
+
+	switch t := a.(type) {
+	case *ConfidenceAssertion, *thresholdAssertion:
+		return t
+	case *AndAssertion:
+		return &AndAssertion{Operands: withMinConfidenceAll(t.Operands, min)}
+	case *OrAssertion:
+		return &OrAssertion{Operands: withMinConfidenceAll(t.Operands, min)}
+	case *NotAssertion:
+		return &NotAssertion{Operand: WithMinConfidence(t.Operand, min)}
+	case *EventuallyAssertion:
+		c := *t
+		c.Operand = WithMinConfidence(t.Operand, min)
+		return &c
+	case *AlwaysAssertion:
+		c := *t
+		c.Operand = WithMinConfidence(t.Operand, min)
+		return &c
+	case *SequenceAssertion:
+		c := *t
+		c.Steps = make([]SequenceStep, len(t.Steps))
+		for i, step := range t.Steps {
+			step.State = WithMinConfidence(step.State, min)
+			c.Steps[i] = step
+		}
+		return &c
+	}
+	return &thresholdAssertion{Assertion: a, min: min}
+}
+
+func withMinConfidenceAll(operands []Assertion, min float64) []Assertion {
+	out := make([]Assertion, 0, len(operands))
+	for _, op := range operands {
+		out = append(out, WithMinConfidence(op, min))
+	}
+	return out
+}
+
+// thresholdAssertion applies a confidence threshold to a leaf assertion.
+// It is transparent in String() so expressions print as the user wrote them.
+type thresholdAssertion struct {
+	Assertion
+	min float64
+}
+
+func (a *thresholdAssertion) Evaluate(obs *core.Observation) AssertionResult {
+	return applyThreshold(a.Assertion.Evaluate(obs), a.min)
+}
+
+// applyThreshold downgrades a leaf result to INCONCLUSIVE when its confidence is below min
+func applyThreshold(result AssertionResult, min float64) AssertionResult {
+	if result.SignalMissing || result.Confidence >= min {
+		return result
+	}
+
+	verdict := "fail"
+	if result.Passed {
+		verdict = "pass"
+	}
+	result.Passed = false
+	result.Inconclusive = true
+	result.Message = fmt.Sprintf("Confidence %.2f is below threshold %.2f (would %s: %s)", result.Confidence, min, verdict, result.Message)
+	return result
+}
+
+// ResolveMinConfidence picks the first positive threshold, most specific first
+// (e.g. command-line flag, device config, global config)
+func ResolveMinConfidence(candidates ...float64) float64 {
+	for _, c := range candidates {
+		if c > 0 {
+			return c
+		}
+	}
+	return 0
+}
+
+// RunConclusive runs the assertion like RunWithSnapshot and, while the outcome is
+// INCONCLUSIVE, re-runs it on fresh observations up to reobserve more times.
+// The returned count is the number of runs made.
+func RunConclusive(a Assertion, snapshot *core.Observation, observe Observer, reobserve int) (AssertionResult, int, error) {
+	start := time.Now()
+	result, err := RunWithSnapshot(a, snapshot, observe)
+	runs := 1
+	for err == nil && result.Inconclusive && runs <= reobserve {
+		result, err = Run(a, observe)
+		runs++
+	}
+	result.Duration = time.Since(start)
+	return result, runs, err
+}
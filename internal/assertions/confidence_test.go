@@ -0,0 +1,199 @@
+package assertions
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/perceptumx/percepta/internal/core"
+)
+
+func boolPtr(b bool) *bool { return &b }
+
+func TestParse_Confidence(t *testing.T) {
+	assertion, err := Parse("CONFIDENCE >= 0.8 (LED.wifi ON && LED.error OFF)")
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	c, ok := assertion.(*ConfidenceAssertion)
+	if !ok {
+		t.Fatalf("Expected *ConfidenceAssertion, got %T", assertion)
+	}
+	if c.Min != 0.8 {
+		t.Errorf("Expected threshold 0.8, got %v", c.Min)
+	}
+	if assertion.String() != "CONFIDENCE >= 0.8 (LED.wifi ON && LED.error OFF)" {
+		t.Errorf("Unexpected String(): %q", assertion.String())
+	}
+}
+
+func TestParse_ConfidenceErrors(t *testing.T) {
+	tests := []struct {
+		dsl     string
+		wantErr string
+	}{
+		{"CONFIDENCE 0.8 LED.wifi ON", "expected '>='"},
+		{"CONFIDENCE >= LED.wifi ON", "expected confidence threshold"},
+		{"CONFIDENCE >= 1.5 LED.wifi ON", "must be between 0 and 1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.dsl, func(t *testing.T) {
+			_, err := Parse(tt.dsl)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestConfidenceAssertion_Outcomes(t *testing.T) {
+	tests := []struct {
+		name string
+		dsl  string
+		conf float64
+		want Outcome
+	}{
+		{"confident pass", "CONFIDENCE >= 0.8 LED.wifi ON", 0.9, OutcomePass},
+		{"weak pass", "CONFIDENCE >= 0.8 LED.wifi ON", 0.3, OutcomeInconclusive},
+		{"weak fail", "CONFIDENCE >= 0.8 LED.wifi OFF", 0.3, OutcomeInconclusive},
+		{"missing signal still fails", "CONFIDENCE >= 0.8 LED.power ON && LED.error OFF", 0.9, OutcomeFail},
+		{"and: confident failure wins", "CONFIDENCE >= 0.8 (LED.wifi ON && LED.error ON)", 0.3, OutcomeFail},
+		{"and: weak clause", "CONFIDENCE >= 0.8 (LED.wifi ON && LED.error OFF)", 0.3, OutcomeInconclusive},
+		{"or: confident pass wins", "CONFIDENCE >= 0.8 (LED.wifi ON || LED.error OFF)", 0.3, OutcomePass},
+		{"not: stays inconclusive", "CONFIDENCE >= 0.8 !LED.wifi OFF", 0.3, OutcomeInconclusive},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertion, err := Parse(tt.dsl)
+			if err != nil {
+				t.Fatalf("Parse failed: %v", err)
+			}
+			obs := observation("conf", core.LEDSignal{Name: "wifi", On: true, Confidence: tt.conf}, core.LEDSignal{Name: "error", On: false, Confidence: 0.95})
+			result := assertion.Evaluate(obs)
+			if result.Outcome() != tt.want {
+				t.Errorf("Expected %s, got %s: %s", tt.want, result.Outcome(), result.Message)
+			}
+		})
+	}
+}
+
+func TestWithMinConfidence_InnerThresholdWins(t *testing.T) {
+	assertion, err := Parse("LED.error OFF && CONFIDENCE >= 0.2 LED.wifi ON")
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	obs := observation("conf", core.LEDSignal{Name: "wifi", On: true, Confidence: 0.3}, core.LEDSignal{Name: "error", On: false, Confidence: 0.95})
+	result := WithMinConfidence(assertion, 0.8).Evaluate(obs)
+	if !result.Passed {
+		t.Errorf("Expected explicit per-assertion threshold to override global one, got %s: %s", result.Outcome(), result.Message)
+	}
+	if result.Expected != assertion.String() {
+		t.Errorf("Expected thresholds to be invisible in String(), got %q", result.Expected)
+	}
+}
+
+func TestWithMinConfidence_Zero(t *testing.T) {
+	assertion, _ := Parse("LED.wifi ON")
+	if WithMinConfidence(assertion, 0) != assertion {
+		t.Error("Expected a zero threshold to leave the assertion unchanged")
+	}
+}
+
+func TestResolveMinConfidence(t *testing.T) {
+	if got := ResolveMinConfidence(0, 0.7, 0.5); got != 0.7 {
+		t.Errorf("Expected first positive threshold 0.7, got %v", got)
+	}
+	if got := ResolveMinConfidence(0, 0); got != 0 {
+		t.Errorf("Expected 0 when no threshold is set, got %v", got)
+	}
+}
+
+func TestRunConclusive_Reobserves(t *testing.T) {
+	confs := []float64{0.3, 0.4, 0.9}
+	calls := 0
+	observe := func() (*core.Observation, error) {
+		obs := observation(fmt.Sprintf("obs-%d", calls+1), core.LEDSignal{Name: "wifi", On: true, Confidence: confs[calls]})
+		calls++
+		return obs, nil
+	}
+
+	assertion := WithMinConfidence(&LEDAssertion{Name: "wifi", Expected: LEDState{On: boolPtr(true)}}, 0.8)
+	result, runs, err := RunConclusive(assertion, nil, observe, 5)
+	if err != nil {
+		t.Fatalf("RunConclusive failed: %v", err)
+	}
+	if !result.Passed || runs != 3 {
+		t.Errorf("Expected pass on third run, got %s after %d runs", result.Outcome(), runs)
+	}
+	if result.ObservationID != "obs-3" {
+		t.Errorf("Expected result from obs-3, got %q", result.ObservationID)
+	}
+}
+
+func TestRunConclusive_GivesUp(t *testing.T) {
+	calls := 0
+	observe := func() (*core.Observation, error) {
+		calls++
+		return observation("conf", core.LEDSignal{Name: "wifi", On: true, Confidence: 0.3}), nil
+	}
+
+	assertion := WithMinConfidence(&LEDAssertion{Name: "wifi", Expected: LEDState{On: boolPtr(true)}}, 0.8)
+	result, runs, err := RunConclusive(assertion, nil, observe, 2)
+	if err != nil {
+		t.Fatalf("RunConclusive failed: %v", err)
+	}
+	if !result.Inconclusive || runs != 3 || calls != 3 {
+		t.Errorf("Expected inconclusive after 3 runs, got %s after %d runs (%d observations)", result.Outcome(), runs, calls)
+	}
+}
+
+func TestEventually_InconclusiveSamples(t *testing.T) {
+	clk := &fakeClock{now: time.Unix(0, 0)}
+	observe := func() (*core.Observation, error) {
+		clk.Sleep(time.Second)
+		return observation("conf", core.LEDSignal{Name: "wifi", On: true, Confidence: 0.3}), nil
+	}
+
+	assertion := &EventuallyAssertion{
+		Operand: WithMinConfidence(&LEDAssertion{Name: "wifi", Expected: LEDState{On: boolPtr(true)}}, 0.8),
+		Within:  3 * time.Second,
+		clock:   clk,
+	}
+
+	result, err := assertion.EvaluateOver(observe)
+	if err != nil {
+		t.Fatalf("EvaluateOver failed: %v", err)
+	}
+	if result.Outcome() != OutcomeInconclusive {
+		t.Errorf("Expected INCONCLUSIVE, got %s: %s", result.Outcome(), result.Message)
+	}
+}
+
+func TestSuite_Inconclusive(t *testing.T) {
+	suite := &Suite{
+		MinConfidence: 0.8,
+		Cases: []SuiteCase{
+			{Name: "wifi", Assertions: []string{"LED.wifi ON"}},
+			{Name: "error", Assertions: []string{"LED.error OFF"}},
+		},
+	}
+	if err := suite.Compile(); err != nil {
+		t.Fatalf("Compile failed: %v", err)
+	}
+
+	result := suite.Run("dev", func(c *SuiteCase, resample bool) (*core.Observation, error) {
+		return observation("conf", core.LEDSignal{Name: "wifi", On: true, Confidence: 0.3}, core.LEDSignal{Name: "error", On: false, Confidence: 0.95}), nil
+	})
+
+	passed, failed, inconclusive := result.Counts()
+	if passed != 1 || failed != 0 || inconclusive != 1 {
+		t.Errorf("Expected 1 passed / 0 failed / 1 inconclusive, got %d / %d / %d", passed, failed, inconclusive)
+	}
+	if result.Outcome() != OutcomeInconclusive {
+		t.Errorf("Expected suite INCONCLUSIVE, got %s", result.Outcome())
+	}
+}
@@ -0,0 +1,250 @@
+package assertions
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Explain describes a parsed assertion in plain English, one clause per line,
+// with nested clauses indented under the operator that combines them, e.g.
+//
+//	All of:
+//	  - LED 'power' is on
+//	  - LED 'status' blinks at 2 Hz (within the blink-rate tolerance)
+func Explain(a Assertion) string {
+	var b strings.Builder
+	explain(&b, a, "")
+	return strings.TrimRight(b.String(), "\n")
+}
+
+// explain writes the clause at indent; composite clauses end with a colon
+// and list their operands below
+func explain(b *strings.Builder, a Assertion, indent string) {
+	line := func(format string, args ...interface{}) {
+		b.WriteString(indent)
+		fmt.Fprintf(b, format, args...)
+		b.WriteByte('\n')
+	}
+	operands := func(ops ...Assertion) {
+		for _, op := range ops {
+			b.WriteString(indent + "  - ")
+			var sub strings.Builder
+			explain(&sub, op, indent+"    ")
+			b.WriteString(strings.TrimPrefix(sub.String(), indent+"    "))
+		}
+	}
+
+	switch t := a.(type) {
+	case *thresholdAssertion:
+		explain(b, t.Assertion, indent)
+	case *AndAssertion:
+		line("All of:")
+		operands(t.Operands...)
+	case *OrAssertion:
+		line("Any of:")
+		operands(t.Operands...)
+	case *NotAssertion:
+		line("Not:")
+		operands(t.Operand)
+	case *ConfidenceAssertion:
+		line("With every signal's confidence at least %g (otherwise INCONCLUSIVE):", t.Min)
+		operands(t.Operand)
+	case *EventuallyAssertion:
+		line("At some point within %s%s:", formatDuration(t.Within), everyString(t.Every))
+		operands(t.Operand)
+	case *AlwaysAssertion:
+		line("Throughout %s%s:", formatDuration(t.For), everyString(t.Every))
+		operands(t.Operand)
+	case *SequenceAssertion:
+		within := t.Within
+		if within <= 0 {
+			within = defaultSequenceTimeout
+		}
+		line("These states in order, all within %s%s:", formatDuration(within), everyString(t.Every))
+		for i, step := range t.Steps {
+			fmt.Fprintf(b, "%s  %d. ", indent, i+1)
+			var sub strings.Builder
+			explain(&sub, step.State, indent+"     ")
+			// The dwell qualifies the whole step, so it goes on its first line
+			first, rest, _ := strings.Cut(strings.TrimPrefix(sub.String(), indent+"     "), "\n")
+			if header, ok := strings.CutSuffix(first, ":"); ok {
+				first = header + dwellString(step) + ":"
+			} else {
+				first += dwellString(step)
+			}
+			b.WriteString(first + "\n" + rest)
+		}
+	default:
+		line("%s", describe(a))
+	}
+}
+
+// describe explains a leaf assertion in one sentence
+func describe(a Assertion) string {
+	switch t := a.(type) {
+	case *LEDAssertion:
+		return describeLED(t)
+	case *LEDLevelAssertion:
+		what := "brightness"
+		if t.Level == LevelDuty {
+			what = "duty cycle (share of each blink period spent lit)"
+		}
+		condition := describeComparison(t.Op, formatPercent(t.Percent)+"%", formatPercent(t.Upper)+"%")
+		switch {
+		case t.Op != CompareEQ:
+		case t.Within != nil:
+			condition += fmt.Sprintf(" (±%s points)", formatPercent(*t.Within))
+		default:
+			condition += " (within the brightness tolerance)"
+		}
+		return fmt.Sprintf("LED '%s' %s is %s", t.Name, what, condition)
+	case *LEDFadeAssertion:
+		switch t.Effect {
+		case EffectBreathing:
+			return fmt.Sprintf("LED '%s' is breathing: its brightness rises and falls gradually", t.Name)
+		case EffectFadeIn:
+			return fmt.Sprintf("LED '%s' is fading in: its brightness only rises, gradually", t.Name)
+		}
+		return fmt.Sprintf("LED '%s' is fading out: its brightness only falls, gradually", t.Name)
+	case *LEDRelationAssertion:
+		return describeRelation(t)
+	case *DisplayAssertion:
+		return fmt.Sprintf("Display '%s' shows text containing \"%s\"%s", t.Name, t.Expected, similarityString(t.MinSimilarity))
+	case *DisplayChangedAssertion:
+		return fmt.Sprintf("Display '%s' changed from \"%s\" to \"%s\"%s", t.Name, t.FromText, t.ToText, similarityString(t.MinSimilarity))
+	case *DisplayValueAssertion:
+		return fmt.Sprintf("Display '%s' reading '%s' is %s", t.Name, t.Key, describeComparison(t.Op, fmt.Sprintf("%g", t.Value), fmt.Sprintf("%g", t.Upper)))
+	case *DisplayMatchAssertion:
+		if t.Key != "" {
+			return fmt.Sprintf("Display '%s' reading '%s' matches the regular expression /%s/", t.Name, t.Key, t.Pattern)
+		}
+		return fmt.Sprintf("Display '%s' text matches the regular expression /%s/", t.Name, t.Pattern)
+	case *TimingAssertion:
+		if t.Milestone != "" {
+			return fmt.Sprintf("The boot reaches milestone '%s' within %d ms of the start trigger", t.Milestone, t.MaxDurationMs)
+		}
+		return fmt.Sprintf("The device boots to a steady state within %d ms of the start trigger", t.MaxDurationMs)
+	case *VisionAssertion:
+		if t.Text {
+			return fmt.Sprintf("The vision model, asked \"%s\", answers with text containing \"%s\"", t.Question, t.Expected)
+		}
+		return fmt.Sprintf("The vision model, asked \"%s\", answers %s", t.Question, t.Expected)
+	case *GoldenAssertion:
+		return fmt.Sprintf("Nothing changed from golden observation %s beyond the tolerances", t.Golden.ID)
+	}
+	return a.String()
+}
+
+func describeLED(a *LEDAssertion) string {
+	var parts []string
+	e := a.Expected
+	if e.On != nil {
+		parts = append(parts, "is "+strings.ToLower(onOffString(*e.On)))
+	}
+	if e.Blinking != nil {
+		if *e.Blinking {
+			parts = append(parts, "is blinking (at any rate)")
+		} else {
+			parts = append(parts, "is steady (not blinking)")
+		}
+	}
+	if e.BlinkHz != nil {
+		parts = append(parts, fmt.Sprintf("blinks at %g Hz (within the blink-rate tolerance)", *e.BlinkHz))
+	}
+	if e.Color != nil || e.ColorName != "" {
+		color := e.ColorName + " (by hue range)"
+		if e.ColorName == "" {
+			color = fmt.Sprintf("RGB(%d,%d,%d)", e.Color.R, e.Color.G, e.Color.B)
+		}
+		limit := "within the color tolerance"
+		if e.MaxDeltaE != nil {
+			limit = fmt.Sprintf("within CIEDE2000 distance %g", *e.MaxDeltaE)
+		}
+		if e.ColorName != "" && e.MaxDeltaE == nil {
+			parts = append(parts, "is "+color)
+		} else {
+			parts = append(parts, fmt.Sprintf("is %s, %s", color, limit))
+		}
+	}
+	if e.Code != nil {
+		pulses := strings.Split(*e.Code, "-")
+		parts = append(parts, fmt.Sprintf("blinks code %s (groups of %s pulses, from a high-rate capture)", *e.Code, strings.Join(pulses, ", ")))
+	}
+	if len(parts) == 0 {
+		return fmt.Sprintf("LED '%s' is present", a.Name)
+	}
+	return fmt.Sprintf("LED '%s' %s", a.Name, strings.Join(parts, " and "))
+}
+
+func describeRelation(a *LEDRelationAssertion) string {
+	switch a.Relation {
+	case RelationRate:
+		other := fmt.Sprintf("LED '%s'", a.Other)
+		if a.Ratio != 1 {
+			other = fmt.Sprintf("%s LED '%s'", ratioString(a.Ratio), a.Other)
+		}
+		if a.Op == CompareEQ {
+			return fmt.Sprintf("LED '%s' blinks at the rate of %s (within the blink-rate tolerance)", a.Name, other)
+		}
+		return fmt.Sprintf("LED '%s' blink rate is %s that of %s", a.Name, describeComparison(a.Op, "", ""), other)
+	case RelationIff:
+		return fmt.Sprintf("LED '%s' is %s exactly when LED '%s' is %s, frame by frame", a.Name, strings.ToLower(onOffString(a.On)), a.Other, strings.ToLower(onOffString(a.OtherOn)))
+	case RelationInPhase:
+		return fmt.Sprintf("LEDs '%s' and '%s' both blink, lit in the same frames", a.Name, a.Other)
+	}
+	return fmt.Sprintf("LEDs '%s' and '%s' both blink, never lit in the same frame", a.Name, a.Other)
+}
+
+// describeComparison words a comparison with value (and upper, for BETWEEN);
+// with an empty value it returns the bare relation, e.g. "below"
+func describeComparison(op Comparison, value, upper string) string {
+	var words string
+	switch op {
+	case CompareBetween:
+		return fmt.Sprintf("between %s and %s (inclusive)", value, upper)
+	case CompareLT:
+		words = "below"
+	case CompareLE:
+		words = "at most"
+	case CompareGT:
+		words = "above"
+	case CompareGE:
+		words = "at least"
+	default:
+		words = "equal to"
+		if value != "" {
+			return value
+		}
+	}
+	if value == "" {
+		return words
+	}
+	return words + " " + value
+}
+
+func similarityString(minSimilarity *float64) string {
+	if minSimilarity == nil {
+		return ""
+	}
+	return fmt.Sprintf(" (similarity at least %g, tolerating OCR misreads)", *minSimilarity)
+}
+
+func everyString(every time.Duration) string {
+	if every <= 0 {
+		return ""
+	}
+	return fmt.Sprintf(", observing every %s", formatDuration(every))
+}
+
+func dwellString(step SequenceStep) string {
+	switch {
+	case step.MinDwell > 0 && step.MaxDwell > 0:
+		return fmt.Sprintf(" (for %s to %s)", formatDuration(step.MinDwell), formatDuration(step.MaxDwell))
+	case step.MinDwell > 0:
+		return fmt.Sprintf(" (for at least %s)", formatDuration(step.MinDwell))
+	case step.MaxDwell > 0:
+		return fmt.Sprintf(" (for at most %s)", formatDuration(step.MaxDwell))
+	}
+	return ""
+}
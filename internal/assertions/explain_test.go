@@ -0,0 +1,58 @@
+package assertions
+
+import "testing"
+
+func TestExplain(t *testing.T) {
+	tests := []struct {
+		dsl  string
+		want string
+	}{
+		{"LED.power ON", "LED 'power' is on"},
+		{"LED.status BLINK 2Hz", "LED 'status' blinks at 2 Hz (within the blink-rate tolerance)"},
+		{"LED.status COLOR RGB(0,0,255) WITHIN 10", "LED 'status' is RGB(0,0,255), within CIEDE2000 distance 10"},
+		{"LED.status COLOR amber", "LED 'status' is amber (by hue range)"},
+		{"LED.err CODE 3-2", "LED 'err' blinks code 3-2 (groups of 3, 2 pulses, from a high-rate capture)"},
+		{"LED.backlight BRIGHTNESS 50% WITHIN 5%", "LED 'backlight' brightness is 50% (±5 points)"},
+		{"LED.status DUTY < 30%", "LED 'status' duty cycle (share of each blink period spent lit) is below 30%"},
+		{"LED.power BREATHING", "LED 'power' is breathing: its brightness rises and falls gradually"},
+		{"LED.heartbeat RATE == 2x LED.status", "LED 'heartbeat' blinks at the rate of 2x LED 'status' (within the blink-rate tolerance)"},
+		{"LED.a ON IFF LED.b OFF", "LED 'a' is on exactly when LED 'b' is off, frame by frame"},
+		{`Display.lcd "Ready" SIMILAR 0.8`, `Display 'lcd' shows text containing "Ready" (similarity at least 0.8, tolerating OCR misreads)`},
+		{"Display.lcd VALUE temp BETWEEN 20 AND 25", "Display 'lcd' reading 'temp' is between 20 and 25 (inclusive)"},
+		{"BootTime.first_text < 1500ms", "The boot reaches milestone 'first_text' within 1500 ms of the start trigger"},
+		{`Vision "Is the WiFi icon shown?" EXPECT yes`, `The vision model, asked "Is the WiFi icon shown?", answers yes`},
+		{"LED.power ON && !(LED.error ON || LED.warn ON)", `All of:
+  - LED 'power' is on
+  - Not:
+      - Any of:
+          - LED 'error' is on
+          - LED 'warn' is on`},
+		{"CONFIDENCE >= 0.8 EVENTUALLY 10s EVERY 2s LED.wifi ON", `With every signal's confidence at least 0.8 (otherwise INCONCLUSIVE):
+  - At some point within 10s, observing every 2s:
+      - LED 'wifi' is on`},
+		{"SEQUENCE WITHIN 60s (LED.a BLINKING && LED.a COLOR blue) FOR 2s..30s THEN LED.a STEADY FOR >= 3s", `These states in order, all within 1m:
+  1. All of (for 2s to 30s):
+       - LED 'a' is blinking (at any rate)
+       - LED 'a' is blue (by hue range)
+  2. LED 'a' is steady (not blinking) (for at least 3s)`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.dsl, func(t *testing.T) {
+			assertion, err := Parse(tt.dsl)
+			if err != nil {
+				t.Fatalf("Parse failed: %v", err)
+			}
+			if got := Explain(assertion); got != tt.want {
+				t.Errorf("Unexpected explanation:\n%s\nwant:\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExplain_UnwrapsThresholds(t *testing.T) {
+	assertion, _ := Parse("LED.power ON && LED.error OFF")
+	if got, want := Explain(WithMinConfidence(assertion, 0.8)), Explain(assertion); got != want {
+		t.Errorf("Expected thresholds not to change the explanation, got:\n%s", got)
+	}
+}
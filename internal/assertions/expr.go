@@ -0,0 +1,262 @@
+package assertions
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/perceptumx/percepta/internal/core"
+)
+
+// AndAssertion passes when every operand passes.
+// All operands are evaluated (no short-circuit) so the result lists every clause.
+type AndAssertion struct {
+	Operands []Assertion
+}
+
+func (a *AndAssertion) Evaluate(obs *core.Observation) AssertionResult {
+	children := make([]AssertionResult, 0, len(a.Operands))
+	for _, op := range a.Operands {
+		children = append(children, op.Evaluate(obs))
+	}
+	return combineAnd(a.String(), children)
+}
+
+func (a *AndAssertion) String() string {
+	parts := make([]string, 0, len(a.Operands))
+	for _, op := range a.Operands {
+		parts = append(parts, wrapOperand(op, precedenceAnd))
+	}
+	return strings.Join(parts, " && ")
+}
+
+// OrAssertion passes when at least one operand passes
+type OrAssertion struct {
+	Operands []Assertion
+}
+
+func (a *OrAssertion) Evaluate(obs *core.Observation) AssertionResult {
+	children := make([]AssertionResult, 0, len(a.Operands))
+	for _, op := range a.Operands {
+		children = append(children, op.Evaluate(obs))
+	}
+	return combineOr(a.String(), children)
+}
+
+func (a *OrAssertion) String() string {
+	parts := make([]string, 0, len(a.Operands))
+	for _, op := range a.Operands {
+		parts = append(parts, wrapOperand(op, precedenceOr))
+	}
+	return strings.Join(parts, " || ")
+}
+
+// NotAssertion inverts the outcome of its operand
+type NotAssertion struct {
+	Operand Assertion
+}
+
+func (a *NotAssertion) Evaluate(obs *core.Observation) AssertionResult {
+	return combineNot(a.String(), a.Operand.Evaluate(obs))
+}
+
+func (a *NotAssertion) String() string {
+	return "!" + wrapOperand(a.Operand, precedenceNot)
+}
+
+// combineAnd folds operand results into a conjunction result.
+// Any failing clause fails the conjunction; otherwise an inconclusive clause makes it inconclusive.
+// Confidence is the weakest passing clause, or the strongest failing clause on failure.
+func combineAnd(expr string, children []AssertionResult) AssertionResult {
+	var failed, inconclusive []string
+	minConf, maxFailConf := 1.0, 0.0
+
+	for _, child := range children {
+		if child.Confidence < minConf {
+			minConf = child.Confidence
+		}
+		switch child.Outcome() {
+		case OutcomeFail:
+			failed = append(failed, child.Expected)
+			if child.Confidence > maxFailConf {
+				maxFailConf = child.Confidence
+			}
+		case OutcomeInconclusive:
+			inconclusive = append(inconclusive, child.Expected)
+		}
+	}
+
+	if len(failed) > 0 {
+		return AssertionResult{
+			Passed:     false,
+			Expected:   expr,
+			Actual:     fmt.Sprintf("%d of %d clauses failed", len(failed), len(children)),
+			Confidence: maxFailConf,
+			Message:    fmt.Sprintf("Failed clauses: %s", strings.Join(failed, "; ")),
+			Op:         OpAnd,
+			Children:   children,
+		}
+	}
+
+	if len(inconclusive) > 0 {
+		return AssertionResult{
+			Inconclusive: true,
+			Expected:     expr,
+			Actual:       fmt.Sprintf("%d of %d clauses inconclusive", len(inconclusive), len(children)),
+			Confidence:   minConf,
+			Message:      fmt.Sprintf("Inconclusive clauses: %s", strings.Join(inconclusive, "; ")),
+			Op:           OpAnd,
+			Children:     children,
+		}
+	}
+
+	return AssertionResult{
+		Passed:     true,
+		Expected:   expr,
+		Actual:     fmt.Sprintf("all %d clauses passed", len(children)),
+		Confidence: minConf,
+		Message:    "All clauses passed",
+		Op:         OpAnd,
+		Children:   children,
+	}
+}
+
+// combineOr folds operand results into a disjunction result.
+// Any passing clause passes the disjunction; otherwise an inconclusive clause makes it inconclusive.
+// Confidence is the strongest passing clause, or the weakest failing clause on failure.
+func combineOr(expr string, children []AssertionResult) AssertionResult {
+	var passed, inconclusive []string
+	maxPassConf, minConf := 0.0, 1.0
+
+	for _, child := range children {
+		if child.Confidence < minConf {
+			minConf = child.Confidence
+		}
+		switch child.Outcome() {
+		case OutcomePass:
+			passed = append(passed, child.Expected)
+			if child.Confidence > maxPassConf {
+				maxPassConf = child.Confidence
+			}
+		case OutcomeInconclusive:
+			inconclusive = append(inconclusive, child.Expected)
+		}
+	}
+
+	if len(passed) > 0 {
+		return AssertionResult{
+			Passed:     true,
+			Expected:   expr,
+			Actual:     fmt.Sprintf("%d of %d alternatives passed", len(passed), len(children)),
+			Confidence: maxPassConf,
+			Message:    fmt.Sprintf("Matched: %s", strings.Join(passed, "; ")),
+			Op:         OpOr,
+			Children:   children,
+		}
+	}
+
+	if len(inconclusive) > 0 {
+		return AssertionResult{
+			Inconclusive: true,
+			Expected:     expr,
+			Actual:       fmt.Sprintf("%d of %d alternatives inconclusive", len(inconclusive), len(children)),
+			Confidence:   minConf,
+			Message:      fmt.Sprintf("Inconclusive alternatives: %s", strings.Join(inconclusive, "; ")),
+			Op:           OpOr,
+			Children:     children,
+		}
+	}
+
+	return AssertionResult{
+		Passed:     false,
+		Expected:   expr,
+		Actual:     fmt.Sprintf("none of %d alternatives passed", len(children)),
+		Confidence: minConf,
+		Message:    "No alternative matched",
+		Op:         OpOr,
+		Children:   children,
+	}
+}
+
+// combineNot inverts a single operand result (an inconclusive operand stays inconclusive)
+func combineNot(expr string, child AssertionResult) AssertionResult {
+	result := AssertionResult{
+		Passed:       !child.Passed && !child.Inconclusive,
+		Inconclusive: child.Inconclusive,
+		Expected:     expr,
+		Actual:       child.Actual,
+		Confidence:   child.Confidence,
+		Op:           OpNot,
+		Children:     []AssertionResult{child},
+	}
+	if result.Inconclusive {
+		result.Message = fmt.Sprintf("Negated clause is inconclusive: %s", child.Expected)
+	} else if result.Passed {
+		result.Message = fmt.Sprintf("Negated clause did not hold: %s", child.Expected)
+	} else {
+		result.Message = fmt.Sprintf("Negated clause unexpectedly held: %s", child.Expected)
+	}
+	return result
+}
+
+// Operator precedence used to decide where String() needs parentheses
+const (
+	precedenceOr = iota + 1
+	precedenceAnd
+	precedenceNot
+	precedenceAtom
+)
+
+func precedenceOf(a Assertion) int {
+	switch a.(type) {
+	case *OrAssertion:
+		return precedenceOr
+	case *AndAssertion:
+		return precedenceAnd
+	case *NotAssertion, *EventuallyAssertion, *AlwaysAssertion, *ConfidenceAssertion, *SequenceAssertion:
+		return precedenceNot
+	}
+	return precedenceAtom
+}
+
+// operandsOf returns the direct sub-assertions of a composite assertion
+func operandsOf(a Assertion) []Assertion {
+	switch t := a.(type) {
+	case *AndAssertion:
+		return t.Operands
+	case *OrAssertion:
+		return t.Operands
+	case *NotAssertion:
+		return []Assertion{t.Operand}
+	case *EventuallyAssertion:
+		return []Assertion{t.Operand}
+	case *AlwaysAssertion:
+		return []Assertion{t.Operand}
+	case *ConfidenceAssertion:
+		return []Assertion{t.Operand}
+	case *SequenceAssertion:
+		states := make([]Assertion, 0, len(t.Steps))
+		for _, step := range t.Steps {
+			states = append(states, step.State)
+		}
+		return states
+	}
+	return nil
+}
+
+// Walk visits the assertion tree depth-first, stopping descent when fn returns false
+func Walk(a Assertion, fn func(Assertion) bool) {
+	if !fn(a) {
+		return
+	}
+	for _, op := range operandsOf(a) {
+		Walk(op, fn)
+	}
+}
+
+// wrapOperand parenthesises an operand that binds looser than its parent
+func wrapOperand(a Assertion, parent int) string {
+	if precedenceOf(a) < parent || (parent == precedenceNot && precedenceOf(a) != precedenceAtom) {
+		return "(" + a.String() + ")"
+	}
+	return a.String()
+}
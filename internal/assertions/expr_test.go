@@ -0,0 +1,313 @@
+package assertions
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/perceptumx/percepta/internal/core"
+)
+
+func TestLex_Tokens(t *testing.T) {
+	tokens, err := lex(`led('LED1').blinks() && !LED.x-y ON || Display.LCD "a && b"`)
+	if err != nil {
+		t.Fatalf("lex failed: %v", err)
+	}
+
+	want := []tokenKind{
+		tokIdent, tokLParen, tokString, tokRParen, tokDot, tokIdent, tokLParen, tokRParen,
+		tokAnd, tokNot, tokIdent, tokDot, tokIdent, tokIdent, tokOr, tokIdent, tokDot, tokIdent, tokString, tokEOF,
+	}
+	if len(tokens) != len(want) {
+		t.Fatalf("Expected %d tokens, got %d: %+v", len(want), len(tokens), tokens)
+	}
+	for i, kind := range want {
+		if tokens[i].kind != kind {
+			t.Errorf("token %d: expected %s, got %s (%q)", i, kind, tokens[i].kind, tokens[i].text)
+		}
+	}
+	if tokens[12].text != "x-y" {
+		t.Errorf("Expected hyphenated identifier 'x-y', got %q", tokens[12].text)
+	}
+	if tokens[18].text != "a && b" {
+		t.Errorf("Expected string contents preserved, got %q", tokens[18].text)
+	}
+}
+
+func TestLex_UnterminatedString(t *testing.T) {
+	_, err := lex(`Display.LCD "Ready`)
+	if err == nil || !strings.Contains(err.Error(), "unterminated string") {
+		t.Errorf("Expected unterminated string error, got %v", err)
+	}
+}
+
+func TestParse_MethodCallPredicates(t *testing.T) {
+	tests := []struct {
+		dsl    string
+		expect string
+	}{
+		{"led('LED1').is_on()", "LED.LED1 ON"},
+		{"led('LED1').is_off()", "LED.LED1 OFF"},
+		{"led('LED1').blinks()", "LED.LED1 BLINKING"},
+		{"led('LED1').blinks(2.5)", "LED.LED1 2.50 Hz"},
+		{"led('LED1').color_rgb(0,0,255)", "LED.LED1 RGB(0,0,255)"},
+		{`display("LCD").shows("Ready")`, `Display.LCD "Ready"`},
+		{`display('LCD').contains('Ready')`, `Display.LCD "Ready"`},
+		{`display('LCD').changed('Boot', 'Ready')`, `Display.LCD CHANGED "Boot" -> "Ready"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.dsl, func(t *testing.T) {
+			assertion, err := Parse(tt.dsl)
+			if err != nil {
+				t.Fatalf("Parse failed: %v", err)
+			}
+			if assertion.String() != tt.expect {
+				t.Errorf("Expected %q, got %q", tt.expect, assertion.String())
+			}
+		})
+	}
+}
+
+// TestParse_DocsExamples parses every assertion the shipped examples show:
+// the "percepta assert <device> "<expr>"" commands in their comments and the
+// assertions of suite files
+func TestParse_DocsExamples(t *testing.T) {
+	files, err := filepath.Glob(filepath.Join("..", "..", "docs", "examples", "*.yaml"))
+	if err != nil || len(files) == 0 {
+		t.Fatalf("Expected example files, got %v (%v)", files, err)
+	}
+	command := regexp.MustCompile(`percepta assert [\w-]+ "(.+)"`)
+
+	checked := 0
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, m := range command.FindAllStringSubmatch(string(data), -1) {
+			checked++
+			if pe := Check(m[1]); pe != nil {
+				t.Errorf("%s: %q: %v", filepath.Base(file), m[1], pe)
+			}
+		}
+		if strings.Contains(string(data), "\ncases:") {
+			suite, err := LoadSuite(file)
+			if err == nil {
+				err = suite.Compile()
+			}
+			if err != nil {
+				t.Errorf("%s: %v", filepath.Base(file), err)
+			}
+			checked++
+		}
+	}
+	if checked == 0 {
+		t.Error("Expected assertions in the examples")
+	}
+}
+
+func TestParse_Precedence(t *testing.T) {
+	assertion, err := Parse("LED.A ON || LED.B ON && !LED.C ON")
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	or, ok := assertion.(*OrAssertion)
+	if !ok {
+		t.Fatalf("Expected *OrAssertion at root, got %T", assertion)
+	}
+	if len(or.Operands) != 2 {
+		t.Fatalf("Expected 2 operands, got %d", len(or.Operands))
+	}
+	and, ok := or.Operands[1].(*AndAssertion)
+	if !ok {
+		t.Fatalf("Expected && to bind tighter than ||, got %T", or.Operands[1])
+	}
+	if _, ok := and.Operands[1].(*NotAssertion); !ok {
+		t.Errorf("Expected ! operand, got %T", and.Operands[1])
+	}
+}
+
+func TestParse_GroupingAndRoundTrip(t *testing.T) {
+	dsl := "LED.power ON && !(LED.error ON || LED.warn ON)"
+	assertion, err := Parse(dsl)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if assertion.String() != dsl {
+		t.Errorf("Expected round-trip %q, got %q", dsl, assertion.String())
+	}
+
+	reparsed, err := Parse(assertion.String())
+	if err != nil {
+		t.Fatalf("Re-parse failed: %v", err)
+	}
+	if reparsed.String() != dsl {
+		t.Errorf("Expected stable String(), got %q", reparsed.String())
+	}
+}
+
+func TestParse_StatementWithParensInsideExpression(t *testing.T) {
+	assertion, err := Parse("(LED.RGB1 COLOR RGB(255, 128, 0)) && BootTime < 3000ms")
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	and, ok := assertion.(*AndAssertion)
+	if !ok {
+		t.Fatalf("Expected *AndAssertion, got %T", assertion)
+	}
+	if _, ok := and.Operands[0].(*LEDAssertion); !ok {
+		t.Errorf("Expected *LEDAssertion, got %T", and.Operands[0])
+	}
+	if _, ok := and.Operands[1].(*TimingAssertion); !ok {
+		t.Errorf("Expected *TimingAssertion, got %T", and.Operands[1])
+	}
+}
+
+func TestParse_ExpressionErrors(t *testing.T) {
+	tests := []struct {
+		dsl     string
+		wantErr string
+	}{
+		{"", "empty assertion"},
+		{"LED.A ON &&", "unexpected end of expression"},
+		{"(LED.A ON", "expected ')'"},
+		{"LED.A ON)", "unexpected ')'"},
+		{"led('A').glows()", "unknown led method"},
+		{"led(1).is_on()", "single quoted name"},
+		{"led('A').color_rgb(1,2)", "takes 3 argument(s)"},
+		{"led('A').color_rgb(1,2,300)", "must be an integer 0-255"},
+		{"lamp('A').is_on()", "unknown subject"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.dsl, func(t *testing.T) {
+			_, err := Parse(tt.dsl)
+			if err == nil {
+				t.Fatalf("Expected error containing %q", tt.wantErr)
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Expected error containing %q, got %q", tt.wantErr, err.Error())
+			}
+		})
+	}
+}
+
+func TestAndAssertion_ReportsFailingClause(t *testing.T) {
+	assertion, err := Parse("led('LED1').blinks() && led('LED1').color_rgb(0,255,0)")
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	obs := observation("test-1", core.LEDSignal{Name: "LED1", On: true, BlinkHz: 2.0, Color: core.RGB{B: 255}, Confidence: 0.9})
+	result := assertion.Evaluate(obs)
+	if result.Passed {
+		t.Fatal("Expected conjunction to fail on color clause")
+	}
+	if result.Op != OpAnd || len(result.Children) != 2 {
+		t.Fatalf("Expected and-result with 2 children, got op=%q children=%d", result.Op, len(result.Children))
+	}
+	if !result.Children[0].Passed {
+		t.Errorf("Expected blink clause to pass: %s", result.Children[0].Message)
+	}
+	if result.Children[1].Passed {
+		t.Error("Expected color clause to fail")
+	}
+	if !strings.Contains(result.Message, "LED.LED1 RGB(0,255,0)") {
+		t.Errorf("Expected message to name failing clause, got %q", result.Message)
+	}
+}
+
+func TestAndAssertion_Pass(t *testing.T) {
+	assertion, err := Parse(`led('LED1').blinks() && led('LED1').color_rgb(0,0,255) && Display.LCD "Ready"`)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	obs := observation("test-1",
+		core.LEDSignal{Name: "LED1", On: true, BlinkHz: 2.0, Color: core.RGB{B: 255}, Confidence: 0.9},
+		core.DisplaySignal{Name: "LCD", Text: "System Ready", Confidence: 0.95},
+	)
+	result := assertion.Evaluate(obs)
+	if !result.Passed {
+		t.Fatalf("Expected pass, got: %s", result.Message)
+	}
+	if result.Confidence != 0.9 {
+		t.Errorf("Expected confidence of weakest clause (0.9), got %.2f", result.Confidence)
+	}
+}
+
+func TestOrAssertion(t *testing.T) {
+	assertion, err := Parse("LED.ERROR ON || LED.LED1 ON")
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	obs := observation("test-1",
+		core.LEDSignal{Name: "ERROR", On: false, Confidence: 0.8},
+		core.LEDSignal{Name: "LED1", On: true, BlinkHz: 2.0, Color: core.RGB{B: 255}, Confidence: 0.9},
+	)
+	result := assertion.Evaluate(obs)
+	if !result.Passed {
+		t.Fatalf("Expected disjunction to pass, got: %s", result.Message)
+	}
+	if result.Children[0].Passed || !result.Children[1].Passed {
+		t.Error("Expected only second alternative to pass")
+	}
+	if result.Confidence != 0.9 {
+		t.Errorf("Expected confidence of passing alternative (0.9), got %.2f", result.Confidence)
+	}
+}
+
+func TestNotAssertion(t *testing.T) {
+	assertion, err := Parse("!LED.ERROR ON")
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	obs := observation("test-1", core.LEDSignal{Name: "ERROR", On: false, Confidence: 0.8})
+	result := assertion.Evaluate(obs)
+	if !result.Passed {
+		t.Fatalf("Expected negation to pass, got: %s", result.Message)
+	}
+	if result.Op != OpNot || len(result.Children) != 1 || result.Children[0].Passed {
+		t.Errorf("Expected not-result wrapping failed child, got %+v", result)
+	}
+}
+
+func TestLEDAssertion_ValueSignals(t *testing.T) {
+	// The vision pipeline emits value (not pointer) signals
+	on := true
+	assertion := &LEDAssertion{Name: "led1", Expected: LEDState{On: &on}}
+
+	obs := observation("test-1", core.LEDSignal{Name: "LED1", On: true, BlinkHz: 2.0, Color: core.RGB{B: 255}, Confidence: 0.9})
+	result := assertion.Evaluate(obs)
+	if !result.Passed {
+		t.Errorf("Expected value LED signal to match, got: %s", result.Message)
+	}
+}
+
+func TestLEDAssertion_Blinking(t *testing.T) {
+	steady := false
+	assertion := &LEDAssertion{Name: "ERROR", Expected: LEDState{Blinking: &steady}}
+
+	obs := observation("test-1", core.LEDSignal{Name: "ERROR", On: false, Confidence: 0.8})
+	result := assertion.Evaluate(obs)
+	if !result.Passed {
+		t.Errorf("Expected steady LED to satisfy non-blinking expectation, got: %s", result.Message)
+	}
+}
+
+func TestParse_LED_Blinking(t *testing.T) {
+	assertion, err := Parse("LED.STATUS BLINKING")
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	led := assertion.(*LEDAssertion)
+	if led.Expected.Blinking == nil || !*led.Expected.Blinking {
+		t.Error("Expected Blinking to be true")
+	}
+}
@@ -0,0 +1,101 @@
+package assertions
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/perceptumx/percepta/internal/core"
+	"github.com/perceptumx/percepta/internal/diff"
+	"github.com/perceptumx/percepta/internal/tolerance"
+)
+
+// GoldenAssertion is a snapshot check: it passes when an observation shows no
+// changes from the device's golden observation beyond the tolerance profile,
+// as reported by diff.CompareWithTolerance. Each change is reported as a
+// failing clause.
+//
+// It has no DSL syntax; `percepta assert --matches-golden` builds it from the
+// golden stored for the device.
+type GoldenAssertion struct {
+	Golden *core.Observation
+
+	profile *tolerance.Profile
+}
+
+func (a *GoldenAssertion) Evaluate(obs *core.Observation) AssertionResult {
+	result := diff.CompareWithTolerance(a.Golden, obs, profileOrDefault(a.profile))
+	changes := result.Changes
+	sort.SliceStable(changes, func(i, j int) bool { return changes[i].Name < changes[j].Name })
+
+	confidence := signalConfidence(obs)
+	if !result.HasChanges() {
+		return AssertionResult{
+			Passed:     true,
+			Expected:   a.String(),
+			Actual:     fmt.Sprintf("No changes from golden %s", a.Golden.ID),
+			Confidence: confidence,
+			Message:    fmt.Sprintf("All %d signals match the golden observation", len(a.Golden.Signals)),
+		}
+	}
+
+	children := make([]AssertionResult, 0, len(changes))
+	lines := make([]string, 0, len(changes))
+	for _, change := range changes {
+		lines = append(lines, change.String())
+		children = append(children, AssertionResult{
+			Passed:        false,
+			Expected:      fmt.Sprintf("%s: %s", change.Name, stateOrAbsent(change.FromState)),
+			Actual:        fmt.Sprintf("%s: %s", change.Name, stateOrAbsent(change.ToState)),
+			Confidence:    confidence,
+			Message:       change.String(),
+			SignalMissing: change.Type == diff.ChangeRemoved,
+		})
+	}
+
+	// A signal that disappeared fails regardless of confidence, like a missing LED
+	added, removed, modified := result.CountByType()
+	return AssertionResult{
+		Passed:        false,
+		Expected:      a.String(),
+		Actual:        fmt.Sprintf("%d change(s) from golden %s: %d added, %d removed, %d modified", len(changes), a.Golden.ID, added, removed, modified),
+		Confidence:    confidence,
+		Message:       strings.Join(lines, "; "),
+		Children:      children,
+		SignalMissing: removed > 0,
+	}
+}
+
+func (a *GoldenAssertion) String() string {
+	return fmt.Sprintf("MATCHES GOLDEN %s", a.Golden.ID)
+}
+
+func stateOrAbsent(state string) string {
+	if state == "" {
+		return "absent"
+	}
+	return state
+}
+
+// signalConfidence is the weakest confidence among the observation's LED and
+// display signals; an observation without any has nothing uncertain in it
+func signalConfidence(obs *core.Observation) float64 {
+	confidence := math.Inf(1)
+	for _, sig := range obs.Signals {
+		switch s := sig.(type) {
+		case core.LEDSignal:
+			confidence = math.Min(confidence, s.Confidence)
+		case *core.LEDSignal:
+			confidence = math.Min(confidence, s.Confidence)
+		case core.DisplaySignal:
+			confidence = math.Min(confidence, s.Confidence)
+		case *core.DisplaySignal:
+			confidence = math.Min(confidence, s.Confidence)
+		}
+	}
+	if math.IsInf(confidence, 1) {
+		return 1
+	}
+	return confidence
+}
@@ -0,0 +1,85 @@
+package assertions
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/perceptumx/percepta/internal/core"
+	"github.com/perceptumx/percepta/internal/tolerance"
+)
+
+func TestGoldenAssertion_Pass(t *testing.T) {
+	golden := observation("golden-1",
+		core.LEDSignal{Name: "power", On: true, Color: core.RGB{G: 255}, Confidence: 0.9},
+		core.LEDSignal{Name: "status", On: true, BlinkHz: 2, Confidence: 0.9},
+		core.DisplaySignal{Name: "LCD", Text: "Ready", Confidence: 0.9},
+	)
+	// Within the default tolerances: a slightly different blink rate and green
+	fresh := observation("obs-2",
+		core.LEDSignal{Name: "power", On: true, Color: core.RGB{R: 3, G: 252}, Confidence: 0.8},
+		core.LEDSignal{Name: "status", On: true, BlinkHz: 2.1, Confidence: 0.7},
+		core.DisplaySignal{Name: "LCD", Text: "Ready", Confidence: 0.9},
+	)
+
+	result := (&GoldenAssertion{Golden: golden}).Evaluate(fresh)
+	if !result.Passed {
+		t.Fatalf("Expected a match within tolerance: %s", result.Message)
+	}
+	if result.Actual != "No changes from golden golden-1" || result.Confidence != 0.7 {
+		t.Errorf("Unexpected result: %q [%.2f]", result.Actual, result.Confidence)
+	}
+}
+
+func TestGoldenAssertion_Changes(t *testing.T) {
+	golden := observation("golden-1",
+		core.LEDSignal{Name: "power", On: true, Color: core.RGB{G: 255}, Confidence: 0.9},
+		core.LEDSignal{Name: "status", On: true, BlinkHz: 2, Confidence: 0.9},
+		core.DisplaySignal{Name: "LCD", Text: "Ready", Confidence: 0.9},
+	)
+	fresh := observation("obs-2",
+		core.LEDSignal{Name: "power", On: false, Confidence: 0.9},
+		core.LEDSignal{Name: "error", On: true, Confidence: 0.9},
+		core.DisplaySignal{Name: "LCD", Text: "Ready", Confidence: 0.9},
+	)
+
+	result := (&GoldenAssertion{Golden: golden}).Evaluate(fresh)
+	if result.Passed {
+		t.Fatal("Expected changes to fail the golden check")
+	}
+	if result.Actual != "3 change(s) from golden golden-1: 1 added, 1 removed, 1 modified" {
+		t.Errorf("Unexpected actual: %q", result.Actual)
+	}
+	if !result.SignalMissing {
+		t.Error("Expected a removed signal to be flagged as missing")
+	}
+	if len(result.Children) != 3 || !strings.HasPrefix(result.Children[0].Message, "+ error:") {
+		t.Errorf("Expected one clause per change, sorted by name, got %+v", result.Children)
+	}
+	if result.Children[2].Actual != "status: absent" {
+		t.Errorf("Expected the removed LED to be absent, got %q", result.Children[2].Actual)
+	}
+}
+
+func TestGoldenAssertion_WithTolerance(t *testing.T) {
+	stored := observation("golden-1",
+		core.LEDSignal{Name: "power", On: true, Color: core.RGB{G: 255}, Confidence: 0.9},
+		core.LEDSignal{Name: "status", On: true, BlinkHz: 2, Confidence: 0.9},
+		core.DisplaySignal{Name: "LCD", Text: "Ready", Confidence: 0.9},
+	)
+	fresh := observation("obs-2",
+		core.LEDSignal{Name: "power", On: true, Color: core.RGB{G: 255}, Confidence: 0.9},
+		core.LEDSignal{Name: "status", On: true, BlinkHz: 2.1, Confidence: 0.9},
+		core.DisplaySignal{Name: "LCD", Text: "Ready", Confidence: 0.9},
+	)
+
+	golden := &GoldenAssertion{Golden: stored}
+	if result := WithTolerance(golden, tolerance.Exact()).Evaluate(fresh); result.Passed {
+		t.Error("Expected an exact profile to report the blink rate change")
+	}
+	if result := WithMinConfidence(golden, 0.95).Evaluate(fresh); !result.Inconclusive {
+		t.Errorf("Expected weak signals to make the check INCONCLUSIVE, got %+v", result)
+	}
+	if golden.String() != "MATCHES GOLDEN golden-1" {
+		t.Errorf("Unexpected String(): %q", golden.String())
+	}
+}
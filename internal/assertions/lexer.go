@@ -0,0 +1,264 @@
+package assertions
+
+import (
+	"fmt"
+	"strings"
+)
+
+// tokenKind identifies the lexical class of a token
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokIdent
+	tokNumber
+	tokString
+	tokAnd     // &&
+	tokOr      // ||
+	tokNot     // !
+	tokLParen  // (
+	tokRParen  // )
+	tokComma   // ,
+	tokDot     // .
+	tokArrow   // ->
+	tokLT      // <
+	tokGT      // >
+	tokLE      // <=
+	tokGE      // >=
+	tokEQ      // ==
+	tokNE      // !=
+	tokRegex   // /pattern/
+	tokPercent // %
+)
+
+func (k tokenKind) String() string {
+	switch k {
+	case tokEOF:
+		return "end of expression"
+	case tokIdent:
+		return "identifier"
+	case tokNumber:
+		return "number"
+	case tokString:
+		return "string"
+	case tokAnd:
+		return "'&&'"
+	case tokOr:
+		return "'||'"
+	case tokNot:
+		return "'!'"
+	case tokLParen:
+		return "'('"
+	case tokRParen:
+		return "')'"
+	case tokComma:
+		return "','"
+	case tokDot:
+		return "'.'"
+	case tokArrow:
+		return "'->'"
+	case tokLT:
+		return "'<'"
+	case tokGT:
+		return "'>'"
+	case tokLE:
+		return "'<='"
+	case tokGE:
+		return "'>='"
+	case tokEQ:
+		return "'=='"
+	case tokNE:
+		return "'!='"
+	case tokRegex:
+		return "regex"
+	case tokPercent:
+		return "'%'"
+	}
+	return "unknown token"
+}
+
+// token is a single lexeme with its byte span in the source expression
+type token struct {
+	kind tokenKind
+	text string // Literal text (unquoted for strings, without slashes for regexes)
+	pos  int    // Byte offset of first character
+	end  int    // Byte offset one past the last character
+}
+
+// lex splits an assertion expression into tokens
+func lex(src string) ([]token, error) {
+	var tokens []token
+	i := 0
+
+	for i < len(src) {
+		ch := src[i]
+
+		// Skip whitespace
+		if ch == ' ' || ch == '\t' || ch == '\n' || ch == '\r' {
+			i++
+			continue
+		}
+
+		start := i
+		switch {
+		case isIdentStart(ch):
+			i++
+			for i < len(src) && isIdentPart(src, i) {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokIdent, text: src[start:i], pos: start, end: i})
+
+		case isDigit(ch) || (ch == '-' && i+1 < len(src) && isDigit(src[i+1])):
+			i++
+			// Hyphens between digits keep blink codes (3-2) in one token
+			for i < len(src) && (isDigit(src[i]) || ((src[i] == '.' || src[i] == '-') && i+1 < len(src) && isDigit(src[i+1]))) {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokNumber, text: src[start:i], pos: start, end: i})
+
+		case ch == '"' || ch == '\'':
+			text, next, err := lexString(src, i)
+			if err != nil {
+				return nil, err
+			}
+			i = next
+			tokens = append(tokens, token{kind: tokString, text: text, pos: start, end: i})
+
+		case ch == '/':
+			pattern, next, err := lexRegex(src, i)
+			if err != nil {
+				return nil, err
+			}
+			i = next
+			tokens = append(tokens, token{kind: tokRegex, text: pattern, pos: start, end: i})
+
+		default:
+			kind, width := lexOperator(src, i)
+			if width == 0 {
+				err := &ParseError{Column: start + 1, Message: fmt.Sprintf("unexpected character %q at column %d", ch, start+1)}
+				if ch == '=' {
+					err.Suggestion = "=="
+				}
+				return nil, err
+			}
+			i += width
+			tokens = append(tokens, token{kind: kind, text: src[start:i], pos: start, end: i})
+		}
+	}
+
+	tokens = append(tokens, token{kind: tokEOF, pos: len(src), end: len(src)})
+	return tokens, nil
+}
+
+// lexString reads a quoted string starting at src[start], honouring backslash escapes
+func lexString(src string, start int) (string, int, error) {
+	quote := src[start]
+	var b strings.Builder
+	i := start + 1
+
+	for i < len(src) {
+		ch := src[i]
+		if ch == '\\' && i+1 < len(src) {
+			b.WriteByte(src[i+1])
+			i += 2
+			continue
+		}
+		if ch == quote {
+			return b.String(), i + 1, nil
+		}
+		b.WriteByte(ch)
+		i++
+	}
+
+	return "", 0, errorAt(start, "unterminated string starting at column %d", start+1)
+}
+
+// lexRegex reads a /pattern/ literal starting at src[start]. Backslashes are
+// kept for the regex engine, except in "\/", which stands for a literal slash.
+func lexRegex(src string, start int) (string, int, error) {
+	var b strings.Builder
+	i := start + 1
+
+	for i < len(src) {
+		ch := src[i]
+		if ch == '\\' && i+1 < len(src) {
+			if src[i+1] != '/' {
+				b.WriteByte(ch)
+			}
+			b.WriteByte(src[i+1])
+			i += 2
+			continue
+		}
+		if ch == '/' {
+			return b.String(), i + 1, nil
+		}
+		b.WriteByte(ch)
+		i++
+	}
+
+	return "", 0, errorAt(start, "unterminated regex starting at column %d", start+1)
+}
+
+// lexOperator matches punctuation at src[i], returning its kind and byte width
+func lexOperator(src string, i int) (tokenKind, int) {
+	two := ""
+	if i+1 < len(src) {
+		two = src[i : i+2]
+	}
+
+	switch two {
+	case "&&":
+		return tokAnd, 2
+	case "||":
+		return tokOr, 2
+	case "->":
+		return tokArrow, 2
+	case "<=":
+		return tokLE, 2
+	case ">=":
+		return tokGE, 2
+	case "==":
+		return tokEQ, 2
+	case "!=":
+		return tokNE, 2
+	}
+
+	switch src[i] {
+	case '!':
+		return tokNot, 1
+	case '(':
+		return tokLParen, 1
+	case ')':
+		return tokRParen, 1
+	case ',':
+		return tokComma, 1
+	case '.':
+		return tokDot, 1
+	case '<':
+		return tokLT, 1
+	case '>':
+		return tokGT, 1
+	case '%':
+		return tokPercent, 1
+	}
+
+	return tokEOF, 0
+}
+
+func isIdentStart(ch byte) bool {
+	return (ch >= 'a' && ch <= 'z') || (ch >= 'A' && ch <= 'Z') || ch == '_'
+}
+
+// isIdentPart reports whether src[i] continues an identifier.
+// Hyphens are allowed inside names (LED.wifi-status) but not when they start "->".
+func isIdentPart(src string, i int) bool {
+	ch := src[i]
+	if ch == '-' {
+		return i+1 < len(src) && src[i+1] != '>'
+	}
+	return isIdentStart(ch) || isDigit(ch)
+}
+
+func isDigit(ch byte) bool {
+	return ch >= '0' && ch <= '9'
+}
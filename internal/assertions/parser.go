@@ -5,34 +5,756 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/perceptumx/percepta/internal/core"
+	"github.com/perceptumx/percepta/internal/tolerance"
 )
 
-// Parse converts DSL string to Assertion
+// blinkCodePattern matches a blink code: pulse counts separated by '-', e.g. "3-2"
+var blinkCodePattern = regexp.MustCompile(`^[1-9]\d*(-[1-9]\d*)*$`)
+
+// Relations between two LEDs: LED.a RATE == 2x LED.b, LED.a ON IFF LED.b OFF,
+// LED.a IN PHASE WITH LED.b, LED.a ALTERNATES WITH LED.b
+var (
+	ledRelationPattern = regexp.MustCompile(`^LED\.\S+\s.*\bLED\.`)
+	ledRatePattern     = regexp.MustCompile(`(?i)^LED\.([a-zA-Z0-9_-]+)\s+RATE\s*(==|<=|>=|<|>)\s*(?:([\d.]+)\s*x\s+)?LED\.([a-zA-Z0-9_-]+)$`)
+	ledIffPattern      = regexp.MustCompile(`(?i)^LED\.([a-zA-Z0-9_-]+)\s+(ON|OFF)\s+IFF\s+LED\.([a-zA-Z0-9_-]+)\s+(ON|OFF)$`)
+	ledPhasePattern    = regexp.MustCompile(`(?i)^LED\.([a-zA-Z0-9_-]+)\s+(IN\s+PHASE|ALTERNATES)\s+WITH\s+LED\.([a-zA-Z0-9_-]+)$`)
+)
+
+// Brightness and duty-cycle levels: LED.a BRIGHTNESS 50% [WITHIN 5%],
+// LED.a DUTY > 20%, LED.a BRIGHTNESS BETWEEN 40% AND 60%; and fade effects:
+// LED.a BREATHING, LED.a FADING IN|OUT
+var (
+	ledLevelStatement = regexp.MustCompile(`(?i)^LED\.\S+\s+(BRIGHTNESS|DUTY|BREATHING|FADING)\b`)
+	ledLevelPattern   = regexp.MustCompile(`(?i)^LED\.([a-zA-Z0-9_-]+)\s+(BRIGHTNESS|DUTY)\s+(.+)$`)
+	ledFadePattern    = regexp.MustCompile(`(?i)^LED\.([a-zA-Z0-9_-]+)\s+(BREATHING|FADING\s+IN|FADING\s+OUT)$`)
+	levelBetween      = regexp.MustCompile(`(?i)^BETWEEN\s+([\d.]+)\s*%\s+AND\s+([\d.]+)\s*%$`)
+	levelCompare      = regexp.MustCompile(`(?i)^(==|<=|>=|<|>)?\s*([\d.]+)\s*%(?:\s+WITHIN\s+([\d.]+)\s*%?)?$`)
+)
+
+// Parse converts a DSL expression to an Assertion.
+//
+// Grammar:
+//
+//	expr      := or
+//	or        := and ( "||" and )*
+//	and       := unary ( "&&" unary )*
+//	unary     := "!" unary | temporal | primary
+//	temporal  := ("EVENTUALLY" | "ALWAYS") duration [ "EVERY" duration ] unary
+//	primary   := "(" expr ")" | call | statement
+//	call      := ("led" | "display") "(" string ")" "." method "(" args ")"
+//	statement := LED.name ... | Display.name ... | BootTime[.milestone] < Nms
+//	           | Vision "question" EXPECT (yes | no | "text")
+//
+// A lone statement or call returns its leaf assertion (e.g. *LEDAssertion);
+// operators produce *AndAssertion, *OrAssertion and *NotAssertion trees.
 func Parse(dsl string) (Assertion, error) {
-	dsl = strings.TrimSpace(dsl)
+	// Columns count from the start of dsl as given, leading space included
+	if strings.TrimSpace(dsl) == "" {
+		return nil, fmt.Errorf("empty assertion")
+	}
+
+	tokens, err := lex(dsl)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{src: dsl, tokens: tokens}
+	assertion, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+
+	if tok := p.peek(); tok.kind != tokEOF {
+		return nil, errorAt(tok.pos, "unexpected %s %q at column %d", tok.kind, tok.text, tok.pos+1)
+	}
+
+	return assertion, nil
+}
+
+// parser is a recursive-descent parser over lexed tokens
+type parser struct {
+	src    string
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	tok := p.tokens[p.pos]
+	if tok.kind != tokEOF {
+		p.pos++
+	}
+	return tok
+}
+
+func (p *parser) expect(kind tokenKind) (token, error) {
+	tok := p.next()
+	if tok.kind != kind {
+		return tok, errorAt(tok.pos, "expected %s at column %d, got %s %q", kind, tok.pos+1, tok.kind, tok.text)
+	}
+	return tok, nil
+}
+
+func (p *parser) parseOr() (Assertion, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	operands := []Assertion{left}
+	for p.peek().kind == tokOr {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		operands = append(operands, right)
+	}
+
+	if len(operands) == 1 {
+		return left, nil
+	}
+	return &OrAssertion{Operands: operands}, nil
+}
+
+func (p *parser) parseAnd() (Assertion, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	operands := []Assertion{left}
+	for p.peek().kind == tokAnd {
+		p.next()
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		operands = append(operands, right)
+	}
+
+	if len(operands) == 1 {
+		return left, nil
+	}
+	return &AndAssertion{Operands: operands}, nil
+}
+
+func (p *parser) parseUnary() (Assertion, error) {
+	if p.peek().kind == tokNot {
+		p.next()
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &NotAssertion{Operand: operand}, nil
+	}
+	if tok := p.peek(); tok.kind == tokIdent && (tok.text == "EVENTUALLY" || tok.text == "ALWAYS") {
+		return p.parseTemporal()
+	}
+	if tok := p.peek(); tok.kind == tokIdent && tok.text == "CONFIDENCE" {
+		return p.parseConfidence()
+	}
+	if tok := p.peek(); tok.kind == tokIdent && tok.text == "SEQUENCE" {
+		return p.parseSequence()
+	}
+	return p.parsePrimary()
+}
+
+// parseConfidence parses CONFIDENCE >= <min> <operand>
+func (p *parser) parseConfidence() (Assertion, error) {
+	p.next()
+	if op, err := p.expect(tokGE); err != nil {
+		return nil, errorAt(op.pos, "expected '>=' after CONFIDENCE at column %d", op.pos+1)
+	}
+	num, err := p.expect(tokNumber)
+	if err != nil {
+		return nil, errorAt(num.pos, "expected confidence threshold (0-1) at column %d", num.pos+1)
+	}
+	min, err := strconv.ParseFloat(num.text, 64)
+	if err != nil || min <= 0 || min > 1 {
+		return nil, errorAt(num.pos, "confidence threshold %q at column %d must be between 0 and 1", num.text, num.pos+1)
+	}
+
+	operand, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	return &ConfidenceAssertion{Operand: operand, Min: min}, nil
+}
+
+// parseTemporal parses EVENTUALLY/ALWAYS <window> [EVERY <interval>] <operand>
+func (p *parser) parseTemporal() (Assertion, error) {
+	keyword := p.next()
+	window, err := p.parseDuration()
+	if err != nil {
+		return nil, err
+	}
+	if window <= 0 {
+		return nil, errorAt(keyword.pos, "%s window at column %d must be positive", keyword.text, keyword.pos+1)
+	}
+
+	var every time.Duration
+	if tok := p.peek(); tok.kind == tokIdent && tok.text == "EVERY" {
+		p.next()
+		if every, err = p.parseDuration(); err != nil {
+			return nil, err
+		}
+	}
+
+	operand, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	if keyword.text == "EVENTUALLY" {
+		return &EventuallyAssertion{Operand: operand, Within: window, Every: every}, nil
+	}
+	return &AlwaysAssertion{Operand: operand, For: window, Every: every}, nil
+}
+
+// parseSequence parses SEQUENCE [WITHIN <d>] [EVERY <d>] <state> [FOR <dwell>] (THEN <state> [FOR <dwell>])*
+func (p *parser) parseSequence() (Assertion, error) {
+	p.next()
+	seq := &SequenceAssertion{}
+
+	for {
+		tok := p.peek()
+		if tok.kind != tokIdent || (tok.text != "WITHIN" && tok.text != "EVERY") {
+			break
+		}
+		p.next()
+		d, err := p.parseDuration()
+		if err != nil {
+			return nil, err
+		}
+		if tok.text == "WITHIN" {
+			seq.Within = d
+		} else {
+			seq.Every = d
+		}
+	}
+
+	for {
+		state, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		step := SequenceStep{State: state}
+
+		if tok := p.peek(); tok.kind == tokIdent && tok.text == "FOR" {
+			p.next()
+			if step.MinDwell, step.MaxDwell, err = p.parseDwell(); err != nil {
+				return nil, err
+			}
+		}
+		seq.Steps = append(seq.Steps, step)
 
-	// LED patterns
-	if strings.HasPrefix(dsl, "LED.") {
-		return parseLED(dsl)
+		if tok := p.peek(); tok.kind != tokIdent || tok.text != "THEN" {
+			break
+		}
+		p.next()
 	}
 
-	// Display patterns
-	if strings.HasPrefix(dsl, "Display.") {
-		return parseDisplay(dsl)
+	return seq, nil
+}
+
+// parseDwell parses a dwell bound: ">= <d>", "<= <d>" or "<d>..<d>"
+func (p *parser) parseDwell() (min, max time.Duration, err error) {
+	switch p.peek().kind {
+	case tokGE:
+		p.next()
+		min, err = p.parseDuration()
+		return min, 0, err
+	case tokLE:
+		p.next()
+		max, err = p.parseDuration()
+		return 0, max, err
+	}
+
+	if min, err = p.parseDuration(); err != nil {
+		return 0, 0, err
+	}
+	for i := 0; i < 2; i++ {
+		if dot, err := p.expect(tokDot); err != nil {
+			return 0, 0, errorAt(dot.pos, "expected dwell range like 2s..30s at column %d", dot.pos+1)
+		}
+	}
+	if max, err = p.parseDuration(); err != nil {
+		return 0, 0, err
 	}
+	if max < min {
+		return 0, 0, fmt.Errorf("dwell range %s..%s has maximum below minimum", formatDuration(min), formatDuration(max))
+	}
+	return min, max, nil
+}
 
-	// Timing patterns
-	if strings.HasPrefix(dsl, "BootTime") {
-		return parseTiming(dsl)
+// parseDuration parses a number followed by a unit: ms, s or m
+func (p *parser) parseDuration() (time.Duration, error) {
+	num, err := p.expect(tokNumber)
+	if err != nil {
+		return 0, errorAt(num.pos, "expected duration (e.g. 10s) at column %d", num.pos+1)
+	}
+	value, err := strconv.ParseFloat(num.text, 64)
+	if err != nil {
+		return 0, errorAt(num.pos, "invalid duration %q at column %d", num.text, num.pos+1)
 	}
 
-	return nil, fmt.Errorf("unknown assertion type: %s", dsl)
+	unit := p.next()
+	if unit.kind != tokIdent || unit.pos != num.end {
+		return 0, errorAt(num.pos, "duration at column %d needs a unit (ms, s or m)", num.pos+1)
+	}
+	switch unit.text {
+	case "ms":
+		return time.Duration(value * float64(time.Millisecond)), nil
+	case "s":
+		return time.Duration(value * float64(time.Second)), nil
+	case "m":
+		return time.Duration(value * float64(time.Minute)), nil
+	}
+	return 0, errorAt(unit.pos, "unknown duration unit %q at column %d (expected ms, s or m)", unit.text, unit.pos+1)
+}
+
+func (p *parser) parsePrimary() (Assertion, error) {
+	tok := p.peek()
+
+	switch tok.kind {
+	case tokLParen:
+		p.next()
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if _, err := p.expect(tokRParen); err != nil {
+			return nil, err
+		}
+		return inner, nil
+
+	case tokIdent:
+		if p.tokens[p.pos+1].kind == tokLParen {
+			return p.parseCall()
+		}
+		switch tok.text {
+		case "LED", "Display", "BootTime", "Vision":
+			return p.parseStatement()
+		}
+
+	case tokEOF:
+		return nil, errorAt(tok.pos, "unexpected end of expression, expected an assertion")
+	}
+
+	pe := &ParseError{Column: tok.pos + 1, Message: fmt.Sprintf("unknown assertion type: %s", p.src[tok.pos:])}
+	if tok.kind == tokIdent {
+		pe.Suggestion, _ = closestWord(tok.text, primaryKeywords)
+	}
+	return nil, pe
+}
+
+// parseStatement consumes a legacy statement (LED.x ON, Display.y "text", BootTime < N, Vision "q" EXPECT yes)
+// up to the next top-level operator and hands its raw text to the statement parsers
+func (p *parser) parseStatement() (Assertion, error) {
+	first := p.peek()
+	last := first
+	depth := 0
+
+loop:
+	for {
+		tok := p.peek()
+		switch tok.kind {
+		case tokEOF, tokAnd, tokOr:
+			break loop
+		case tokIdent:
+			// Keywords of enclosing SEQUENCE clauses end the statement
+			if depth == 0 && (tok.text == "THEN" || tok.text == "FOR") {
+				break loop
+			}
+		case tokLParen:
+			depth++
+		case tokRParen:
+			if depth == 0 {
+				break loop
+			}
+			depth--
+		}
+		last = p.next()
+	}
+
+	raw := p.src[first.pos:last.end]
+	assertion, err := parseStatementText(raw)
+	if err != nil {
+		pe := asParseError(err, first.pos)
+		if fixed, offset, ok := suggestStatement(raw); ok {
+			pe.Column = first.pos + offset + 1
+			pe.Suggestion = fixed
+		}
+		return nil, pe
+	}
+	return assertion, nil
+}
+
+// parseStatementText hands a statement's raw text to the parser for its subject
+func parseStatementText(raw string) (Assertion, error) {
+	first, _, _ := strings.Cut(raw, ".")
+	first, _, _ = strings.Cut(first, " ")
+	switch first {
+	case "LED":
+		if ledRelationPattern.MatchString(raw) {
+			return parseLEDRelation(raw)
+		}
+		if ledLevelStatement.MatchString(raw) {
+			return parseLEDLevel(raw)
+		}
+		return parseLED(raw)
+	case "Display":
+		return parseDisplay(raw)
+	case "Vision":
+		return parseVision(raw)
+	default:
+		return parseTiming(raw)
+	}
+}
+
+// parseCall parses a method-call predicate such as led('LED1').color_rgb(0,0,255)
+func (p *parser) parseCall() (Assertion, error) {
+	subject := p.next()
+	subjectArgs, err := p.parseArgs()
+	if err != nil {
+		return nil, err
+	}
+	if len(subjectArgs) != 1 || subjectArgs[0].kind != tokString {
+		return nil, errorAt(subject.pos, "%s() at column %d takes a single quoted name, e.g. %s('LED1')", subject.text, subject.pos+1, subject.text)
+	}
+	name := subjectArgs[0].text
+
+	if _, err := p.expect(tokDot); err != nil {
+		return nil, err
+	}
+	method, err := p.expect(tokIdent)
+	if err != nil {
+		return nil, err
+	}
+	args, err := p.parseArgs()
+	if err != nil {
+		return nil, err
+	}
+
+	switch strings.ToLower(subject.text) {
+	case "led":
+		return ledPredicate(name, method, args)
+	case "display":
+		return displayPredicate(name, method, args)
+	}
+
+	return nil, errorAt(subject.pos, "unknown subject %q at column %d (expected led or display)", subject.text, subject.pos+1)
+}
+
+// parseArgs parses a parenthesised, comma-separated list of literal arguments
+func (p *parser) parseArgs() ([]token, error) {
+	if _, err := p.expect(tokLParen); err != nil {
+		return nil, err
+	}
+
+	var args []token
+	if p.peek().kind == tokRParen {
+		p.next()
+		return args, nil
+	}
+
+	for {
+		arg := p.next()
+		if arg.kind != tokString && arg.kind != tokNumber && arg.kind != tokRegex {
+			return nil, errorAt(arg.pos, "expected literal argument at column %d, got %s %q", arg.pos+1, arg.kind, arg.text)
+		}
+		args = append(args, arg)
+
+		sep := p.next()
+		if sep.kind == tokRParen {
+			return args, nil
+		}
+		if sep.kind != tokComma {
+			return nil, errorAt(sep.pos, "expected ',' or ')' at column %d, got %s %q", sep.pos+1, sep.kind, sep.text)
+		}
+	}
+}
+
+// ledPredicate builds an LEDAssertion from a led('name').method(args) call
+func ledPredicate(name string, method token, args []token) (Assertion, error) {
+	assertion := &LEDAssertion{Name: name}
+
+	switch method.text {
+	case "is_on", "is_off":
+		if err := checkArity(method, args, 0); err != nil {
+			return nil, err
+		}
+		on := method.text == "is_on"
+		assertion.Expected.On = &on
+
+	case "blinks":
+		if len(args) == 0 {
+			blinking := true
+			assertion.Expected.Blinking = &blinking
+			return assertion, nil
+		}
+		if err := checkArity(method, args, 1); err != nil {
+			return nil, err
+		}
+		hz, err := numberArg(method, args[0])
+		if err != nil {
+			return nil, err
+		}
+		assertion.Expected.BlinkHz = &hz
+
+	case "color_rgb":
+		if len(args) == 4 {
+			maxDeltaE, err := numberArg(method, args[3])
+			if err != nil {
+				return nil, err
+			}
+			assertion.Expected.MaxDeltaE = &maxDeltaE
+			args = args[:3]
+		}
+		if err := checkArity(method, args, 3); err != nil {
+			return nil, err
+		}
+		var channels [3]uint8
+		for i, arg := range args {
+			v, err := strconv.ParseUint(arg.text, 10, 8)
+			if err != nil || arg.kind != tokNumber {
+				return nil, errorAt(arg.pos, "color_rgb() argument %d at column %d must be an integer 0-255", i+1, arg.pos+1)
+			}
+			channels[i] = uint8(v)
+		}
+		assertion.Expected.Color = &core.RGB{R: channels[0], G: channels[1], B: channels[2]}
+
+	case "color":
+		if len(args) == 2 {
+			maxDeltaE, err := numberArg(method, args[1])
+			if err != nil {
+				return nil, err
+			}
+			assertion.Expected.MaxDeltaE = &maxDeltaE
+			args = args[:1]
+		}
+		if err := checkArity(method, args, 1); err != nil {
+			return nil, err
+		}
+		name, err := colorName(args[0].text)
+		if err != nil {
+			return nil, errorAt(args[0].pos, "color() argument at column %d: %v", args[0].pos+1, err)
+		}
+		assertion.Expected.ColorName = name
+
+	case "code":
+		if err := checkArity(method, args, 1); err != nil {
+			return nil, err
+		}
+		if !blinkCodePattern.MatchString(args[0].text) {
+			return nil, errorAt(args[0].pos, "code() argument at column %d must be a blink code like '3-2'", args[0].pos+1)
+		}
+		code := args[0].text
+		assertion.Expected.Code = &code
+
+	case "same_rate", "rate_ratio", "in_phase", "alternates", "iff":
+		return ledRelationPredicate(name, method, args)
+
+	case "brightness", "duty", "breathing", "fading":
+		return ledLevelPredicate(name, method, args)
+
+	default:
+		return nil, unknownMethod(method, ledMethods, "unknown led method %q at column %d (expected is_on, is_off, blinks, color, color_rgb, code, brightness, duty, breathing, fading, same_rate, rate_ratio, in_phase, alternates or iff)")
+	}
+
+	return assertion, nil
+}
+
+// ledRelationPredicate builds an LEDRelationAssertion from a call such as
+// led('tx').same_rate('rx') or led('a').iff('on', 'b', 'off')
+func ledRelationPredicate(name string, method token, args []token) (Assertion, error) {
+	want := map[string]int{"same_rate": 1, "rate_ratio": 2, "in_phase": 1, "alternates": 1, "iff": 3}[method.text]
+	if err := checkArity(method, args, want); err != nil {
+		return nil, err
+	}
+	otherArg := args[0]
+	if method.text == "iff" {
+		otherArg = args[1]
+	}
+	if otherArg.kind != tokString {
+		return nil, errorAt(otherArg.pos, "%s() argument at column %d must be a quoted LED name", method.text, otherArg.pos+1)
+	}
+	assertion := &LEDRelationAssertion{Name: name, Other: otherArg.text}
+
+	switch method.text {
+	case "same_rate":
+		assertion.Relation, assertion.Op, assertion.Ratio = RelationRate, CompareEQ, 1
+	case "rate_ratio":
+		ratio, err := numberArg(method, args[1])
+		if err != nil {
+			return nil, err
+		}
+		if ratio <= 0 {
+			return nil, errorAt(args[1].pos, "rate_ratio() ratio at column %d must be positive", args[1].pos+1)
+		}
+		assertion.Relation, assertion.Op, assertion.Ratio = RelationRate, CompareEQ, ratio
+	case "in_phase":
+		assertion.Relation = RelationInPhase
+	case "alternates":
+		assertion.Relation = RelationAlternates
+	case "iff":
+		assertion.Relation = RelationIff
+		for i, state := range []*bool{&assertion.On, &assertion.OtherOn} {
+			arg := args[i*2]
+			switch strings.ToLower(arg.text) {
+			case "on":
+				*state = true
+			case "off":
+				*state = false
+			default:
+				return nil, errorAt(arg.pos, "iff() argument at column %d must be 'on' or 'off'", arg.pos+1)
+			}
+		}
+	}
+	return assertion, nil
+}
+
+// ledLevelPredicate builds a brightness, duty-cycle or fade assertion from a
+// call such as led('backlight').brightness(50, 5) or led('power').fading('in')
+func ledLevelPredicate(name string, method token, args []token) (Assertion, error) {
+	switch method.text {
+	case "breathing":
+		if err := checkArity(method, args, 0); err != nil {
+			return nil, err
+		}
+		return &LEDFadeAssertion{Name: name, Effect: EffectBreathing}, nil
+
+	case "fading":
+		if err := checkArity(method, args, 1); err != nil {
+			return nil, err
+		}
+		switch strings.ToLower(args[0].text) {
+		case "in":
+			return &LEDFadeAssertion{Name: name, Effect: EffectFadeIn}, nil
+		case "out":
+			return &LEDFadeAssertion{Name: name, Effect: EffectFadeOut}, nil
+		}
+		return nil, errorAt(args[0].pos, "fading() argument at column %d must be 'in' or 'out'", args[0].pos+1)
+	}
+
+	assertion := &LEDLevelAssertion{Name: name, Level: LevelBrightness, Op: CompareEQ}
+	if method.text == "duty" {
+		assertion.Level = LevelDuty
+	}
+	if len(args) == 2 {
+		within, err := numberArg(method, args[1])
+		if err != nil {
+			return nil, err
+		}
+		if within < 0 {
+			return nil, errorAt(args[1].pos, "%s() tolerance at column %d must not be negative", method.text, args[1].pos+1)
+		}
+		assertion.Within = &within
+		args = args[:1]
+	}
+	if err := checkArity(method, args, 1); err != nil {
+		return nil, err
+	}
+	percent, err := numberArg(method, args[0])
+	if err != nil {
+		return nil, err
+	}
+	if percent < 0 || percent > 100 {
+		return nil, errorAt(args[0].pos, "%s() percentage at column %d must be between 0 and 100", method.text, args[0].pos+1)
+	}
+	assertion.Percent = percent
+	return assertion, nil
+}
+
+// displayPredicate builds a display assertion from a display('name').method(args) call
+func displayPredicate(name string, method token, args []token) (Assertion, error) {
+	switch method.text {
+	case "shows", "contains": // Both match text contained in the display
+		args, similarity, err := similarityArg(method, args, 1)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkArity(method, args, 1); err != nil {
+			return nil, err
+		}
+		return &DisplayAssertion{Name: name, Expected: args[0].text, MinSimilarity: similarity}, nil
+
+	case "changed":
+		args, similarity, err := similarityArg(method, args, 2)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkArity(method, args, 2); err != nil {
+			return nil, err
+		}
+		return &DisplayChangedAssertion{Name: name, FromText: args[0].text, ToText: args[1].text, MinSimilarity: similarity}, nil
+
+	case "value":
+		if err := checkArity(method, args, 3); err != nil {
+			return nil, err
+		}
+		if args[0].kind != tokString {
+			return nil, errorAt(args[0].pos, "value() argument 1 at column %d must be a quoted value name", args[0].pos+1)
+		}
+		low, err := numberArg(method, args[1])
+		if err != nil {
+			return nil, err
+		}
+		high, err := numberArg(method, args[2])
+		if err != nil {
+			return nil, err
+		}
+		return &DisplayValueAssertion{Name: name, Key: strings.ToLower(args[0].text), Op: CompareBetween, Value: low, Upper: high}, nil
+
+	case "matches":
+		if err := checkArity(method, args, 1); err != nil {
+			return nil, err
+		}
+		re, err := regexp.Compile(args[0].text)
+		if err != nil {
+			return nil, errorAt(args[0].pos, "invalid regex at column %d: %v", args[0].pos+1, err)
+		}
+		return &DisplayMatchAssertion{Name: name, Pattern: re}, nil
+	}
+
+	return nil, unknownMethod(method, displayMethods, "unknown display method %q at column %d (expected shows, contains, changed, value or matches)")
+}
+
+// unknownMethod reports a method name that is not one of methods, suggesting
+// the closest; format takes the name and the column
+func unknownMethod(method token, methods []string, format string) error {
+	pe := &ParseError{Column: method.pos + 1, Message: fmt.Sprintf(format, method.text, method.pos+1)}
+	pe.Suggestion, _ = closestWord(method.text, methods)
+	return pe
+}
+
+func checkArity(method token, args []token, want int) error {
+	if len(args) != want {
+		return errorAt(method.pos, "%s() at column %d takes %d argument(s), got %d", method.text, method.pos+1, want, len(args))
+	}
+	return nil
+}
+
+func numberArg(method token, arg token) (float64, error) {
+	if arg.kind != tokNumber {
+		return 0, errorAt(arg.pos, "%s() argument at column %d must be a number", method.text, arg.pos+1)
+	}
+	v, err := strconv.ParseFloat(arg.text, 64)
+	if err != nil {
+		return 0, errorAt(arg.pos, "invalid number %q at column %d", arg.text, arg.pos+1)
+	}
+	return v, nil
 }
 
 func parseLED(dsl string) (*LEDAssertion, error) {
-	// LED.name [ON|OFF|BLINK freq|COLOR RGB(r,g,b)]
+	// LED.name [ON|OFF|BLINKING|STEADY|BLINK freq|COLOR RGB(r,g,b)|COLOR name|CODE n-n]
 	// Match: LED.{name} {rest}
 	parts := strings.SplitN(dsl, " ", 2)
 	if len(parts) < 1 {
@@ -72,6 +794,13 @@ func parseLED(dsl string) (*LEDAssertion, error) {
 		return assertion, nil
 	}
 
+	// Check for BLINKING (any non-zero rate) / STEADY (not blinking)
+	if strings.EqualFold(state, "BLINKING") || strings.EqualFold(state, "STEADY") {
+		blinking := strings.EqualFold(state, "BLINKING")
+		assertion.Expected.Blinking = &blinking
+		return assertion, nil
+	}
+
 	// Check for BLINK {freq}Hz
 	blinkPattern := regexp.MustCompile(`(?i)^BLINK\s+([\d.]+)\s*Hz$`)
 	if blinkMatch := blinkPattern.FindStringSubmatch(state); blinkMatch != nil {
@@ -83,8 +812,18 @@ func parseLED(dsl string) (*LEDAssertion, error) {
 		return assertion, nil
 	}
 
-	// Check for COLOR RGB(r,g,b)
-	colorPattern := regexp.MustCompile(`(?i)^COLOR\s+RGB\((\d+),\s*(\d+),\s*(\d+)\)$`)
+	// Check for CODE {n-n...}
+	if codeMatch := regexp.MustCompile(`(?i)^CODE\s+(\S+)$`).FindStringSubmatch(state); codeMatch != nil {
+		if !blinkCodePattern.MatchString(codeMatch[1]) {
+			return nil, fmt.Errorf("invalid blink code %q (expected digits separated by '-', e.g. 3-2)", codeMatch[1])
+		}
+		code := codeMatch[1]
+		assertion.Expected.Code = &code
+		return assertion, nil
+	}
+
+	// Check for COLOR RGB(r,g,b) [WITHIN ΔE] (COLOR is optional so String() output parses back)
+	colorPattern := regexp.MustCompile(`(?i)^(?:COLOR\s+)?RGB\((\d+),\s*(\d+),\s*(\d+)\)(?:\s+WITHIN\s+([\d.]+))?$`)
 	if colorMatch := colorPattern.FindStringSubmatch(state); colorMatch != nil {
 		r, err := strconv.ParseUint(colorMatch[1], 10, 8)
 		if err != nil {
@@ -103,50 +842,306 @@ func parseLED(dsl string) (*LEDAssertion, error) {
 			G: uint8(g),
 			B: uint8(b),
 		}
+		if colorMatch[4] != "" {
+			if assertion.Expected.MaxDeltaE, err = parseDeltaE(colorMatch[4]); err != nil {
+				return nil, err
+			}
+		}
+		return assertion, nil
+	}
+
+	// Check for COLOR {name} [WITHIN ΔE]
+	if nameMatch := regexp.MustCompile(`(?i)^COLOR\s+([a-z]+)(?:\s+WITHIN\s+([\d.]+))?$`).FindStringSubmatch(state); nameMatch != nil {
+		name, err := colorName(nameMatch[1])
+		if err != nil {
+			return nil, err
+		}
+		assertion.Expected.ColorName = name
+		if nameMatch[2] != "" {
+			if assertion.Expected.MaxDeltaE, err = parseDeltaE(nameMatch[2]); err != nil {
+				return nil, err
+			}
+		}
 		return assertion, nil
 	}
 
 	return nil, fmt.Errorf("unknown LED state format: %s", state)
 }
 
+// parseLEDRelation parses a statement comparing two LEDs
+func parseLEDRelation(dsl string) (*LEDRelationAssertion, error) {
+	if m := ledRatePattern.FindStringSubmatch(dsl); m != nil {
+		ratio := 1.0
+		if m[3] != "" {
+			var err error
+			if ratio, err = strconv.ParseFloat(m[3], 64); err != nil || ratio <= 0 {
+				return nil, fmt.Errorf("invalid rate ratio %sx (expected a positive number, e.g. 2x)", m[3])
+			}
+		}
+		return &LEDRelationAssertion{Name: m[1], Other: m[4], Relation: RelationRate, Op: Comparison(m[2]), Ratio: ratio}, nil
+	}
+
+	if m := ledIffPattern.FindStringSubmatch(dsl); m != nil {
+		return &LEDRelationAssertion{
+			Name:     m[1],
+			Other:    m[3],
+			Relation: RelationIff,
+			On:       strings.EqualFold(m[2], "ON"),
+			OtherOn:  strings.EqualFold(m[4], "ON"),
+		}, nil
+	}
+
+	if m := ledPhasePattern.FindStringSubmatch(dsl); m != nil {
+		relation := RelationAlternates
+		if !strings.EqualFold(m[2], "ALTERNATES") {
+			relation = RelationInPhase
+		}
+		return &LEDRelationAssertion{Name: m[1], Other: m[3], Relation: relation}, nil
+	}
+
+	return nil, fmt.Errorf("unknown LED relation format: %s (expected RATE ==, IFF, IN PHASE WITH or ALTERNATES WITH)", dsl)
+}
+
+// parseLEDLevel parses a brightness, duty-cycle or fade statement
+func parseLEDLevel(dsl string) (Assertion, error) {
+	if m := ledFadePattern.FindStringSubmatch(dsl); m != nil {
+		effect := Effect(strings.ToUpper(strings.Join(strings.Fields(m[2]), " ")))
+		return &LEDFadeAssertion{Name: m[1], Effect: effect}, nil
+	}
+
+	m := ledLevelPattern.FindStringSubmatch(dsl)
+	if m == nil {
+		return nil, fmt.Errorf("invalid LED level syntax: %s (expected BRIGHTNESS 50%%, DUTY > 20%%, BREATHING or FADING IN|OUT)", dsl)
+	}
+	assertion := &LEDLevelAssertion{Name: m[1], Level: Level(strings.ToUpper(m[2]))}
+	condition := strings.TrimSpace(m[3])
+
+	var err error
+	if between := levelBetween.FindStringSubmatch(condition); between != nil {
+		assertion.Op = CompareBetween
+		if assertion.Percent, err = parsePercent(between[1]); err != nil {
+			return nil, err
+		}
+		if assertion.Upper, err = parsePercent(between[2]); err != nil {
+			return nil, err
+		}
+		if assertion.Upper < assertion.Percent {
+			return nil, fmt.Errorf("empty range: BETWEEN %s%% AND %s%% (lower bound first)", between[1], between[2])
+		}
+		return assertion, nil
+	}
+
+	compare := levelCompare.FindStringSubmatch(condition)
+	if compare == nil {
+		return nil, fmt.Errorf("invalid %s condition %q (expected a percentage like 50%%, optionally after <, <=, >, >= or ==, or BETWEEN 40%% AND 60%%)", assertion.Level, condition)
+	}
+	assertion.Op = CompareEQ
+	if compare[1] != "" {
+		assertion.Op = Comparison(compare[1])
+	}
+	if assertion.Percent, err = parsePercent(compare[2]); err != nil {
+		return nil, err
+	}
+	if compare[3] != "" {
+		if assertion.Op != CompareEQ {
+			return nil, fmt.Errorf("WITHIN only applies to an exact %s, not %s", assertion.Level, assertion.Op)
+		}
+		within, err := strconv.ParseFloat(compare[3], 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid tolerance: %s", compare[3])
+		}
+		assertion.Within = &within
+	}
+	return assertion, nil
+}
+
+// parsePercent parses a percentage between 0 and 100
+func parsePercent(s string) (float64, error) {
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil || v < 0 || v > 100 {
+		return 0, fmt.Errorf("invalid percentage %s%% (expected 0-100)", s)
+	}
+	return v, nil
+}
+
+// similarityArg splits an optional trailing similarity off a text method's
+// arguments, e.g. shows('Ready', 0.8)
+func similarityArg(method token, args []token, texts int) ([]token, *float64, error) {
+	if len(args) != texts+1 {
+		return args, nil, nil
+	}
+	v, err := numberArg(method, args[texts])
+	if err != nil {
+		return nil, nil, err
+	}
+	if v <= 0 || v > 1 {
+		return nil, nil, errorAt(args[texts].pos, "%s() similarity at column %d must be between 0 and 1", method.text, args[texts].pos+1)
+	}
+	return args[:texts], &v, nil
+}
+
+// colorName resolves a color name (or alias such as violet) to its canonical name
+func colorName(name string) (string, error) {
+	named, ok := tolerance.LookupColor(name)
+	if !ok {
+		return "", fmt.Errorf("unknown color %q (expected one of %s)", name, strings.Join(tolerance.ColorNames(), ", "))
+	}
+	return named.Name, nil
+}
+
+func parseDeltaE(s string) (*float64, error) {
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid color distance: %s", s)
+	}
+	return &v, nil
+}
+
+// parseSimilarity parses the operand of SIMILAR, a minimum similarity in (0, 1]
+func parseSimilarity(s string) (*float64, error) {
+	if s == "" {
+		return nil, nil
+	}
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil || v <= 0 || v > 1 {
+		return nil, fmt.Errorf("invalid similarity %s (expected a number between 0 and 1)", s)
+	}
+	return &v, nil
+}
+
 func parseDisplay(dsl string) (Assertion, error) {
-	// Display.name CHANGED "from" -> "to"
-	changedPattern := regexp.MustCompile(`^Display\.([a-zA-Z0-9_-]+)\s+CHANGED\s+"([^"]+)"\s*->\s*"([^"]+)"$`)
+	// Display.name CHANGED "from" -> "to" [SIMILAR s]
+	changedPattern := regexp.MustCompile(`^Display\.([a-zA-Z0-9_-]+)\s+CHANGED\s+"([^"]+)"\s*->\s*"([^"]+)"(?:\s+SIMILAR\s+(\S+))?$`)
 	if matches := changedPattern.FindStringSubmatch(dsl); matches != nil {
+		similarity, err := parseSimilarity(matches[4])
+		if err != nil {
+			return nil, err
+		}
 		return &DisplayChangedAssertion{
-			Name:     matches[1],
-			FromText: matches[2],
-			ToText:   matches[3],
+			Name:          matches[1],
+			FromText:      matches[2],
+			ToText:        matches[3],
+			MinSimilarity: similarity,
 		}, nil
 	}
 
-	// Display.name "text"
-	pattern := regexp.MustCompile(`^Display\.([a-zA-Z0-9_-]+)\s+"([^"]+)"$`)
+	// Display.name [VALUE key] MATCHES /regex/
+	matchesPattern := regexp.MustCompile(`^Display\.([a-zA-Z0-9_-]+)\s+(?:VALUE\s+([A-Za-z_]\w*)\s+)?MATCHES\s+/`)
+	if matches := matchesPattern.FindStringSubmatchIndex(dsl); matches != nil {
+		return parseDisplayMatches(dsl, matches)
+	}
+
+	// Display.name VALUE key BETWEEN low AND high | VALUE key <op> number
+	valuePattern := regexp.MustCompile(`^Display\.([a-zA-Z0-9_-]+)\s+VALUE\s+([A-Za-z_]\w*)\s+(.+)$`)
+	if matches := valuePattern.FindStringSubmatch(dsl); matches != nil {
+		return parseDisplayValue(matches[1], matches[2], matches[3])
+	}
+
+	// Display.name "text" [SIMILAR s]
+	pattern := regexp.MustCompile(`^Display\.([a-zA-Z0-9_-]+)\s+"([^"]+)"(?:\s+SIMILAR\s+(\S+))?$`)
 	matches := pattern.FindStringSubmatch(dsl)
 	if matches == nil {
-		return nil, fmt.Errorf("invalid Display assertion syntax: %s (expected: Display.name \"text\" or Display.name CHANGED \"from\" -> \"to\")", dsl)
+		return nil, fmt.Errorf("invalid Display assertion syntax: %s (expected: Display.name \"text\" [SIMILAR 0.8], Display.name CHANGED \"from\" -> \"to\", Display.name VALUE key BETWEEN low AND high or Display.name MATCHES /regex/)", dsl)
 	}
 
+	similarity, err := parseSimilarity(matches[3])
+	if err != nil {
+		return nil, err
+	}
 	return &DisplayAssertion{
-		Name:     matches[1],
-		Expected: matches[2],
+		Name:          matches[1],
+		Expected:      matches[2],
+		MinSimilarity: similarity,
 	}, nil
 }
 
+// parseDisplayMatches builds a regex assertion; loc holds the submatch
+// indices of the statement prefix, which ends at the opening slash
+func parseDisplayMatches(dsl string, loc []int) (Assertion, error) {
+	name := dsl[loc[2]:loc[3]]
+	key := ""
+	if loc[4] >= 0 {
+		key = strings.ToLower(dsl[loc[4]:loc[5]])
+	}
+
+	source, end, err := lexRegex(dsl, loc[1]-1)
+	if err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(dsl[end:]) != "" {
+		return nil, fmt.Errorf("unexpected %q after regex in: %s", strings.TrimSpace(dsl[end:]), dsl)
+	}
+	re, err := regexp.Compile(source)
+	if err != nil {
+		return nil, fmt.Errorf("invalid regex /%s/: %w", source, err)
+	}
+	return &DisplayMatchAssertion{Name: name, Key: key, Pattern: re}, nil
+}
+
+// parseDisplayValue parses the comparison of a VALUE statement
+func parseDisplayValue(name, key, condition string) (Assertion, error) {
+	assertion := &DisplayValueAssertion{Name: name, Key: strings.ToLower(key)}
+
+	between := regexp.MustCompile(`(?i)^BETWEEN\s+(\S+)\s+AND\s+(\S+)$`).FindStringSubmatch(condition)
+	compare := regexp.MustCompile(`^(<=|>=|==|<|>)\s*(\S+)$`).FindStringSubmatch(condition)
+	var err error
+	switch {
+	case between != nil:
+		assertion.Op = CompareBetween
+		if assertion.Value, err = strconv.ParseFloat(between[1], 64); err != nil {
+			return nil, fmt.Errorf("invalid lower bound: %s", between[1])
+		}
+		if assertion.Upper, err = strconv.ParseFloat(between[2], 64); err != nil {
+			return nil, fmt.Errorf("invalid upper bound: %s", between[2])
+		}
+		if assertion.Upper < assertion.Value {
+			return nil, fmt.Errorf("empty range: BETWEEN %s AND %s (lower bound first)", between[1], between[2])
+		}
+	case compare != nil:
+		assertion.Op = Comparison(compare[1])
+		if assertion.Value, err = strconv.ParseFloat(compare[2], 64); err != nil {
+			return nil, fmt.Errorf("invalid number: %s", compare[2])
+		}
+	default:
+		return nil, fmt.Errorf("invalid VALUE condition %q (expected BETWEEN low AND high, or <, <=, >, >=, == followed by a number)", condition)
+	}
+	return assertion, nil
+}
+
 func parseTiming(dsl string) (*TimingAssertion, error) {
-	// BootTime < {ms}ms
-	pattern := regexp.MustCompile(`^BootTime\s+<\s+(\d+)\s*ms$`)
+	// BootTime < {ms}ms or BootTime.{milestone} < {ms}ms
+	pattern := regexp.MustCompile(`^BootTime(?:\.([\w.-]+))?\s+<\s+(\d+)\s*ms$`)
 	matches := pattern.FindStringSubmatch(dsl)
 	if matches == nil {
-		return nil, fmt.Errorf("invalid BootTime assertion syntax: %s (expected: BootTime < 3000ms)", dsl)
+		return nil, fmt.Errorf("invalid BootTime assertion syntax: %s (expected: BootTime < 3000ms or BootTime.first_text < 1500ms)", dsl)
 	}
 
-	duration, err := strconv.ParseInt(matches[1], 10, 64)
+	duration, err := strconv.ParseInt(matches[2], 10, 64)
 	if err != nil {
-		return nil, fmt.Errorf("invalid duration value: %s", matches[1])
+		return nil, fmt.Errorf("invalid duration value: %s", matches[2])
 	}
 
 	return &TimingAssertion{
+		Milestone:     matches[1],
 		MaxDurationMs: duration,
 	}, nil
 }
+
+// visionPattern matches Vision "question" EXPECT yes|no|"text"
+var visionPattern = regexp.MustCompile(`^Vision\s+"([^"]+)"\s+(?i:EXPECT)\s+(?:((?i:yes|no))|"([^"]+)")$`)
+
+func parseVision(dsl string) (*VisionAssertion, error) {
+	matches := visionPattern.FindStringSubmatch(dsl)
+	if matches == nil {
+		return nil, fmt.Errorf("invalid Vision assertion syntax: %s (expected: Vision \"question\" EXPECT yes, EXPECT no or EXPECT \"text\")", dsl)
+	}
+
+	question := strings.TrimSpace(matches[1])
+	if question == "" {
+		return nil, fmt.Errorf("empty Vision question: %s", dsl)
+	}
+	if matches[3] != "" {
+		return &VisionAssertion{Question: question, Expected: matches[3], Text: true}, nil
+	}
+	return &VisionAssertion{Question: question, Expected: strings.ToLower(matches[2])}, nil
+}
@@ -0,0 +1,138 @@
+package assertions
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/perceptumx/percepta/internal/core"
+)
+
+// Quorum decides an assertion by vote over repeated attempts, each on its own
+// observation, so that one bad capture does not decide a check. The assertion
+// passes once Required attempts passed, out of at most Attempts. The zero value
+// makes a single attempt.
+type Quorum struct {
+	Required int // Passing attempts needed (K)
+	Attempts int // Attempts allowed (N)
+}
+
+var quorumPattern = regexp.MustCompile(`^\s*(\d+)\s*(?:-?of-?|/)\s*(\d+)\s*$`)
+
+// ParseQuorum parses "K-of-N" (also "K of N" or "K/N")
+func ParseQuorum(s string) (Quorum, error) {
+	m := quorumPattern.FindStringSubmatch(s)
+	if m == nil {
+		return Quorum{}, fmt.Errorf("invalid quorum %q (expected K-of-N, e.g. 2-of-3)", s)
+	}
+	k, _ := strconv.Atoi(m[1])
+	n, _ := strconv.Atoi(m[2])
+	q := Quorum{Required: k, Attempts: n}
+	return q, q.Validate()
+}
+
+// RetryQuorum passes on the first of 1+retries attempts that passes
+func RetryQuorum(retries int) Quorum {
+	return Quorum{Required: 1, Attempts: retries + 1}
+}
+
+// Validate rejects quorums that can never pass
+func (q Quorum) Validate() error {
+	if q.Required < 1 || q.Attempts < 1 {
+		return fmt.Errorf("quorum %s needs at least one attempt and one required pass", q)
+	}
+	if q.Required > q.Attempts {
+		return fmt.Errorf("quorum %s requires more passes than attempts", q)
+	}
+	return nil
+}
+
+// Voting reports whether the quorum makes more than one attempt
+func (q Quorum) Voting() bool {
+	return q.Attempts > 1
+}
+
+func (q Quorum) String() string {
+	return fmt.Sprintf("%d-of-%d", q.Required, q.Attempts)
+}
+
+// decide tallies the attempts so far. done is true once more attempts cannot
+// change the outcome: the quorum was reached, or can no longer be.
+func (q Quorum) decide(attempts []AssertionResult) (outcome Outcome, done bool) {
+	passed, failed := 0, 0
+	for _, r := range attempts {
+		switch r.Outcome() {
+		case OutcomePass:
+			passed++
+		case OutcomeFail:
+			failed++
+		}
+	}
+
+	switch {
+	case passed >= q.Required:
+		return OutcomePass, true
+	case failed > q.Attempts-q.Required:
+		// Even passing every remaining attempt cannot reach the quorum
+		return OutcomeFail, true
+	case passed+q.Attempts-len(attempts) < q.Required:
+		// Inconclusive attempts used up the chances to pass
+		return OutcomeInconclusive, true
+	}
+	return OutcomeInconclusive, len(attempts) >= q.Attempts
+}
+
+// RunQuorum runs the assertion like RunConclusive until the quorum is decided.
+// The first attempt uses snapshot when given; later ones capture fresh
+// observations. The result is that of the last attempt agreeing with the vote,
+// with every attempt listed in Attempts.
+func RunQuorum(a Assertion, snapshot *core.Observation, observe Observer, reobserve int, q Quorum) (AssertionResult, error) {
+	if !q.Voting() {
+		result, _, err := RunConclusive(a, snapshot, observe, reobserve)
+		return result, err
+	}
+
+	start := time.Now()
+	var attempts []AssertionResult
+	for {
+		result, _, err := RunConclusive(a, snapshot, observe, reobserve)
+		if err != nil {
+			return AssertionResult{}, fmt.Errorf("attempt %d: %w", len(attempts)+1, err)
+		}
+		snapshot = nil
+		attempts = append(attempts, result)
+
+		outcome, done := q.decide(attempts)
+		if !done {
+			continue
+		}
+
+		var decisive AssertionResult
+		for _, r := range attempts {
+			if r.Outcome() == outcome {
+				decisive = r
+			}
+		}
+		if decisive.Message != "" {
+			decisive.Message = fmt.Sprintf("%s (%s)", q.tally(attempts), decisive.Message)
+		} else {
+			decisive.Message = q.tally(attempts)
+		}
+		decisive.Attempts = attempts
+		decisive.Quorum = q
+		decisive.Duration = time.Since(start)
+		return decisive, nil
+	}
+}
+
+// tally summarises the vote, e.g. "2 of 3 attempts passed, 2-of-3 required"
+func (q Quorum) tally(attempts []AssertionResult) string {
+	passed := 0
+	for _, r := range attempts {
+		if r.Passed {
+			passed++
+		}
+	}
+	return fmt.Sprintf("%d of %d attempts passed, %s required", passed, len(attempts), q)
+}
@@ -0,0 +1,132 @@
+package assertions
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/perceptumx/percepta/internal/core"
+)
+
+// votingObserver returns observations in which the wifi LED is on (true) or
+// off (false), or has low confidence (nil), one per call
+func votingObserver(votes ...*bool) (Observer, *int) {
+	calls := 0
+	return func() (*core.Observation, error) {
+		if calls >= len(votes) {
+			return nil, fmt.Errorf("unexpected observation %d", calls+1)
+		}
+		wifi := core.LEDSignal{Name: "wifi", On: true, Confidence: 0.9}
+		if votes[calls] == nil {
+			wifi.Confidence = 0.3
+		} else if !*votes[calls] {
+			wifi.On = false
+		}
+		calls++
+		return observation(fmt.Sprintf("obs-%d", calls), wifi), nil
+	}, &calls
+}
+
+func TestParseQuorum(t *testing.T) {
+	for input, want := range map[string]Quorum{"2-of-3": {2, 3}, "1 of 1": {1, 1}, "3/5": {3, 5}, " 2of3 ": {2, 3}} {
+		if got, err := ParseQuorum(input); err != nil || got != want {
+			t.Errorf("ParseQuorum(%q) = %+v, %v; want %+v", input, got, err, want)
+		}
+	}
+	for _, input := range []string{"", "2", "two-of-three", "4-of-3", "0-of-3", "1-of-0"} {
+		if _, err := ParseQuorum(input); err == nil {
+			t.Errorf("Expected ParseQuorum(%q) to fail", input)
+		}
+	}
+	if q := RetryQuorum(2); q != (Quorum{Required: 1, Attempts: 3}) || q.String() != "1-of-3" {
+		t.Errorf("Expected --retries 2 to be 1-of-3, got %s", q)
+	}
+}
+
+func TestRunQuorum(t *testing.T) {
+	on, off := boolPtr(true), boolPtr(false)
+	tests := []struct {
+		name     string
+		quorum   Quorum
+		votes    []*bool
+		want     Outcome
+		attempts int
+	}{
+		{"retry passes on second attempt", RetryQuorum(2), []*bool{off, on}, OutcomePass, 2},
+		{"retries exhausted", RetryQuorum(2), []*bool{off, off, off}, OutcomeFail, 3},
+		{"majority passes", Quorum{2, 3}, []*bool{on, off, on}, OutcomePass, 3},
+		{"decided after two passes", Quorum{2, 3}, []*bool{on, on}, OutcomePass, 2},
+		{"decided after two failures", Quorum{2, 3}, []*bool{off, off}, OutcomeFail, 2},
+		{"inconclusive vote cannot pass", Quorum{2, 3}, []*bool{on, nil, off}, OutcomeInconclusive, 3},
+		{"unanimous", Quorum{3, 3}, []*bool{on, on, off}, OutcomeFail, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			observe, calls := votingObserver(tt.votes...)
+			assertion := WithMinConfidence(&LEDAssertion{Name: "wifi", Expected: LEDState{On: boolPtr(true)}}, 0.8)
+			result, err := RunQuorum(assertion, nil, observe, 0, tt.quorum)
+			if err != nil {
+				t.Fatalf("RunQuorum failed: %v", err)
+			}
+			if result.Outcome() != tt.want {
+				t.Errorf("Expected %s, got %s: %s", tt.want, result.Outcome(), result.Message)
+			}
+			if len(result.Attempts) != tt.attempts || *calls != tt.attempts {
+				t.Errorf("Expected %d attempts, got %d (%d observations)", tt.attempts, len(result.Attempts), *calls)
+			}
+			if result.Quorum != tt.quorum {
+				t.Errorf("Expected quorum %s on the result, got %s", tt.quorum, result.Quorum)
+			}
+		})
+	}
+}
+
+func TestRunQuorum_Result(t *testing.T) {
+	on, off := boolPtr(true), boolPtr(false)
+	observe, _ := votingObserver(on, off, off)
+	assertion := &LEDAssertion{Name: "wifi", Expected: LEDState{On: boolPtr(true)}}
+
+	result, err := RunQuorum(assertion, nil, observe, 0, Quorum{2, 3})
+	if err != nil {
+		t.Fatalf("RunQuorum failed: %v", err)
+	}
+	// The result is the last attempt that voted like the quorum
+	if result.ObservationID != "obs-3" || result.Passed {
+		t.Errorf("Expected the failing obs-3 to decide, got %s from %q", result.Outcome(), result.ObservationID)
+	}
+	if !strings.HasPrefix(result.Message, "1 of 3 attempts passed, 2-of-3 required (") {
+		t.Errorf("Expected the tally in the message, got %q", result.Message)
+	}
+	ids := []string{}
+	for _, a := range result.Attempts {
+		ids = append(ids, a.ObservationID)
+	}
+	if strings.Join(ids, ",") != "obs-1,obs-2,obs-3" {
+		t.Errorf("Expected every attempt on its own observation, got %v", ids)
+	}
+}
+
+func TestRunQuorum_SingleAttempt(t *testing.T) {
+	observe, calls := votingObserver(boolPtr(false))
+	result, err := RunQuorum(&LEDAssertion{Name: "wifi", Expected: LEDState{On: boolPtr(true)}}, nil, observe, 0, Quorum{})
+	if err != nil || result.Passed || *calls != 1 || result.Attempts != nil {
+		t.Errorf("Expected the zero quorum to run once without votes, got %+v (%v)", result, err)
+	}
+}
+
+func TestRunQuorum_ObservationError(t *testing.T) {
+	calls := 0
+	observe := func() (*core.Observation, error) {
+		calls++
+		if calls == 2 {
+			return nil, errors.New("camera unplugged")
+		}
+		return &core.Observation{ID: "obs", Signals: []core.Signal{core.LEDSignal{Name: "wifi", Confidence: 0.9}}}, nil
+	}
+	_, err := RunQuorum(&LEDAssertion{Name: "wifi", Expected: LEDState{On: boolPtr(true)}}, nil, observe, 0, RetryQuorum(2))
+	if err == nil || !strings.Contains(err.Error(), "attempt 2: camera unplugged") {
+		t.Errorf("Expected the failing attempt to be named, got %v", err)
+	}
+}
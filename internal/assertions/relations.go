@@ -0,0 +1,252 @@
+package assertions
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/perceptumx/percepta/internal/core"
+	"github.com/perceptumx/percepta/internal/timeline"
+	"github.com/perceptumx/percepta/internal/tolerance"
+)
+
+// Relation is how an LEDRelationAssertion compares two LEDs
+type Relation string
+
+const (
+	RelationRate       Relation = "RATE"       // Blink rates compare, optionally scaled: LED.a RATE == 2x LED.b
+	RelationIff        Relation = "IFF"        // One state holds exactly when the other does: LED.a ON IFF LED.b OFF
+	RelationInPhase    Relation = "IN PHASE"   // Both blink, lit in the same frames
+	RelationAlternates Relation = "ALTERNATES" // Both blink, never lit in the same frame
+)
+
+// phaseSlack is the share of frames allowed to disagree in frame-by-frame
+// relations: a frame exposed mid-transition can catch one LED before the other
+const phaseSlack = 0.1
+
+// LEDRelationAssertion compares two LEDs of the same observation, e.g.
+// LED.tx RATE == LED.rx or LED.a ALTERNATES WITH LED.b.
+// Frame-by-frame relations use the LEDs' high-rate timelines when both have
+// one, else the per-frame states of the regular multi-frame capture.
+type LEDRelationAssertion struct {
+	Name     string
+	Other    string
+	Relation Relation
+
+	// RATE: Name's rate compared (Op) with Ratio × Other's rate
+	Op    Comparison
+	Ratio float64
+
+	// IFF: Name is On exactly when Other is OtherOn
+	On      bool
+	OtherOn bool
+
+	profile *tolerance.Profile
+}
+
+func (a *LEDRelationAssertion) Evaluate(obs *core.Observation) AssertionResult {
+	led := findLED(obs, a.Name)
+	other := findLED(obs, a.Other)
+	// With a single LED in view, both names would fall back to it
+	if led != nil && other != nil && !strings.EqualFold(a.Name, a.Other) && led.Name == other.Name {
+		if strings.EqualFold(led.Name, a.Name) {
+			other = nil
+		} else {
+			led = nil
+		}
+	}
+	for _, missing := range []struct {
+		name string
+		led  *core.LEDSignal
+	}{{a.Name, led}, {a.Other, other}} {
+		if missing.led == nil {
+			return AssertionResult{
+				Passed:        false,
+				Expected:      a.String(),
+				Actual:        "LED not found in observation",
+				Confidence:    0.0,
+				Message:       fmt.Sprintf("LED '%s' not found in observation", missing.name),
+				SignalMissing: true,
+			}
+		}
+	}
+
+	result := AssertionResult{
+		Expected:   a.String(),
+		Confidence: math.Min(led.Confidence, other.Confidence),
+	}
+	switch a.Relation {
+	case RelationRate:
+		a.compareRates(led, other, profileOrDefault(a.profile), &result)
+	case RelationIff:
+		a.compareStates(led, other, &result)
+	default:
+		a.comparePhase(led, other, &result)
+	}
+	return result
+}
+
+// compareRates checks the LEDs' blink rates against each other
+func (a *LEDRelationAssertion) compareRates(led, other *core.LEDSignal, profile tolerance.Profile, result *AssertionResult) {
+	for _, l := range []*core.LEDSignal{led, other} {
+		if l.BlinkHz == 0 {
+			result.Actual = fmt.Sprintf("LED '%s' is not blinking", l.Name)
+			result.Message = fmt.Sprintf("Comparing blink rates needs both LEDs blinking, but '%s' is %s", l.Name, onOffString(l.On))
+			return
+		}
+	}
+
+	target := a.Ratio * other.BlinkHz
+	result.Actual = fmt.Sprintf("LED '%s' blinks at %.2f Hz, LED '%s' at %.2f Hz (%.2fx)", led.Name, led.BlinkHz, other.Name, other.BlinkHz, led.BlinkHz/other.BlinkHz)
+
+	var holds bool
+	switch a.Op {
+	case CompareLT:
+		holds = led.BlinkHz < target
+	case CompareLE:
+		holds = led.BlinkHz <= target
+	case CompareGT:
+		holds = led.BlinkHz > target
+	case CompareGE:
+		holds = led.BlinkHz >= target
+	default:
+		holds = profile.BlinkMatches(target, led.BlinkHz)
+	}
+
+	if !holds {
+		result.Message = fmt.Sprintf("Expected '%s' to blink at %s %.2f Hz, got %.2f Hz", led.Name, a.Op, target, led.BlinkHz)
+		if a.Op == CompareEQ {
+			result.Message += fmt.Sprintf(" (outside ±%g%% tolerance)", profile.BlinkPercent)
+		}
+		return
+	}
+	result.Passed = true
+	result.Message = fmt.Sprintf("LED '%s' blinks %.2fx as fast as LED '%s' (expected %s %s)", led.Name, led.BlinkHz/other.BlinkHz, other.Name, a.Op, ratioString(a.Ratio))
+}
+
+// compareStates checks that Name is On exactly when Other is OtherOn, frame by
+// frame when per-frame states exist, else on the snapshot states
+func (a *LEDRelationAssertion) compareStates(led, other *core.LEDSignal, result *AssertionResult) {
+	pairs := pairFrames(led, other)
+	if len(pairs) == 0 {
+		for _, l := range []*core.LEDSignal{led, other} {
+			if l.BlinkHz > 0 {
+				result.Inconclusive = true
+				result.Actual = fmt.Sprintf("LED '%s' is blinking and has no per-frame states", l.Name)
+				result.Message = "Comparing a blinking LED's state needs per-frame data; the observation has none"
+				return
+			}
+		}
+		pairs = [][2]bool{{led.On, other.On}}
+	}
+
+	mismatches := 0
+	for _, p := range pairs {
+		if (p[0] == a.On) != (p[1] == a.OtherOn) {
+			mismatches++
+		}
+	}
+	a.judgeFrames(led, other, len(pairs), mismatches, result)
+}
+
+// comparePhase checks that two blinking LEDs are lit in the same frames
+// (IN PHASE) or in opposite frames (ALTERNATES)
+func (a *LEDRelationAssertion) comparePhase(led, other *core.LEDSignal, result *AssertionResult) {
+	for _, l := range []*core.LEDSignal{led, other} {
+		if l.BlinkHz == 0 && timeline.Transitions(frameStates(l)) == 0 {
+			result.Actual = fmt.Sprintf("LED '%s' is steady %s", l.Name, onOffString(l.On))
+			result.Message = fmt.Sprintf("Comparing phase needs both LEDs blinking, but '%s' is not", l.Name)
+			return
+		}
+	}
+
+	pairs := pairFrames(led, other)
+	if len(pairs) == 0 {
+		result.Inconclusive = true
+		result.Actual = fmt.Sprintf("LEDs '%s' and '%s' have no per-frame states in common", led.Name, other.Name)
+		result.Message = "Comparing phase needs both LEDs' per-frame states from the same capture"
+		return
+	}
+
+	mismatches := 0
+	for _, p := range pairs {
+		if (p[0] == p[1]) != (a.Relation == RelationInPhase) {
+			mismatches++
+		}
+	}
+	a.judgeFrames(led, other, len(pairs), mismatches, result)
+}
+
+// judgeFrames passes a frame-by-frame relation if few enough frames disagree
+func (a *LEDRelationAssertion) judgeFrames(led, other *core.LEDSignal, frames, mismatches int, result *AssertionResult) {
+	result.Actual = fmt.Sprintf("LEDs '%s' and '%s' agree in %d of %d frames", led.Name, other.Name, frames-mismatches, frames)
+	allowed := int(math.Floor(float64(frames)*phaseSlack + 1e-9))
+	if mismatches > allowed {
+		result.Message = fmt.Sprintf("Expected '%s' %s, but %d of %d frames disagree (%d allowed)", led.Name, a.describe(other.Name), mismatches, frames, allowed)
+		return
+	}
+	result.Passed = true
+	result.Message = fmt.Sprintf("LED '%s' %s", led.Name, a.describe(other.Name))
+}
+
+// describe states a frame-by-frame relation in words, e.g. "alternates with 'b'"
+func (a *LEDRelationAssertion) describe(other string) string {
+	switch a.Relation {
+	case RelationIff:
+		return fmt.Sprintf("is %s exactly when '%s' is %s", onOffString(a.On), other, onOffString(a.OtherOn))
+	case RelationInPhase:
+		return fmt.Sprintf("is in phase with '%s'", other)
+	}
+	return fmt.Sprintf("alternates with '%s'", other)
+}
+
+func (a *LEDRelationAssertion) String() string {
+	switch a.Relation {
+	case RelationRate:
+		ratio := ""
+		if a.Ratio != 1 {
+			ratio = ratioString(a.Ratio) + " "
+		}
+		return fmt.Sprintf("LED.%s RATE %s %sLED.%s", a.Name, a.Op, ratio, a.Other)
+	case RelationIff:
+		return fmt.Sprintf("LED.%s %s IFF LED.%s %s", a.Name, onOffString(a.On), a.Other, onOffString(a.OtherOn))
+	case RelationInPhase:
+		return fmt.Sprintf("LED.%s IN PHASE WITH LED.%s", a.Name, a.Other)
+	}
+	return fmt.Sprintf("LED.%s ALTERNATES WITH LED.%s", a.Name, a.Other)
+}
+
+func ratioString(ratio float64) string {
+	return strconv.FormatFloat(ratio, 'f', -1, 64) + "x"
+}
+
+// frameStates returns an LED's per-frame states, preferring a high-rate timeline
+func frameStates(led *core.LEDSignal) []core.LEDSample {
+	if len(led.Timeline) > 0 {
+		return led.Timeline
+	}
+	return led.Frames
+}
+
+// pairFrames pairs two LEDs' states in the frames both were seen in. Both
+// must come from the same capture: high-rate timelines if both have one,
+// else multi-frame states.
+func pairFrames(led, other *core.LEDSignal) [][2]bool {
+	a, b := led.Frames, other.Frames
+	if len(led.Timeline) > 0 && len(other.Timeline) > 0 {
+		a, b = led.Timeline, other.Timeline
+	}
+
+	states := make(map[int64]bool, len(b))
+	for _, s := range b {
+		states[s.OffsetMs] = s.On
+	}
+	var pairs [][2]bool
+	for _, s := range a {
+		if on, ok := states[s.OffsetMs]; ok {
+			pairs = append(pairs, [2]bool{s.On, on})
+		}
+	}
+	return pairs
+}
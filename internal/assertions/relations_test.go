@@ -0,0 +1,190 @@
+package assertions
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/perceptumx/percepta/internal/core"
+	"github.com/perceptumx/percepta/internal/tolerance"
+)
+
+// frames builds per-frame states 200ms apart from a pattern such as "1010"
+func frames(pattern string) []core.LEDSample {
+	samples := make([]core.LEDSample, len(pattern))
+	for i, c := range pattern {
+		samples[i] = core.LEDSample{OffsetMs: int64(i) * 200, On: c == '1'}
+	}
+	return samples
+}
+
+func TestLEDRelationAssertion(t *testing.T) {
+	obs := observation("relations",
+		core.LEDSignal{Name: "tx", On: true, BlinkHz: 2, Confidence: 0.9, Frames: frames("1010101010")},
+		core.LEDSignal{Name: "rx", On: true, BlinkHz: 2.1, Confidence: 0.8, Frames: frames("0101010101")},
+		core.LEDSignal{Name: "heartbeat", On: true, BlinkHz: 4, Confidence: 0.9},
+		core.LEDSignal{Name: "power", On: true, Confidence: 0.95, Frames: frames("1111111111")},
+		core.LEDSignal{Name: "standby", On: false, Confidence: 0.95, Frames: frames("0000000000")},
+		core.LEDSignal{Name: "link", On: true, BlinkHz: 2, Confidence: 0.7, Frames: frames("1010101011")},
+	)
+
+	tests := []struct {
+		dsl  string
+		want bool
+	}{
+		{"LED.tx RATE == LED.rx", true},
+		{"LED.heartbeat RATE == 2x LED.tx", true},
+		{"LED.heartbeat RATE == 3x LED.tx", false},
+		{"LED.tx RATE == 0.5x LED.heartbeat", true},
+		{"LED.heartbeat RATE > LED.tx", true},
+		{"LED.tx RATE >= LED.heartbeat", false},
+		{"LED.power RATE == LED.tx", false}, // Not blinking
+		{"LED.power ON IFF LED.standby OFF", true},
+		{"LED.power ON IFF LED.standby ON", false},
+		{"LED.tx ON IFF LED.rx OFF", true},
+		{"LED.tx ALTERNATES WITH LED.rx", true},
+		{"LED.tx IN PHASE WITH LED.rx", false},
+		{"LED.tx IN PHASE WITH LED.link", true}, // 1 of 10 frames disagrees
+		{"LED.tx ALTERNATES WITH LED.power", false},
+		{"led('tx').same_rate('rx')", true},
+		{"led('heartbeat').rate_ratio('tx', 2)", true},
+		{"led('tx').alternates('rx')", true},
+		{"led('tx').in_phase('rx')", false},
+		{"led('power').iff('on', 'standby', 'off')", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.dsl, func(t *testing.T) {
+			assertion, err := Parse(tt.dsl)
+			if err != nil {
+				t.Fatalf("Parse failed: %v", err)
+			}
+			result := assertion.Evaluate(obs)
+			if result.Passed != tt.want {
+				t.Errorf("Expected passed=%v, got %v: %s (%s)", tt.want, result.Passed, result.Message, result.Actual)
+			}
+		})
+	}
+}
+
+func TestLEDRelationAssertion_Messages(t *testing.T) {
+	obs := observation("relations",
+		core.LEDSignal{Name: "tx", On: true, BlinkHz: 2, Confidence: 0.9, Frames: frames("1010101010")},
+		core.LEDSignal{Name: "rx", On: true, BlinkHz: 2.1, Confidence: 0.8, Frames: frames("0101010101")},
+		core.LEDSignal{Name: "heartbeat", On: true, BlinkHz: 4, Confidence: 0.9},
+	)
+	assertion, _ := Parse("LED.tx IN PHASE WITH LED.rx")
+	result := assertion.Evaluate(obs)
+	if result.Actual != "LEDs 'tx' and 'rx' agree in 0 of 10 frames" || result.Confidence != 0.8 {
+		t.Errorf("Unexpected result: %q [%.2f]", result.Actual, result.Confidence)
+	}
+	if !strings.Contains(result.Message, "10 of 10 frames disagree (1 allowed)") {
+		t.Errorf("Unexpected message: %q", result.Message)
+	}
+
+	assertion, _ = Parse("LED.heartbeat RATE == 3x LED.tx")
+	result = assertion.Evaluate(obs)
+	if result.Actual != "LED 'heartbeat' blinks at 4.00 Hz, LED 'tx' at 2.00 Hz (2.00x)" {
+		t.Errorf("Unexpected actual: %q", result.Actual)
+	}
+
+	assertion, _ = Parse("LED.tx RATE == LED.wifi")
+	if result := assertion.Evaluate(obs); !result.SignalMissing || !strings.Contains(result.Message, "'wifi'") {
+		t.Errorf("Expected missing LED to be flagged, got %+v", result)
+	}
+}
+
+func TestLEDRelationAssertion_WithoutFrames(t *testing.T) {
+	obs := &core.Observation{
+		Signals: []core.Signal{
+			core.LEDSignal{Name: "a", On: true, BlinkHz: 1, Confidence: 0.9},
+			core.LEDSignal{Name: "b", On: true, BlinkHz: 1, Confidence: 0.9},
+			core.LEDSignal{Name: "c", On: false, Confidence: 0.9},
+		},
+	}
+
+	for _, dsl := range []string{"LED.a ALTERNATES WITH LED.b", "LED.a ON IFF LED.c OFF"} {
+		assertion, _ := Parse(dsl)
+		if result := assertion.Evaluate(obs); !result.Inconclusive {
+			t.Errorf("%s: expected INCONCLUSIVE without per-frame states, got %+v", dsl, result)
+		}
+	}
+
+	// Steady LEDs compare on their snapshot state
+	assertion, _ := Parse("LED.c OFF IFF LED.a ON")
+	obs.Signals[0] = core.LEDSignal{Name: "a", On: true, Confidence: 0.9}
+	if result := assertion.Evaluate(obs); !result.Passed {
+		t.Errorf("Expected snapshot states to satisfy IFF: %s", result.Message)
+	}
+}
+
+func TestLEDRelationAssertion_PrefersTimelines(t *testing.T) {
+	// The multi-frame states disagree, the high-rate timelines alternate
+	obs := &core.Observation{
+		Signals: []core.Signal{
+			core.LEDSignal{Name: "a", On: true, BlinkHz: 1, Confidence: 0.9, Frames: frames("11111"), Timeline: frames("1100110011")},
+			core.LEDSignal{Name: "b", On: true, BlinkHz: 1, Confidence: 0.9, Frames: frames("11111"), Timeline: frames("0011001100")},
+		},
+	}
+	assertion, _ := Parse("LED.a ALTERNATES WITH LED.b")
+	if result := assertion.Evaluate(obs); !result.Passed {
+		t.Errorf("Expected timelines to be compared: %s", result.Actual)
+	}
+}
+
+func TestLEDRelationAssertion_SingleLEDFallback(t *testing.T) {
+	obs := &core.Observation{Signals: []core.Signal{core.LEDSignal{Name: "tx", On: true, BlinkHz: 2, Confidence: 0.9}}}
+	assertion, _ := Parse("LED.tx RATE == LED.rx")
+	if result := assertion.Evaluate(obs); !result.SignalMissing || !strings.Contains(result.Message, "'rx'") {
+		t.Errorf("Expected rx to be missing rather than fall back to tx, got %+v", result)
+	}
+}
+
+func TestLEDRelationAssertion_WithTolerance(t *testing.T) {
+	obs := observation("relations", core.LEDSignal{Name: "tx", On: true, BlinkHz: 2, Confidence: 0.9, Frames: frames("1010101010")}, core.LEDSignal{Name: "rx", On: true, BlinkHz: 2.1, Confidence: 0.8, Frames: frames("0101010101")})
+	assertion, _ := Parse("LED.tx RATE == LED.rx") // 2 Hz vs 2.1 Hz
+	strict := tolerance.Default()
+	strict.BlinkPercent = 1
+	if result := WithTolerance(assertion, strict).Evaluate(obs); result.Passed {
+		t.Error("Expected a 1% blink tolerance to reject 2 Hz vs 2.1 Hz")
+	}
+}
+
+func TestParse_LEDRelation(t *testing.T) {
+	for _, dsl := range []string{
+		"LED.tx RATE == LED.rx",
+		"LED.heartbeat RATE == 2x LED.status",
+		"LED.a RATE < 0.5x LED.b",
+		"LED.a ON IFF LED.b OFF",
+		"LED.a IN PHASE WITH LED.b",
+		"LED.a ALTERNATES WITH LED.b",
+		"LED.a ALTERNATES WITH LED.b && LED.c ON",
+	} {
+		assertion, err := Parse(dsl)
+		if err != nil {
+			t.Fatalf("Parse(%q) failed: %v", dsl, err)
+		}
+		if assertion.String() != dsl {
+			t.Errorf("Expected %q to round-trip, got %q", dsl, assertion.String())
+		}
+	}
+
+	assertion, err := Parse("LED.hb rate == 2 x LED.status")
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if rel, ok := assertion.(*LEDRelationAssertion); !ok || rel.Ratio != 2 || rel.Other != "status" {
+		t.Errorf("Unexpected relation: %+v", assertion)
+	}
+
+	for _, dsl := range []string{
+		"LED.a RATE == 0x LED.b",
+		"LED.a BLINKS WITH LED.b",
+		"led('a').rate_ratio('b', -1)",
+		"led('a').iff('on', 'b', 'dim')",
+		"led('a').same_rate(2)",
+	} {
+		if _, err := Parse(dsl); err == nil {
+			t.Errorf("Expected Parse(%q) to fail", dsl)
+		}
+	}
+}
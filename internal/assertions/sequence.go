@@ -0,0 +1,315 @@
+package assertions
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/perceptumx/percepta/internal/core"
+)
+
+// defaultSequenceTimeout bounds a SEQUENCE without an explicit WITHIN
+const defaultSequenceTimeout = 60 * time.Second
+
+// SequenceStep is one expected state of a SequenceAssertion with optional dwell bounds
+type SequenceStep struct {
+	State    Assertion
+	MinDwell time.Duration // 0 = no minimum
+	MaxDwell time.Duration // 0 = no maximum
+}
+
+func (s SequenceStep) String() string {
+	state := wrapOperand(s.State, precedenceNot)
+	switch {
+	case s.MinDwell > 0 && s.MaxDwell > 0:
+		return fmt.Sprintf("%s FOR %s..%s", state, formatDuration(s.MinDwell), formatDuration(s.MaxDwell))
+	case s.MinDwell > 0:
+		return fmt.Sprintf("%s FOR >= %s", state, formatDuration(s.MinDwell))
+	case s.MaxDwell > 0:
+		return fmt.Sprintf("%s FOR <= %s", state, formatDuration(s.MaxDwell))
+	}
+	return state
+}
+
+// SequenceAssertion watches a stream of observations for an ordered set of states,
+// e.g. blue blinking while connecting, then green solid once connected.
+//
+// Syntax: SEQUENCE [WITHIN <duration>] [EVERY <duration>] <state> [FOR <dwell>] THEN <state> [FOR <dwell>] ...
+// where <dwell> is ">= 2s", "<= 30s" or "2s..30s".
+//
+// Samples matching neither the current nor the next state are ignored until the
+// first state is seen; afterwards they fail the sequence as an unexpected state.
+// Inconclusive samples (too uncertain to tell either state) are skipped, but a
+// sequence that times out after skipping some is inconclusive rather than failed.
+type SequenceAssertion struct {
+	Steps  []SequenceStep
+	Within time.Duration // Overall timeout (0 = defaultSequenceTimeout)
+	Every  time.Duration // Minimum spacing between samples (0 = back-to-back)
+	clock  clock
+}
+
+// Evaluate cannot decide an ordering from a single snapshot, so the result is inconclusive
+func (a *SequenceAssertion) Evaluate(obs *core.Observation) AssertionResult {
+	return AssertionResult{
+		Inconclusive: true,
+		Expected:     a.String(),
+		Actual:       fmt.Sprintf("single observation: %s", describeState(obs)),
+		Message:      "A state sequence needs a stream of observations; run it against a live device",
+	}
+}
+
+func (a *SequenceAssertion) String() string {
+	var b strings.Builder
+	b.WriteString("SEQUENCE ")
+	if a.Within > 0 {
+		fmt.Fprintf(&b, "WITHIN %s ", formatDuration(a.Within))
+	}
+	if a.Every > 0 {
+		fmt.Fprintf(&b, "EVERY %s ", formatDuration(a.Every))
+	}
+	steps := make([]string, 0, len(a.Steps))
+	for _, step := range a.Steps {
+		steps = append(steps, step.String())
+	}
+	b.WriteString(strings.Join(steps, " THEN "))
+	return b.String()
+}
+
+// observedState is a run of consecutive samples that looked the same
+type observedState struct {
+	step    int // Index of the matching step, -1 if none matched
+	label   string
+	from    time.Time
+	to      time.Time
+	samples int
+}
+
+// sequenceTracker records the observed states for the failure report
+type sequenceTracker struct {
+	states []observedState
+}
+
+func (t *sequenceTracker) add(step int, label string, at time.Time) {
+	if n := len(t.states); n > 0 && t.states[n-1].step == step && t.states[n-1].label == label {
+		t.states[n-1].to = at
+		t.states[n-1].samples++
+		return
+	}
+	t.states = append(t.states, observedState{step: step, label: label, from: at, to: at, samples: 1})
+}
+
+func (t *sequenceTracker) String() string {
+	if len(t.states) == 0 {
+		return "no states observed"
+	}
+	parts := make([]string, 0, len(t.states))
+	for _, s := range t.states {
+		marker := "?"
+		if s.step >= 0 {
+			marker = fmt.Sprintf("%d", s.step+1)
+		}
+		parts = append(parts, fmt.Sprintf("[%s] %s (%s, %d samples)", marker, s.label, s.to.Sub(s.from).Round(time.Millisecond), s.samples))
+	}
+	return strings.Join(parts, " → ")
+}
+
+// EvaluateOver samples until every step has been seen in order, a step breaks
+// its dwell bounds, an unexpected state appears, or the timeout expires.
+// Dwell bounds are checked while in a state and when leaving it.
+func (a *SequenceAssertion) EvaluateOver(observe Observer) (AssertionResult, error) {
+	if len(a.Steps) == 0 {
+		return AssertionResult{}, fmt.Errorf("sequence has no states")
+	}
+
+	clk := clockOrDefault(a.clock)
+	within := a.Within
+	if within <= 0 {
+		within = defaultSequenceTimeout
+	}
+	deadline := clk.Now().Add(within)
+
+	var tracker sequenceTracker
+	current := -1 // Index of the step we are in; -1 while waiting for the first
+	var entered time.Time
+	minConf, matchedAny := 1.0, false
+	samples, inconclusive := 0, 0
+	var lastObs *core.Observation
+	var lastErr error
+
+	confidence := func() float64 {
+		if !matchedAny {
+			return 0
+		}
+		return minConf
+	}
+	fail := func(actual, message string, obs *core.Observation) AssertionResult {
+		return a.result(false, actual, message, confidence(), obs)
+	}
+
+	for {
+		sampleStart := clk.Now()
+		obs, err := observe()
+		if err != nil {
+			lastErr = err
+		} else {
+			samples++
+			lastObs = obs
+			at := obs.Timestamp
+			if at.IsZero() {
+				at = clk.Now()
+			}
+
+			// Prefer staying in the current state, then advancing to the next
+			matched, uncertain := -1, false
+			var matchedResult AssertionResult
+			for _, i := range []int{current, current + 1} {
+				if i < 0 || i >= len(a.Steps) {
+					continue
+				}
+				r := a.Steps[i].State.Evaluate(obs)
+				if r.Passed {
+					matched, matchedResult = i, r
+					break
+				}
+				uncertain = uncertain || r.Inconclusive
+			}
+			label := describeState(obs)
+			if matched == -1 && uncertain {
+				label += " (inconclusive)"
+			}
+			tracker.add(matched, label, at)
+
+			switch {
+			case matched == -1 && uncertain:
+				// Too uncertain to tell: neither a state nor a violation
+				inconclusive++
+
+			case matched == -1 && current == -1:
+				// Still waiting for the first state
+
+			case matched == -1:
+				return fail(
+					fmt.Sprintf("unexpected state %s while in state %d", label, current+1),
+					fmt.Sprintf("Expected %s or %s; observed: %s", a.Steps[current].State.String(), nextStateString(a.Steps, current), tracker.String()),
+					obs), nil
+
+			case matched == current:
+				if max := a.Steps[current].MaxDwell; max > 0 && at.Sub(entered) > max {
+					return fail(
+						fmt.Sprintf("state %d held for %s, longer than %s", current+1, at.Sub(entered).Round(time.Millisecond), formatDuration(max)),
+						fmt.Sprintf("State %d (%s) exceeded its maximum dwell; observed: %s", current+1, a.Steps[current].State.String(), tracker.String()),
+						obs), nil
+				}
+
+			default: // Advanced to the next state
+				if current >= 0 {
+					if min := a.Steps[current].MinDwell; at.Sub(entered) < min {
+						return fail(
+							fmt.Sprintf("state %d lasted %s, shorter than %s", current+1, at.Sub(entered).Round(time.Millisecond), formatDuration(min)),
+							fmt.Sprintf("State %d (%s) left before its minimum dwell; observed: %s", current+1, a.Steps[current].State.String(), tracker.String()),
+							obs), nil
+					}
+					if max := a.Steps[current].MaxDwell; max > 0 && at.Sub(entered) > max {
+						return fail(
+							fmt.Sprintf("state %d lasted %s, longer than %s", current+1, at.Sub(entered).Round(time.Millisecond), formatDuration(max)),
+							fmt.Sprintf("State %d (%s) exceeded its maximum dwell; observed: %s", current+1, a.Steps[current].State.String(), tracker.String()),
+							obs), nil
+					}
+				}
+				current, entered = matched, at
+			}
+
+			if matched >= 0 {
+				matchedAny = true
+				if matchedResult.Confidence < minConf {
+					minConf = matchedResult.Confidence
+				}
+			}
+
+			// Done once the final state has been entered and held long enough
+			if last := len(a.Steps) - 1; current == last && at.Sub(entered) >= a.Steps[last].MinDwell {
+				return a.result(true,
+					fmt.Sprintf("observed all %d states in order", len(a.Steps)),
+					fmt.Sprintf("Observed: %s", tracker.String()),
+					confidence(), obs), nil
+			}
+		}
+
+		if !clk.Now().Before(deadline) {
+			break
+		}
+		pace(clk, a.Every, sampleStart, deadline)
+	}
+
+	if samples == 0 {
+		return AssertionResult{}, fmt.Errorf("no observation succeeded within %s: %w", formatDuration(within), lastErr)
+	}
+
+	reached := "none of the states"
+	if current >= 0 {
+		reached = fmt.Sprintf("state %d of %d", current+1, len(a.Steps))
+	}
+	if inconclusive > 0 {
+		result := a.result(false,
+			fmt.Sprintf("timed out after %s having reached %s, with %d of %d samples inconclusive", formatDuration(within), reached, inconclusive, samples),
+			fmt.Sprintf("Sequence incomplete, but uncertain samples may have hidden its states; observed: %s", tracker.String()),
+			confidence(), lastObs)
+		result.Inconclusive = true
+		return result, nil
+	}
+	return fail(
+		fmt.Sprintf("timed out after %s having reached %s", formatDuration(within), reached),
+		fmt.Sprintf("Sequence incomplete; observed: %s", tracker.String()),
+		lastObs), nil
+}
+
+// result builds the sequence result, stamped with the deciding observation
+func (a *SequenceAssertion) result(passed bool, actual, message string, confidence float64, obs *core.Observation) AssertionResult {
+	result := AssertionResult{
+		Passed:     passed,
+		Expected:   a.String(),
+		Actual:     actual,
+		Confidence: confidence,
+		Message:    message,
+		Op:         OpSequence,
+	}
+	if obs != nil {
+		stampObservation(&result, obs)
+	}
+	return result
+}
+
+func nextStateString(steps []SequenceStep, current int) string {
+	if current+1 < len(steps) {
+		return steps[current+1].State.String()
+	}
+	return "no further state"
+}
+
+// describeState summarises the LED and display signals of an observation,
+// e.g. `LED1 ON RGB(0,0,255) 2.0Hz, LCD "Connecting"`
+func describeState(obs *core.Observation) string {
+	var parts []string
+	for _, led := range ledSignals(obs) {
+		desc := fmt.Sprintf("%s %s", led.Name, onOffString(led.On))
+		if led.Color != (core.RGB{}) {
+			desc += fmt.Sprintf(" RGB(%d,%d,%d)", led.Color.R, led.Color.G, led.Color.B)
+		}
+		if led.BlinkHz > 0 {
+			desc += fmt.Sprintf(" %.1fHz", led.BlinkHz)
+		}
+		parts = append(parts, desc)
+	}
+	for _, sig := range obs.Signals {
+		switch s := sig.(type) {
+		case core.DisplaySignal:
+			parts = append(parts, fmt.Sprintf("%s %q", s.Name, s.Text))
+		case *core.DisplaySignal:
+			parts = append(parts, fmt.Sprintf("%s %q", s.Name, s.Text))
+		}
+	}
+	if len(parts) == 0 {
+		return "no signals"
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,259 @@
+package assertions
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/perceptumx/percepta/internal/core"
+)
+
+var (
+	blueBlinking = core.LEDSignal{Name: "LED1", On: true, Color: core.RGB{B: 255}, BlinkHz: 2, Confidence: 0.9}
+	greenSolid   = core.LEDSignal{Name: "LED1", On: true, Color: core.RGB{G: 255}, Confidence: 0.8}
+	redBlinking  = core.LEDSignal{Name: "LED1", On: true, Color: core.RGB{R: 255}, BlinkHz: 4, Confidence: 0.9}
+	ledOff       = core.LEDSignal{Name: "LED1", On: false, Confidence: 0.9}
+)
+
+const wifiSequence = "SEQUENCE WITHIN 30s " +
+	"(led('LED1').blinks() && led('LED1').color_rgb(0,0,255)) FOR 2s..10s " +
+	"THEN (LED.LED1 STEADY && led('LED1').color_rgb(0,255,0)) FOR >= 2s"
+
+// ledStream returns one observation per call with the given LED states,
+// one second of fake time apart, repeating the last state
+func ledStream(clk *fakeClock, states ...core.LEDSignal) Observer {
+	calls := 0
+	return func() (*core.Observation, error) {
+		state := states[len(states)-1]
+		if calls < len(states) {
+			state = states[calls]
+		}
+		calls++
+		clk.Sleep(time.Second)
+		return &core.Observation{
+			ID:        fmt.Sprintf("obs-%d", calls),
+			Timestamp: clk.now,
+			Signals:   []core.Signal{state},
+		}, nil
+	}
+}
+
+func parseSequence(t *testing.T, dsl string, clk *fakeClock) *SequenceAssertion {
+	t.Helper()
+	assertion, err := Parse(dsl)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	seq, ok := assertion.(*SequenceAssertion)
+	if !ok {
+		t.Fatalf("Expected *SequenceAssertion, got %T", assertion)
+	}
+	seq.clock = clk
+	return seq
+}
+
+func TestParse_Sequence(t *testing.T) {
+	seq := parseSequence(t, wifiSequence, nil)
+
+	if seq.Within != 30*time.Second || len(seq.Steps) != 2 {
+		t.Fatalf("Expected 2 steps within 30s, got %d within %v", len(seq.Steps), seq.Within)
+	}
+	if seq.Steps[0].MinDwell != 2*time.Second || seq.Steps[0].MaxDwell != 10*time.Second {
+		t.Errorf("Expected first dwell 2s..10s, got %v..%v", seq.Steps[0].MinDwell, seq.Steps[0].MaxDwell)
+	}
+	if seq.Steps[1].MinDwell != 2*time.Second || seq.Steps[1].MaxDwell != 0 {
+		t.Errorf("Expected second dwell >= 2s, got %v..%v", seq.Steps[1].MinDwell, seq.Steps[1].MaxDwell)
+	}
+
+	reparsed, err := Parse(seq.String())
+	if err != nil {
+		t.Fatalf("Re-parse of %q failed: %v", seq.String(), err)
+	}
+	if reparsed.String() != seq.String() {
+		t.Errorf("Expected stable String(), got %q then %q", seq.String(), reparsed.String())
+	}
+}
+
+func TestParse_SequenceStatementsEndAtKeywords(t *testing.T) {
+	seq := parseSequence(t, "SEQUENCE LED.LED1 BLINKING FOR <= 5s THEN LED.LED1 ON", nil)
+	if len(seq.Steps) != 2 {
+		t.Fatalf("Expected 2 steps, got %d", len(seq.Steps))
+	}
+	if seq.Steps[0].State.String() != "LED.LED1 BLINKING" || seq.Steps[0].MaxDwell != 5*time.Second {
+		t.Errorf("Unexpected first step: %s", seq.Steps[0])
+	}
+}
+
+func TestParse_SequenceErrors(t *testing.T) {
+	tests := []struct {
+		dsl     string
+		wantErr string
+	}{
+		{"SEQUENCE LED.a ON THEN", "unexpected end of expression"},
+		{"SEQUENCE LED.a ON FOR 2s THEN LED.b ON", "expected dwell range"},
+		{"SEQUENCE LED.a ON FOR 5s..2s THEN LED.b ON", "maximum below minimum"},
+		{"SEQUENCE WITHIN LED.a ON", "expected duration"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.dsl, func(t *testing.T) {
+			_, err := Parse(tt.dsl)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestSequence_Pass(t *testing.T) {
+	clk := &fakeClock{now: time.Unix(0, 0)}
+	seq := parseSequence(t, wifiSequence, clk)
+
+	// Off while booting, blue blinking for 3s, then green solid
+	observe := ledStream(clk, ledOff, blueBlinking, blueBlinking, blueBlinking, greenSolid, greenSolid, greenSolid)
+
+	result, err := seq.EvaluateOver(observe)
+	if err != nil {
+		t.Fatalf("EvaluateOver failed: %v", err)
+	}
+	if !result.Passed {
+		t.Fatalf("Expected pass, got: %s / %s", result.Actual, result.Message)
+	}
+	if result.ObservationID != "obs-7" {
+		t.Errorf("Expected sequence to complete on obs-7, got %q", result.ObservationID)
+	}
+	if result.Confidence != 0.8 {
+		t.Errorf("Expected weakest matched confidence 0.8, got %.2f", result.Confidence)
+	}
+	if !strings.Contains(result.Message, "[?] LED1 OFF") || !strings.Contains(result.Message, "[2] LED1 ON RGB(0,255,0)") {
+		t.Errorf("Expected observed states in report, got %q", result.Message)
+	}
+}
+
+func TestSequence_UnexpectedState(t *testing.T) {
+	clk := &fakeClock{now: time.Unix(0, 0)}
+	seq := parseSequence(t, wifiSequence, clk)
+
+	observe := ledStream(clk, blueBlinking, blueBlinking, blueBlinking, redBlinking)
+
+	result, err := seq.EvaluateOver(observe)
+	if err != nil {
+		t.Fatalf("EvaluateOver failed: %v", err)
+	}
+	if result.Passed {
+		t.Fatal("Expected red blinking to fail the sequence")
+	}
+	if !strings.Contains(result.Actual, "unexpected state LED1 ON RGB(255,0,0) 4.0Hz") {
+		t.Errorf("Expected unexpected state in Actual, got %q", result.Actual)
+	}
+	if !strings.Contains(result.Message, "[1] LED1 ON RGB(0,0,255) 2.0Hz (2s, 3 samples) → [?] LED1 ON RGB(255,0,0)") {
+		t.Errorf("Expected observed state history, got %q", result.Message)
+	}
+}
+
+func TestSequence_DwellBounds(t *testing.T) {
+	t.Run("too short", func(t *testing.T) {
+		clk := &fakeClock{now: time.Unix(0, 0)}
+		seq := parseSequence(t, wifiSequence, clk)
+		result, err := seq.EvaluateOver(ledStream(clk, blueBlinking, greenSolid))
+		if err != nil {
+			t.Fatalf("EvaluateOver failed: %v", err)
+		}
+		if result.Passed || !strings.Contains(result.Actual, "shorter than 2s") {
+			t.Errorf("Expected minimum dwell failure, got %q", result.Actual)
+		}
+	})
+
+	t.Run("too long", func(t *testing.T) {
+		clk := &fakeClock{now: time.Unix(0, 0)}
+		seq := parseSequence(t, wifiSequence, clk)
+		result, err := seq.EvaluateOver(ledStream(clk, blueBlinking))
+		if err != nil {
+			t.Fatalf("EvaluateOver failed: %v", err)
+		}
+		if result.Passed || !strings.Contains(result.Actual, "longer than 10s") {
+			t.Errorf("Expected maximum dwell failure, got %q", result.Actual)
+		}
+	})
+}
+
+func TestSequence_MaxDwellOnLeaving(t *testing.T) {
+	// Blue for 11s: every sample while dwelling is within 10s of entering,
+	// but the state has lasted too long by the time green appears
+	clk := &fakeClock{now: time.Unix(0, 0)}
+	seq := parseSequence(t, wifiSequence, clk)
+	states := make([]core.LEDSignal, 0, 12)
+	for i := 0; i < 11; i++ {
+		states = append(states, blueBlinking)
+	}
+	states = append(states, greenSolid)
+
+	result, err := seq.EvaluateOver(ledStream(clk, states...))
+	if err != nil {
+		t.Fatalf("EvaluateOver failed: %v", err)
+	}
+	if result.Passed || !strings.Contains(result.Actual, "state 1 lasted 11s, longer than 10s") {
+		t.Errorf("Expected maximum dwell failure on leaving, got %q", result.Actual)
+	}
+}
+
+func TestSequence_InconclusiveSamples(t *testing.T) {
+	uncertain := redBlinking
+	uncertain.Confidence = 0.3
+
+	t.Run("skipped", func(t *testing.T) {
+		clk := &fakeClock{now: time.Unix(0, 0)}
+		seq := WithMinConfidence(parseSequence(t, wifiSequence, clk), 0.5).(*SequenceAssertion)
+		observe := ledStream(clk, blueBlinking, blueBlinking, uncertain, blueBlinking, greenSolid, greenSolid, greenSolid)
+
+		result, err := seq.EvaluateOver(observe)
+		if err != nil {
+			t.Fatalf("EvaluateOver failed: %v", err)
+		}
+		if !result.Passed {
+			t.Fatalf("Expected the low-confidence sample to be skipped, got %s: %s", result.Outcome(), result.Actual)
+		}
+		if !strings.Contains(result.Message, "RGB(255,0,0) 4.0Hz (inconclusive)") {
+			t.Errorf("Expected the skipped sample in the report, got %q", result.Message)
+		}
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		clk := &fakeClock{now: time.Unix(0, 0)}
+		seq := WithMinConfidence(parseSequence(t, "SEQUENCE WITHIN 5s LED.LED1 BLINKING THEN LED.LED1 STEADY", clk), 0.5).(*SequenceAssertion)
+
+		result, err := seq.EvaluateOver(ledStream(clk, uncertain))
+		if err != nil {
+			t.Fatalf("EvaluateOver failed: %v", err)
+		}
+		if result.Outcome() != OutcomeInconclusive || !strings.Contains(result.Actual, "5 of 5 samples inconclusive") {
+			t.Errorf("Expected an inconclusive timeout, got %s: %q", result.Outcome(), result.Actual)
+		}
+	})
+}
+
+func TestSequence_Timeout(t *testing.T) {
+	clk := &fakeClock{now: time.Unix(0, 0)}
+	seq := parseSequence(t, "SEQUENCE WITHIN 5s LED.LED1 BLINKING THEN LED.LED1 STEADY", clk)
+
+	result, err := seq.EvaluateOver(ledStream(clk, ledOff))
+	if err != nil {
+		t.Fatalf("EvaluateOver failed: %v", err)
+	}
+	if result.Passed || !strings.Contains(result.Actual, "having reached none of the states") {
+		t.Errorf("Expected timeout before first state, got %q", result.Actual)
+	}
+	if result.Confidence != 0 {
+		t.Errorf("Expected zero confidence when no state matched, got %.2f", result.Confidence)
+	}
+}
+
+func TestSequence_SnapshotIsInconclusive(t *testing.T) {
+	seq := parseSequence(t, wifiSequence, nil)
+	obs := observation("test-1", core.LEDSignal{Name: "LED1", On: true, BlinkHz: 2.0, Color: core.RGB{B: 255}, Confidence: 0.9})
+	result := seq.Evaluate(obs)
+	if result.Outcome() != OutcomeInconclusive {
+		t.Errorf("Expected INCONCLUSIVE for single snapshot, got %s", result.Outcome())
+	}
+}
@@ -0,0 +1,92 @@
+package assertions
+
+import (
+	"strings"
+
+	"github.com/perceptumx/percepta/internal/core"
+)
+
+// Signal lookup helpers accept both value and pointer signals: the vision
+// pipeline emits values while hand-built observations often use pointers.
+
+// ledSignals returns every LED signal in the observation
+func ledSignals(obs *core.Observation) []core.LEDSignal {
+	var leds []core.LEDSignal
+	for _, sig := range obs.Signals {
+		switch s := sig.(type) {
+		case core.LEDSignal:
+			leds = append(leds, s)
+		case *core.LEDSignal:
+			leds = append(leds, *s)
+		}
+	}
+	return leds
+}
+
+// findLED matches an LED by name (case-insensitive).
+// If no name matches and exactly one LED exists, that LED is used.
+func findLED(obs *core.Observation, name string) *core.LEDSignal {
+	leds := ledSignals(obs)
+	for i := range leds {
+		if strings.EqualFold(leds[i].Name, name) {
+			return &leds[i]
+		}
+	}
+
+	if len(leds) == 1 {
+		return &leds[0]
+	}
+	return nil
+}
+
+// findDisplay matches a display by name, optionally ignoring case
+func findDisplay(obs *core.Observation, name string, ignoreCase bool) *core.DisplaySignal {
+	for _, sig := range obs.Signals {
+		var display core.DisplaySignal
+		switch s := sig.(type) {
+		case core.DisplaySignal:
+			display = s
+		case *core.DisplaySignal:
+			display = *s
+		default:
+			continue
+		}
+
+		if display.Name == name || (ignoreCase && strings.EqualFold(display.Name, name)) {
+			return &display
+		}
+	}
+	return nil
+}
+
+// findBootTiming returns the first boot timing signal in the observation
+func findBootTiming(obs *core.Observation) *core.BootTimingSignal {
+	for _, sig := range obs.Signals {
+		switch s := sig.(type) {
+		case core.BootTimingSignal:
+			return &s
+		case *core.BootTimingSignal:
+			return s
+		}
+	}
+	return nil
+}
+
+// findQuery returns the answer to the question, matched ignoring surrounding
+// space and case
+func findQuery(obs *core.Observation, question string) *core.QuerySignal {
+	question = strings.TrimSpace(question)
+	for _, sig := range obs.Signals {
+		switch s := sig.(type) {
+		case core.QuerySignal:
+			if strings.EqualFold(strings.TrimSpace(s.Question), question) {
+				return &s
+			}
+		case *core.QuerySignal:
+			if strings.EqualFold(strings.TrimSpace(s.Question), question) {
+				return s
+			}
+		}
+	}
+	return nil
+}
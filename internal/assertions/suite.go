@@ -0,0 +1,345 @@
+package assertions
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/perceptumx/percepta/internal/core"
+	"github.com/perceptumx/percepta/internal/tolerance"
+	"go.yaml.in/yaml/v3"
+)
+
+// Suite is a declarative collection of named assertion cases for one device.
+//
+// Example (YAML):
+//
+//	device: my-esp32
+//	setup: Power the board from USB and wait for WiFi
+//	shared_observation: false
+//	min_confidence: 0.7
+//	reobserve: 2
+//	require: 2-of-3
+//	cases:
+//	  - name: wifi connected
+//	    assertions:
+//	      - LED.status ON
+//	      - led('status').color_rgb(0,255,0)
+type Suite struct {
+	Device            string      `yaml:"device" json:"device"`
+	Setup             string      `yaml:"setup" json:"setup"`
+	SharedObservation bool        `yaml:"shared_observation" json:"shared_observation"`
+	MinConfidence     float64     `yaml:"min_confidence" json:"min_confidence"` // Below this, results are INCONCLUSIVE (0 = off)
+	Reobserve         int         `yaml:"reobserve" json:"reobserve"`           // Extra observations to try while INCONCLUSIVE
+	Retries           int         `yaml:"retries" json:"retries"`               // Extra attempts after a failure; any passing attempt passes
+	Require           string      `yaml:"require" json:"require"`               // K-of-N attempts that must pass, e.g. 2-of-3
+	Cases             []SuiteCase `yaml:"cases" json:"cases"`
+
+	// Quorum decides each assertion by vote over attempts; set by Compile from
+	// Retries or Require, and overridable by the caller
+	Quorum Quorum `yaml:"-" json:"-"`
+
+	// Tolerance is the device's profile, set by the caller (nil = tolerance.Default())
+	Tolerance *tolerance.Profile `yaml:"-" json:"-"`
+}
+
+// SuiteCase is a named test case holding one or more assertion expressions
+type SuiteCase struct {
+	Name       string   `yaml:"name" json:"name"`
+	Setup      string   `yaml:"setup" json:"setup"`
+	Assertions []string `yaml:"assertions" json:"assertions"`
+
+	compiled []Assertion
+}
+
+// CaseResult is the outcome of running one suite case
+type CaseResult struct {
+	Name          string
+	ObservationID string
+	Results       []AssertionResult
+	Duration      time.Duration // Wall-clock time for the case, including its observations
+	Err           error         // Observation failure; remaining assertions were not evaluated
+}
+
+// Passed reports whether the observation succeeded and every assertion passed
+func (c CaseResult) Passed() bool {
+	return c.Outcome() == OutcomePass
+}
+
+// Outcome is FAIL on error or any failing assertion, INCONCLUSIVE if any
+// assertion was inconclusive, PASS otherwise
+func (c CaseResult) Outcome() Outcome {
+	if c.Err != nil {
+		return OutcomeFail
+	}
+	outcome := OutcomePass
+	for _, r := range c.Results {
+		switch r.Outcome() {
+		case OutcomeFail:
+			return OutcomeFail
+		case OutcomeInconclusive:
+			outcome = OutcomeInconclusive
+		}
+	}
+	return outcome
+}
+
+// SuiteResult aggregates the outcome of every case in a suite
+type SuiteResult struct {
+	Device string
+	Cases  []CaseResult
+}
+
+// Passed reports whether every case passed
+func (s SuiteResult) Passed() bool {
+	return s.Outcome() == OutcomePass
+}
+
+// Outcome is FAIL if any case failed, INCONCLUSIVE if any was inconclusive, PASS otherwise
+func (s SuiteResult) Outcome() Outcome {
+	_, failed, inconclusive := s.Counts()
+	switch {
+	case failed > 0:
+		return OutcomeFail
+	case inconclusive > 0:
+		return OutcomeInconclusive
+	}
+	return OutcomePass
+}
+
+// Counts returns the number of passed, failed and inconclusive cases
+func (s SuiteResult) Counts() (passed, failed, inconclusive int) {
+	for _, c := range s.Cases {
+		switch c.Outcome() {
+		case OutcomePass:
+			passed++
+		case OutcomeFail:
+			failed++
+		case OutcomeInconclusive:
+			inconclusive++
+		}
+	}
+	return
+}
+
+// LoadSuite reads a suite from a .yaml/.yml or .json file and compiles its assertions
+func LoadSuite(path string) (*Suite, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read suite file: %w", err)
+	}
+
+	var suite Suite
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		if err := json.Unmarshal(data, &suite); err != nil {
+			return nil, fmt.Errorf("invalid suite JSON: %w", err)
+		}
+	default:
+		if err := yaml.Unmarshal(data, &suite); err != nil {
+			return nil, fmt.Errorf("invalid suite YAML: %w", err)
+		}
+	}
+
+	if err := suite.Compile(); err != nil {
+		return nil, err
+	}
+
+	return &suite, nil
+}
+
+// expressionCase names the single case of an ExpressionSuite
+const expressionCase = "assertions"
+
+// ExpressionSuite builds a suite from ad-hoc expressions, such as several
+// command-line arguments: one case holding every expression, all evaluated
+// against a single shared observation
+func ExpressionSuite(device string, expressions []string) (*Suite, error) {
+	if len(expressions) == 0 {
+		return nil, fmt.Errorf("no assertions given")
+	}
+
+	c := SuiteCase{Name: expressionCase, Assertions: expressions}
+	for _, dsl := range expressions {
+		assertion, err := Parse(dsl)
+		if err != nil {
+			return nil, fmt.Errorf("invalid assertion %q: %w", dsl, err)
+		}
+		c.compiled = append(c.compiled, assertion)
+	}
+
+	return &Suite{Device: device, SharedObservation: true, Cases: []SuiteCase{c}}, nil
+}
+
+// LoadExpressions reads assertion expressions from a text file, one per
+// line. Blank lines and lines starting with # are skipped. Every expression
+// is parsed, so syntax errors are reported with their line number.
+func LoadExpressions(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read assertion file: %w", err)
+	}
+	defer f.Close()
+
+	var expressions []string
+	scanner := bufio.NewScanner(f)
+	for line := 1; scanner.Scan(); line++ {
+		dsl := strings.TrimSpace(scanner.Text())
+		if dsl == "" || strings.HasPrefix(dsl, "#") {
+			continue
+		}
+		if _, err := Parse(dsl); err != nil {
+			return nil, fmt.Errorf("%s:%d: invalid assertion: %w", path, line, err)
+		}
+		expressions = append(expressions, dsl)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read assertion file: %w", err)
+	}
+	if len(expressions) == 0 {
+		return nil, fmt.Errorf("%s: no assertions found", path)
+	}
+
+	return expressions, nil
+}
+
+// Compile validates the suite and parses every assertion expression
+func (s *Suite) Compile() error {
+	if len(s.Cases) == 0 {
+		return fmt.Errorf("suite has no cases")
+	}
+	if s.MinConfidence < 0 || s.MinConfidence > 1 {
+		return fmt.Errorf("min_confidence must be between 0 and 1, got %g", s.MinConfidence)
+	}
+
+	switch {
+	case s.Retries > 0 && s.Require != "":
+		return fmt.Errorf("retries and require cannot both be set")
+	case s.Retries > 0:
+		s.Quorum = RetryQuorum(s.Retries)
+	case s.Retries < 0:
+		return fmt.Errorf("retries must not be negative")
+	case s.Require != "":
+		q, err := ParseQuorum(s.Require)
+		if err != nil {
+			return err
+		}
+		s.Quorum = q
+	}
+
+	seen := make(map[string]bool)
+	for i := range s.Cases {
+		c := &s.Cases[i]
+		if c.Name == "" {
+			return fmt.Errorf("case %d has no name", i+1)
+		}
+		if seen[c.Name] {
+			return fmt.Errorf("duplicate case name %q", c.Name)
+		}
+		seen[c.Name] = true
+
+		if len(c.Assertions) == 0 {
+			return fmt.Errorf("case %q has no assertions", c.Name)
+		}
+
+		c.compiled = make([]Assertion, 0, len(c.Assertions))
+		for _, dsl := range c.Assertions {
+			assertion, err := Parse(dsl)
+			if err != nil {
+				return fmt.Errorf("case %q: invalid assertion %q: %w", c.Name, dsl, err)
+			}
+			c.compiled = append(c.compiled, assertion)
+		}
+	}
+
+	return nil
+}
+
+// Compiled returns the parsed assertions for the case (nil before Compile)
+func (c *SuiteCase) Compiled() []Assertion {
+	return c.compiled
+}
+
+// CaseObserver captures an observation for the named case. resample is set
+// for the further samples and attempts after a case's first observation.
+type CaseObserver func(c *SuiteCase, resample bool) (*core.Observation, error)
+
+// Run evaluates every case in order. With SharedObservation set, observe is
+// called once and its observation is reused for all cases. Temporal assertions
+// call observe again for each additional sample they need, as do INCONCLUSIVE
+// assertions while Reobserve attempts remain and every attempt after the first
+// when a Quorum votes.
+func (s *Suite) Run(device string, observe CaseObserver) SuiteResult {
+	result := SuiteResult{Device: device}
+
+	var shared *core.Observation
+	var sharedErr error
+	if s.SharedObservation {
+		shared, sharedErr = observe(nil, false)
+	}
+
+	for i := range s.Cases {
+		c := &s.Cases[i]
+		start := time.Now()
+
+		obs, err := shared, sharedErr
+		if !s.SharedObservation {
+			obs, err = observe(c, false)
+		}
+
+		caseResult := CaseResult{Name: c.Name}
+		if err != nil {
+			caseResult.Err = err
+			caseResult.Duration = time.Since(start)
+			result.Cases = append(result.Cases, caseResult)
+			continue
+		}
+
+		caseResult.ObservationID = obs.ID
+		resample := func() (*core.Observation, error) { return observe(c, true) }
+		for _, assertion := range c.compiled {
+			r, err := RunQuorum(s.prepare(assertion), obs, resample, s.Reobserve, s.Quorum)
+			if err != nil {
+				caseResult.Err = err
+				break
+			}
+			caseResult.Results = append(caseResult.Results, r)
+		}
+		caseResult.Duration = time.Since(start)
+		result.Cases = append(result.Cases, caseResult)
+	}
+
+	return result
+}
+
+// RunStored evaluates every case against a single existing observation, such as
+// one loaded from storage. No new observations are captured, so temporal
+// operators are checked against that snapshot only; Reobserve and Quorum are ignored.
+func (s *Suite) RunStored(device string, obs *core.Observation) SuiteResult {
+	result := SuiteResult{Device: device}
+
+	for i := range s.Cases {
+		c := &s.Cases[i]
+		start := time.Now()
+		caseResult := CaseResult{Name: c.Name, ObservationID: obs.ID}
+		for _, assertion := range c.compiled {
+			caseResult.Results = append(caseResult.Results, EvaluateSnapshot(s.prepare(assertion), obs))
+		}
+		caseResult.Duration = time.Since(start)
+		result.Cases = append(result.Cases, caseResult)
+	}
+
+	return result
+}
+
+// prepare applies the suite's confidence threshold and tolerance profile
+func (s *Suite) prepare(assertion Assertion) Assertion {
+	if s.Tolerance != nil {
+		assertion = WithTolerance(assertion, *s.Tolerance)
+	}
+	return WithMinConfidence(assertion, s.MinConfidence)
+}
@@ -0,0 +1,309 @@
+package assertions
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/perceptumx/percepta/internal/core"
+)
+
+func writeSuiteFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write suite file: %v", err)
+	}
+	return path
+}
+
+func TestLoadSuite_YAML(t *testing.T) {
+	path := writeSuiteFile(t, "suite.yaml", `
+device: my-esp32
+setup: Power the board from USB
+shared_observation: true
+cases:
+  - name: power
+    assertions:
+      - LED.power ON
+  - name: wifi connected
+    setup: Wait for association
+    assertions:
+      - led('status').is_on()
+      - led('status').color_rgb(0,255,0)
+`)
+
+	suite, err := LoadSuite(path)
+	if err != nil {
+		t.Fatalf("LoadSuite failed: %v", err)
+	}
+
+	if suite.Device != "my-esp32" {
+		t.Errorf("Expected device 'my-esp32', got '%s'", suite.Device)
+	}
+	if !suite.SharedObservation {
+		t.Error("Expected shared_observation to be true")
+	}
+	if len(suite.Cases) != 2 {
+		t.Fatalf("Expected 2 cases, got %d", len(suite.Cases))
+	}
+	if suite.Cases[1].Setup != "Wait for association" {
+		t.Errorf("Expected case setup notes, got '%s'", suite.Cases[1].Setup)
+	}
+	if len(suite.Cases[1].Compiled()) != 2 {
+		t.Errorf("Expected 2 compiled assertions, got %d", len(suite.Cases[1].Compiled()))
+	}
+}
+
+func TestLoadSuite_JSON(t *testing.T) {
+	path := writeSuiteFile(t, "suite.json", `{
+		"device": "fpga",
+		"cases": [{"name": "led", "assertions": ["LED.LED1 BLINKING"]}]
+	}`)
+
+	suite, err := LoadSuite(path)
+	if err != nil {
+		t.Fatalf("LoadSuite failed: %v", err)
+	}
+	if suite.Device != "fpga" || len(suite.Cases) != 1 {
+		t.Errorf("Unexpected suite: %+v", suite)
+	}
+}
+
+func TestLoadSuite_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{"no cases", "device: x\n", "no cases"},
+		{"missing name", "cases:\n  - assertions: [LED.a ON]\n", "has no name"},
+		{"no assertions", "cases:\n  - name: a\n", "has no assertions"},
+		{"duplicate", "cases:\n  - name: a\n    assertions: [LED.a ON]\n  - name: a\n    assertions: [LED.a ON]\n", "duplicate case name"},
+		{"bad expression", "cases:\n  - name: a\n    assertions: [\"LED.a PURPLE\"]\n", `case "a": invalid assertion`},
+		{"bad quorum", "require: 3-of-2\ncases:\n  - name: a\n    assertions: [LED.a ON]\n", "more passes than attempts"},
+		{"retries and require", "retries: 2\nrequire: 2-of-3\ncases:\n  - name: a\n    assertions: [LED.a ON]\n", "cannot both be set"},
+		{"min confidence out of range", "min_confidence: 80\ncases:\n  - name: a\n    assertions: [LED.a ON]\n", "between 0 and 1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LoadSuite(writeSuiteFile(t, "suite.yaml", tt.content))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestSuite_RunPerCaseObservations(t *testing.T) {
+	suite := &Suite{
+		Cases: []SuiteCase{
+			{Name: "on", Assertions: []string{"LED.power ON"}},
+			{Name: "off", Assertions: []string{"LED.power OFF"}},
+		},
+	}
+	if err := suite.Compile(); err != nil {
+		t.Fatalf("Compile failed: %v", err)
+	}
+
+	calls := 0
+	result := suite.Run("dev", func(c *SuiteCase, resample bool) (*core.Observation, error) {
+		calls++
+		if c == nil {
+			t.Fatal("Expected case for per-case observation")
+		}
+		return &core.Observation{
+			ID:      "obs-" + c.Name,
+			Signals: []core.Signal{core.LEDSignal{Name: "power", On: true, Confidence: 0.9}},
+		}, nil
+	})
+
+	if calls != 2 {
+		t.Errorf("Expected one observation per case, got %d", calls)
+	}
+	if result.Passed() {
+		t.Error("Expected suite to fail")
+	}
+	passed, failed, _ := result.Counts()
+	if passed != 1 || failed != 1 {
+		t.Errorf("Expected 1 passed / 1 failed, got %d / %d", passed, failed)
+	}
+	if result.Cases[1].ObservationID != "obs-off" {
+		t.Errorf("Expected case observation ID recorded, got '%s'", result.Cases[1].ObservationID)
+	}
+}
+
+func TestSuite_RunQuorum(t *testing.T) {
+	path := writeSuiteFile(t, "suite.yaml", `
+require: 2-of-3
+cases:
+  - name: power
+    assertions: [LED.power ON]
+`)
+	suite, err := LoadSuite(path)
+	if err != nil {
+		t.Fatalf("LoadSuite failed: %v", err)
+	}
+	if suite.Quorum != (Quorum{Required: 2, Attempts: 3}) {
+		t.Fatalf("Expected a 2-of-3 quorum, got %s", suite.Quorum)
+	}
+
+	calls, resamples := 0, 0
+	result := suite.Run("dev", func(c *SuiteCase, resample bool) (*core.Observation, error) {
+		calls++
+		if resample {
+			resamples++
+		}
+		return &core.Observation{
+			ID:      fmt.Sprintf("obs-%d", calls),
+			Signals: []core.Signal{core.LEDSignal{Name: "power", On: calls != 2, Confidence: 0.9}},
+		}, nil
+	})
+
+	r := result.Cases[0].Results[0]
+	if !r.Passed || len(r.Attempts) != 3 || calls != 3 {
+		t.Errorf("Expected a pass after 3 attempts, got %s with %d attempts (%d observations)", r.Outcome(), len(r.Attempts), calls)
+	}
+	if resamples != 2 {
+		t.Errorf("Expected the attempts after the first to be flagged as resamples, got %d", resamples)
+	}
+	if result.Cases[0].ObservationID != "obs-1" {
+		t.Errorf("Expected the case to record its first observation, got %q", result.Cases[0].ObservationID)
+	}
+}
+
+func TestSuite_RunSharedObservation(t *testing.T) {
+	suite := &Suite{
+		SharedObservation: true,
+		Cases: []SuiteCase{
+			{Name: "a", Assertions: []string{"LED.power ON"}},
+			{Name: "b", Assertions: []string{"!LED.power OFF", "LED.power BLINKING || LED.power ON"}},
+		},
+	}
+	if err := suite.Compile(); err != nil {
+		t.Fatalf("Compile failed: %v", err)
+	}
+
+	calls := 0
+	result := suite.Run("dev", func(c *SuiteCase, resample bool) (*core.Observation, error) {
+		calls++
+		return &core.Observation{
+			ID:      "shared",
+			Signals: []core.Signal{core.LEDSignal{Name: "power", On: true, Confidence: 0.9}},
+		}, nil
+	})
+
+	if calls != 1 {
+		t.Errorf("Expected a single shared observation, got %d", calls)
+	}
+	if !result.Passed() {
+		t.Errorf("Expected suite to pass: %+v", result)
+	}
+}
+
+func TestSuite_RunObservationError(t *testing.T) {
+	suite := &Suite{Cases: []SuiteCase{{Name: "a", Assertions: []string{"LED.power ON"}}}}
+	if err := suite.Compile(); err != nil {
+		t.Fatalf("Compile failed: %v", err)
+	}
+
+	result := suite.Run("dev", func(c *SuiteCase, resample bool) (*core.Observation, error) {
+		return nil, errors.New("camera unplugged")
+	})
+
+	if result.Passed() || result.Cases[0].Err == nil {
+		t.Error("Expected observation error to fail the case")
+	}
+}
+
+func TestSuite_RunStored(t *testing.T) {
+	suite := &Suite{Cases: []SuiteCase{
+		{Name: "power", Assertions: []string{"LED.power ON"}},
+		{Name: "temporal", Assertions: []string{"EVENTUALLY 10s LED.power ON"}},
+	}}
+	if err := suite.Compile(); err != nil {
+		t.Fatalf("Compile failed: %v", err)
+	}
+
+	stored := &core.Observation{
+		ID:      "stored-1",
+		Signals: []core.Signal{core.LEDSignal{Name: "power", On: true, Confidence: 0.9}},
+	}
+	result := suite.RunStored("dev", stored)
+
+	if !result.Passed() {
+		t.Errorf("Expected suite to pass against stored observation: %+v", result)
+	}
+	for _, c := range result.Cases {
+		if c.ObservationID != "stored-1" || c.Results[0].ObservationID != "stored-1" {
+			t.Errorf("Expected case %q to be evaluated on stored-1", c.Name)
+		}
+	}
+}
+
+func TestExpressionSuite(t *testing.T) {
+	suite, err := ExpressionSuite("dev", []string{"LED.power ON", "LED.error OFF", "Display.LCD \"Ready\""})
+	if err != nil {
+		t.Fatalf("ExpressionSuite failed: %v", err)
+	}
+
+	calls := 0
+	result := suite.Run("dev", func(c *SuiteCase, resample bool) (*core.Observation, error) {
+		calls++
+		return &core.Observation{
+			ID: "shared",
+			Signals: []core.Signal{
+				core.LEDSignal{Name: "power", On: true, Confidence: 0.9},
+				core.LEDSignal{Name: "error", On: true, Confidence: 0.9},
+			},
+		}, nil
+	})
+
+	if calls != 1 {
+		t.Errorf("Expected one shared observation, got %d", calls)
+	}
+	if len(result.Cases) != 1 || len(result.Cases[0].Results) != 3 {
+		t.Fatalf("Expected one case with three results, got %+v", result)
+	}
+	outcomes := []Outcome{OutcomePass, OutcomeFail, OutcomeFail}
+	for i, r := range result.Cases[0].Results {
+		if r.Outcome() != outcomes[i] {
+			t.Errorf("Assertion %d: expected %s, got %s", i, outcomes[i], r.Outcome())
+		}
+	}
+	if result.Passed() {
+		t.Error("Expected the run to fail when any assertion fails")
+	}
+
+	if _, err := ExpressionSuite("dev", []string{"LED.power ON", "LED.power PURPLE"}); err == nil || !strings.Contains(err.Error(), `invalid assertion "LED.power PURPLE"`) {
+		t.Errorf("Expected the invalid expression to be named, got %v", err)
+	}
+	if _, err := ExpressionSuite("dev", nil); err == nil {
+		t.Error("Expected an error without expressions")
+	}
+}
+
+func TestLoadExpressions(t *testing.T) {
+	path := writeSuiteFile(t, "checks.txt", "# boot checks\nLED.power ON\n\n  LED.error OFF  \n")
+	expressions, err := LoadExpressions(path)
+	if err != nil {
+		t.Fatalf("LoadExpressions failed: %v", err)
+	}
+	if len(expressions) != 2 || expressions[1] != "LED.error OFF" {
+		t.Errorf("Unexpected expressions: %q", expressions)
+	}
+
+	bad := writeSuiteFile(t, "bad.txt", "LED.power ON\nLED.power PURPLE\n")
+	if _, err := LoadExpressions(bad); err == nil || !strings.Contains(err.Error(), "bad.txt:2: invalid assertion") {
+		t.Errorf("Expected error with line number, got %v", err)
+	}
+
+	empty := writeSuiteFile(t, "empty.txt", "# nothing yet\n")
+	if _, err := LoadExpressions(empty); err == nil || !strings.Contains(err.Error(), "no assertions") {
+		t.Errorf("Expected empty file error, got %v", err)
+	}
+}
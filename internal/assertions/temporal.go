@@ -0,0 +1,373 @@
+package assertions
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/perceptumx/percepta/internal/core"
+)
+
+// Observer captures a fresh observation on demand
+type Observer func() (*core.Observation, error)
+
+// TemporalAssertion is evaluated over a stream of observations instead of a single snapshot
+type TemporalAssertion interface {
+	Assertion
+	EvaluateOver(observe Observer) (AssertionResult, error)
+}
+
+// clock abstracts time so temporal operators can be tested without sleeping
+type clock interface {
+	Now() time.Time
+	Sleep(d time.Duration)
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time        { return time.Now() }
+func (realClock) Sleep(d time.Duration) { time.Sleep(d) }
+
+// EventuallyAssertion passes as soon as one observation within the window satisfies the operand.
+//
+// Syntax: EVENTUALLY <duration> [EVERY <duration>] <expr>
+type EventuallyAssertion struct {
+	Operand Assertion
+	Within  time.Duration
+	Every   time.Duration // Minimum spacing between samples (0 = back-to-back)
+	clock   clock
+}
+
+// Evaluate checks the operand against a single snapshot
+func (a *EventuallyAssertion) Evaluate(obs *core.Observation) AssertionResult {
+	return snapshotResult(a.String(), OpEventually, a.Operand.Evaluate(obs))
+}
+
+func (a *EventuallyAssertion) String() string {
+	return temporalString("EVENTUALLY", a.Within, a.Every, a.Operand)
+}
+
+// EvaluateOver samples until the operand holds or the window expires.
+// The result carries the decisive sample: the first passing one, or the last one seen.
+// If no sample passed but some were inconclusive, the result is inconclusive.
+func (a *EventuallyAssertion) EvaluateOver(observe Observer) (AssertionResult, error) {
+	clk := clockOrDefault(a.clock)
+	start := clk.Now()
+	deadline := start.Add(a.Within)
+
+	samples, inconclusive := 0, 0
+	var last, lastInconclusive AssertionResult
+	var lastObs, lastInconclusiveObs *core.Observation
+	var lastErr error
+
+	for {
+		sampleStart := clk.Now()
+		obs, err := observe()
+		if err != nil {
+			lastErr = err
+		} else {
+			samples++
+			child := a.Operand.Evaluate(obs)
+			if child.Passed {
+				return decisiveResult(AssertionResult{
+					Passed:     true,
+					Expected:   a.String(),
+					Actual:     fmt.Sprintf("held after %s (%d samples)", clk.Now().Sub(start).Round(time.Millisecond), samples),
+					Confidence: child.Confidence,
+					Message:    fmt.Sprintf("Condition satisfied within %s", formatDuration(a.Within)),
+				}, OpEventually, child, obs), nil
+			}
+			if child.Inconclusive {
+				inconclusive++
+				lastInconclusive, lastInconclusiveObs = child, obs
+			}
+			last, lastObs = child, obs
+		}
+
+		if !clk.Now().Before(deadline) {
+			break
+		}
+		pace(clk, a.Every, sampleStart, deadline)
+	}
+
+	if samples == 0 {
+		return AssertionResult{}, fmt.Errorf("no observation succeeded within %s: %w", formatDuration(a.Within), lastErr)
+	}
+
+	if inconclusive > 0 {
+		return decisiveResult(AssertionResult{
+			Inconclusive: true,
+			Expected:     a.String(),
+			Actual:       fmt.Sprintf("%d of %d samples inconclusive over %s", inconclusive, samples, formatDuration(a.Within)),
+			Confidence:   lastInconclusive.Confidence,
+			Message:      fmt.Sprintf("Never held confidently; last inconclusive sample: %s", lastInconclusive.Message),
+		}, OpEventually, lastInconclusive, lastInconclusiveObs), nil
+	}
+
+	return decisiveResult(AssertionResult{
+		Passed:     false,
+		Expected:   a.String(),
+		Actual:     fmt.Sprintf("never held in %d samples over %s", samples, formatDuration(a.Within)),
+		Confidence: last.Confidence,
+		Message:    fmt.Sprintf("Timed out after %s; last sample: %s", formatDuration(a.Within), last.Actual),
+	}, OpEventually, last, lastObs), nil
+}
+
+// AlwaysAssertion passes only if every observation within the window satisfies the operand.
+//
+// Syntax: ALWAYS <duration> [EVERY <duration>] <expr>
+type AlwaysAssertion struct {
+	Operand Assertion
+	For     time.Duration
+	Every   time.Duration // Minimum spacing between samples (0 = back-to-back)
+	clock   clock
+}
+
+// Evaluate checks the operand against a single snapshot
+func (a *AlwaysAssertion) Evaluate(obs *core.Observation) AssertionResult {
+	return snapshotResult(a.String(), OpAlways, a.Operand.Evaluate(obs))
+}
+
+func (a *AlwaysAssertion) String() string {
+	return temporalString("ALWAYS", a.For, a.Every, a.Operand)
+}
+
+// EvaluateOver samples for the whole window and fails on the first violating sample.
+// Failed observations are treated as gaps; at least one sample must succeed.
+// Inconclusive samples do not break the invariant but make a passing window inconclusive.
+func (a *AlwaysAssertion) EvaluateOver(observe Observer) (AssertionResult, error) {
+	clk := clockOrDefault(a.clock)
+	start := clk.Now()
+	deadline := start.Add(a.For)
+
+	samples, inconclusive := 0, 0
+	minConf := 1.0
+	var last, lastInconclusive AssertionResult
+	var lastObs, lastInconclusiveObs *core.Observation
+	var lastErr error
+
+	for {
+		sampleStart := clk.Now()
+		obs, err := observe()
+		if err != nil {
+			lastErr = err
+		} else {
+			samples++
+			child := a.Operand.Evaluate(obs)
+			if child.Outcome() == OutcomeFail {
+				return decisiveResult(AssertionResult{
+					Passed:     false,
+					Expected:   a.String(),
+					Actual:     fmt.Sprintf("broke after %s (sample %d): %s", clk.Now().Sub(start).Round(time.Millisecond), samples, child.Actual),
+					Confidence: child.Confidence,
+					Message:    fmt.Sprintf("Invariant violated: %s", child.Message),
+				}, OpAlways, child, obs), nil
+			}
+			if child.Inconclusive {
+				inconclusive++
+				lastInconclusive, lastInconclusiveObs = child, obs
+			}
+			if child.Confidence < minConf {
+				minConf = child.Confidence
+			}
+			last, lastObs = child, obs
+		}
+
+		if !clk.Now().Before(deadline) {
+			break
+		}
+		pace(clk, a.Every, sampleStart, deadline)
+	}
+
+	if samples == 0 {
+		return AssertionResult{}, fmt.Errorf("no observation succeeded within %s: %w", formatDuration(a.For), lastErr)
+	}
+
+	if inconclusive > 0 {
+		return decisiveResult(AssertionResult{
+			Inconclusive: true,
+			Expected:     a.String(),
+			Actual:       fmt.Sprintf("%d of %d samples inconclusive over %s", inconclusive, samples, formatDuration(a.For)),
+			Confidence:   minConf,
+			Message:      fmt.Sprintf("No violation seen, but not every sample was confident: %s", lastInconclusive.Message),
+		}, OpAlways, lastInconclusive, lastInconclusiveObs), nil
+	}
+
+	return decisiveResult(AssertionResult{
+		Passed:     true,
+		Expected:   a.String(),
+		Actual:     fmt.Sprintf("held for %s (%d samples)", formatDuration(a.For), samples),
+		Confidence: minConf,
+		Message:    fmt.Sprintf("Condition held in all %d samples", samples),
+	}, OpAlways, last, lastObs), nil
+}
+
+// Run evaluates an assertion, driving any temporal operators through observe.
+// Non-temporal clauses share a single observation captured on first use.
+func Run(a Assertion, observe Observer) (AssertionResult, error) {
+	return RunWithSnapshot(a, nil, observe)
+}
+
+// RunWithSnapshot is like Run but evaluates non-temporal clauses against an
+// already captured observation when one is given
+func RunWithSnapshot(a Assertion, snapshot *core.Observation, observe Observer) (AssertionResult, error) {
+	r := &runner{observe: observe, snapshot: snapshot}
+	return r.eval(a)
+}
+
+// EvaluateSnapshot evaluates the assertion against a single existing observation
+// without capturing new ones (e.g. one loaded from storage). Temporal operators
+// are checked against that snapshot only.
+func EvaluateSnapshot(a Assertion, obs *core.Observation) AssertionResult {
+	result := a.Evaluate(obs)
+	stampObservation(&result, obs)
+	return result
+}
+
+// runner evaluates assertion trees, sharing one snapshot among non-temporal clauses
+type runner struct {
+	observe  Observer
+	snapshot *core.Observation
+}
+
+func (r *runner) snap() (*core.Observation, error) {
+	if r.snapshot == nil {
+		obs, err := r.observe()
+		if err != nil {
+			return nil, err
+		}
+		r.snapshot = obs
+	}
+	return r.snapshot, nil
+}
+
+func (r *runner) eval(a Assertion) (AssertionResult, error) {
+	if !IsTemporal(a) {
+		obs, err := r.snap()
+		if err != nil {
+			return AssertionResult{}, err
+		}
+		return EvaluateSnapshot(a, obs), nil
+	}
+
+	switch t := a.(type) {
+	case TemporalAssertion:
+		return t.EvaluateOver(r.observe)
+
+	case *ConfidenceAssertion:
+		result, err := r.eval(WithMinConfidence(t.Operand, t.Min))
+		if err != nil {
+			return AssertionResult{}, err
+		}
+		result.Expected = t.String()
+		return result, nil
+
+	case *NotAssertion:
+		child, err := r.eval(t.Operand)
+		if err != nil {
+			return AssertionResult{}, err
+		}
+		return combineNot(t.String(), child), nil
+
+	case *AndAssertion, *OrAssertion:
+		ops := operandsOf(t)
+		children := make([]AssertionResult, 0, len(ops))
+		for _, op := range ops {
+			child, err := r.eval(op)
+			if err != nil {
+				return AssertionResult{}, err
+			}
+			children = append(children, child)
+		}
+		if _, ok := t.(*AndAssertion); ok {
+			return combineAnd(t.String(), children), nil
+		}
+		return combineOr(t.String(), children), nil
+	}
+
+	return AssertionResult{}, fmt.Errorf("cannot evaluate %T over time", a)
+}
+
+// IsTemporal reports whether the assertion tree contains a temporal operator
+func IsTemporal(a Assertion) bool {
+	temporal := false
+	Walk(a, func(node Assertion) bool {
+		if _, ok := node.(TemporalAssertion); ok {
+			temporal = true
+		}
+		return !temporal
+	})
+	return temporal
+}
+
+// snapshotResult wraps a single-observation evaluation of a temporal operand
+func snapshotResult(expr string, op Op, child AssertionResult) AssertionResult {
+	return AssertionResult{
+		Passed:       child.Passed,
+		Inconclusive: child.Inconclusive,
+		Expected:     expr,
+		Actual:       child.Actual,
+		Confidence:   child.Confidence,
+		Message:      fmt.Sprintf("Evaluated on a single snapshot: %s", child.Message),
+		Op:           op,
+		Children:     []AssertionResult{child},
+	}
+}
+
+// decisiveResult attaches the deciding sample to a temporal result
+func decisiveResult(result AssertionResult, op Op, child AssertionResult, obs *core.Observation) AssertionResult {
+	stampObservation(&child, obs)
+	result.Op = op
+	result.Children = []AssertionResult{child}
+	result.ObservationID = obs.ID
+	result.ObservedAt = obs.Timestamp
+	return result
+}
+
+// stampObservation records which observation a result was evaluated against
+func stampObservation(result *AssertionResult, obs *core.Observation) {
+	if result.ObservationID == "" {
+		result.ObservationID = obs.ID
+		result.ObservedAt = obs.Timestamp
+	}
+}
+
+// pace sleeps so consecutive samples start at least every apart, without passing the deadline
+func pace(clk clock, every time.Duration, sampleStart, deadline time.Time) {
+	if every <= 0 {
+		return
+	}
+	now := clk.Now()
+	wait := every - now.Sub(sampleStart)
+	if remaining := deadline.Sub(now); wait > remaining {
+		wait = remaining
+	}
+	if wait > 0 {
+		clk.Sleep(wait)
+	}
+}
+
+func clockOrDefault(c clock) clock {
+	if c == nil {
+		return realClock{}
+	}
+	return c
+}
+
+func temporalString(keyword string, window, every time.Duration, operand Assertion) string {
+	s := fmt.Sprintf("%s %s ", keyword, formatDuration(window))
+	if every > 0 {
+		s += fmt.Sprintf("EVERY %s ", formatDuration(every))
+	}
+	return s + wrapOperand(operand, precedenceNot)
+}
+
+// formatDuration renders durations in the DSL's own units (500ms, 10s, 2m)
+func formatDuration(d time.Duration) string {
+	switch {
+	case d >= time.Minute && d%time.Minute == 0:
+		return fmt.Sprintf("%dm", d/time.Minute)
+	case d >= time.Second && d%time.Second == 0:
+		return fmt.Sprintf("%ds", d/time.Second)
+	}
+	return fmt.Sprintf("%dms", d.Milliseconds())
+}
@@ -0,0 +1,282 @@
+package assertions
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/perceptumx/percepta/internal/core"
+)
+
+// fakeClock advances only when slept on or stepped by the observer
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time        { return c.now }
+func (c *fakeClock) Sleep(d time.Duration) { c.now = c.now.Add(d) }
+
+// sequenceObserver returns the given LED states in order, repeating the last one.
+// Each observation takes one second of fake time.
+func sequenceObserver(clk *fakeClock, states ...bool) (Observer, *int) {
+	calls := 0
+	return func() (*core.Observation, error) {
+		state := states[len(states)-1]
+		if calls < len(states) {
+			state = states[calls]
+		}
+		calls++
+		clk.Sleep(time.Second)
+		return &core.Observation{
+			ID:        fmt.Sprintf("obs-%d", calls),
+			Timestamp: clk.now,
+			Signals:   []core.Signal{core.LEDSignal{Name: "wifi", On: state, Confidence: 0.9}},
+		}, nil
+	}, &calls
+}
+
+func TestParse_Temporal(t *testing.T) {
+	tests := []struct {
+		dsl    string
+		expect string
+	}{
+		{"EVENTUALLY 10s EVERY 2s LED.wifi ON", "EVENTUALLY 10s EVERY 2s LED.wifi ON"},
+		{"ALWAYS 30s LED.error OFF", "ALWAYS 30s LED.error OFF"},
+		{"EVENTUALLY 500ms led('wifi').is_on()", "EVENTUALLY 500ms LED.wifi ON"},
+		{"ALWAYS 2m (LED.a ON || LED.b ON)", "ALWAYS 2m (LED.a ON || LED.b ON)"},
+		{"LED.power ON && EVENTUALLY 10s LED.wifi ON", "LED.power ON && EVENTUALLY 10s LED.wifi ON"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.dsl, func(t *testing.T) {
+			assertion, err := Parse(tt.dsl)
+			if err != nil {
+				t.Fatalf("Parse failed: %v", err)
+			}
+			if assertion.String() != tt.expect {
+				t.Errorf("Expected %q, got %q", tt.expect, assertion.String())
+			}
+			if !IsTemporal(assertion) {
+				t.Error("Expected IsTemporal to be true")
+			}
+		})
+	}
+}
+
+func TestParse_TemporalFields(t *testing.T) {
+	assertion, err := Parse("EVENTUALLY 10s EVERY 2s LED.wifi ON")
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	ev, ok := assertion.(*EventuallyAssertion)
+	if !ok {
+		t.Fatalf("Expected *EventuallyAssertion, got %T", assertion)
+	}
+	if ev.Within != 10*time.Second || ev.Every != 2*time.Second {
+		t.Errorf("Expected 10s window every 2s, got %v every %v", ev.Within, ev.Every)
+	}
+	if _, ok := ev.Operand.(*LEDAssertion); !ok {
+		t.Errorf("Expected *LEDAssertion operand, got %T", ev.Operand)
+	}
+}
+
+func TestParse_TemporalErrors(t *testing.T) {
+	tests := []struct {
+		dsl     string
+		wantErr string
+	}{
+		{"EVENTUALLY LED.wifi ON", "expected duration"},
+		{"EVENTUALLY 10 LED.wifi ON", "needs a unit"},
+		{"EVENTUALLY 10 s LED.wifi ON", "needs a unit"},
+		{"ALWAYS 10h LED.wifi ON", "unknown duration unit"},
+		{"ALWAYS 0s LED.wifi ON", "must be positive"},
+		{"EVENTUALLY 10s", "unexpected end of expression"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.dsl, func(t *testing.T) {
+			_, err := Parse(tt.dsl)
+			if err == nil {
+				t.Fatalf("Expected error containing %q", tt.wantErr)
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Expected error containing %q, got %q", tt.wantErr, err.Error())
+			}
+		})
+	}
+}
+
+func TestEventually_PassesOnFirstMatch(t *testing.T) {
+	clk := &fakeClock{now: time.Unix(0, 0)}
+	observe, calls := sequenceObserver(clk, false, false, true)
+	on := true
+	assertion := &EventuallyAssertion{
+		Operand: &LEDAssertion{Name: "wifi", Expected: LEDState{On: &on}},
+		Within:  10 * time.Second,
+		clock:   clk,
+	}
+
+	result, err := assertion.EvaluateOver(observe)
+	if err != nil {
+		t.Fatalf("EvaluateOver failed: %v", err)
+	}
+	if !result.Passed {
+		t.Fatalf("Expected pass, got: %s", result.Message)
+	}
+	if *calls != 3 {
+		t.Errorf("Expected to stop after 3 samples, got %d", *calls)
+	}
+	if result.ObservationID != "obs-3" {
+		t.Errorf("Expected decisive observation obs-3, got %q", result.ObservationID)
+	}
+	if result.Op != OpEventually || len(result.Children) != 1 {
+		t.Errorf("Expected eventually-result with one child, got op=%q children=%d", result.Op, len(result.Children))
+	}
+}
+
+func TestEventually_TimesOut(t *testing.T) {
+	clk := &fakeClock{now: time.Unix(0, 0)}
+	observe, calls := sequenceObserver(clk, false)
+	on := true
+	assertion := &EventuallyAssertion{
+		Operand: &LEDAssertion{Name: "wifi", Expected: LEDState{On: &on}},
+		Within:  10 * time.Second,
+		Every:   2 * time.Second,
+		clock:   clk,
+	}
+
+	result, err := assertion.EvaluateOver(observe)
+	if err != nil {
+		t.Fatalf("EvaluateOver failed: %v", err)
+	}
+	if result.Passed {
+		t.Fatal("Expected timeout failure")
+	}
+	// Samples start every 2s: t=0, 2, 4, 6, 8 and a final one as the window closes at 10s
+	if *calls != 6 {
+		t.Errorf("Expected 6 samples paced 2s apart, got %d", *calls)
+	}
+	if result.ObservationID != "obs-6" {
+		t.Errorf("Expected last sample to be reported, got %q", result.ObservationID)
+	}
+	if !strings.Contains(result.Message, "Timed out") {
+		t.Errorf("Expected timeout message, got %q", result.Message)
+	}
+}
+
+func TestAlways_Holds(t *testing.T) {
+	clk := &fakeClock{now: time.Unix(0, 0)}
+	observe, calls := sequenceObserver(clk, true)
+	on := true
+	assertion := &AlwaysAssertion{
+		Operand: &LEDAssertion{Name: "wifi", Expected: LEDState{On: &on}},
+		For:     5 * time.Second,
+		clock:   clk,
+	}
+
+	result, err := assertion.EvaluateOver(observe)
+	if err != nil {
+		t.Fatalf("EvaluateOver failed: %v", err)
+	}
+	if !result.Passed {
+		t.Fatalf("Expected pass, got: %s", result.Message)
+	}
+	if *calls != 5 {
+		t.Errorf("Expected 5 back-to-back samples over 5s, got %d", *calls)
+	}
+}
+
+func TestAlways_FailsOnViolation(t *testing.T) {
+	clk := &fakeClock{now: time.Unix(0, 0)}
+	observe, calls := sequenceObserver(clk, true, true, false, true)
+	on := true
+	assertion := &AlwaysAssertion{
+		Operand: &LEDAssertion{Name: "wifi", Expected: LEDState{On: &on}},
+		For:     30 * time.Second,
+		clock:   clk,
+	}
+
+	result, err := assertion.EvaluateOver(observe)
+	if err != nil {
+		t.Fatalf("EvaluateOver failed: %v", err)
+	}
+	if result.Passed {
+		t.Fatal("Expected violation to fail the invariant")
+	}
+	if *calls != 3 {
+		t.Errorf("Expected to stop at the violating sample, got %d samples", *calls)
+	}
+	if result.ObservationID != "obs-3" {
+		t.Errorf("Expected violating observation obs-3, got %q", result.ObservationID)
+	}
+}
+
+func TestTemporal_ObserverErrors(t *testing.T) {
+	clk := &fakeClock{now: time.Unix(0, 0)}
+	observe := func() (*core.Observation, error) {
+		clk.Sleep(time.Second)
+		return nil, errors.New("camera unplugged")
+	}
+	on := true
+	assertion := &AlwaysAssertion{
+		Operand: &LEDAssertion{Name: "wifi", Expected: LEDState{On: &on}},
+		For:     3 * time.Second,
+		clock:   clk,
+	}
+
+	_, err := assertion.EvaluateOver(observe)
+	if err == nil || !strings.Contains(err.Error(), "camera unplugged") {
+		t.Errorf("Expected observer error to surface, got %v", err)
+	}
+}
+
+func TestRun_SharesSnapshotForNonTemporalClauses(t *testing.T) {
+	clk := &fakeClock{now: time.Unix(0, 0)}
+	observe, calls := sequenceObserver(clk, false, true)
+
+	assertion, err := Parse("!LED.wifi ON && EVENTUALLY 10s LED.wifi ON")
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	assertion.(*AndAssertion).Operands[1].(*EventuallyAssertion).clock = clk
+
+	result, err := Run(assertion, observe)
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if !result.Passed {
+		t.Fatalf("Expected pass, got: %s", result.Message)
+	}
+	if result.Children[0].ObservationID != "obs-1" {
+		t.Errorf("Expected snapshot clause on obs-1, got %q", result.Children[0].ObservationID)
+	}
+	if result.Children[1].ObservationID != "obs-2" {
+		t.Errorf("Expected temporal clause decided by obs-2, got %q", result.Children[1].ObservationID)
+	}
+	if *calls != 2 {
+		t.Errorf("Expected 2 observations, got %d", *calls)
+	}
+}
+
+func TestRunWithSnapshot_NonTemporal(t *testing.T) {
+	assertion, err := Parse("LED.LED1 ON")
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	observe := func() (*core.Observation, error) {
+		t.Fatal("Expected no additional observation")
+		return nil, nil
+	}
+
+	obs := observation("test-1", core.LEDSignal{Name: "LED1", On: true, BlinkHz: 2.0, Color: core.RGB{B: 255}, Confidence: 0.9})
+	result, err := RunWithSnapshot(assertion, obs, observe)
+	if err != nil {
+		t.Fatalf("RunWithSnapshot failed: %v", err)
+	}
+	if !result.Passed || result.ObservationID != "test-1" {
+		t.Errorf("Expected pass on snapshot test-1, got passed=%v id=%q", result.Passed, result.ObservationID)
+	}
+}
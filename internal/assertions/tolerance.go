@@ -0,0 +1,83 @@
+package assertions
+
+import "github.com/perceptumx/percepta/internal/tolerance"
+
+// WithTolerance returns a copy of the assertion tree whose LED and display
+// checks compare blink rates, colors, brightness and text (including vision
+// answers) using the given profile
+// (typically the device's), instead of tolerance.Default().
+func WithTolerance(a Assertion, profile tolerance.Profile) Assertion {
+	switch t := a.(type) {
+	case *LEDAssertion:
+		c := *t
+		c.profile = &profile
+		return &c
+	case *DisplayAssertion:
+		c := *t
+		c.profile = &profile
+		return &c
+	case *DisplayChangedAssertion:
+		c := *t
+		c.profile = &profile
+		return &c
+	case *LEDRelationAssertion:
+		c := *t
+		c.profile = &profile
+		return &c
+	case *LEDLevelAssertion:
+		c := *t
+		c.profile = &profile
+		return &c
+	case *VisionAssertion:
+		c := *t
+		c.profile = &profile
+		return &c
+	case *GoldenAssertion:
+		c := *t
+		c.profile = &profile
+		return &c
+	case *thresholdAssertion:
+		return &thresholdAssertion{Assertion: WithTolerance(t.Assertion, profile), min: t.min}
+	case *AndAssertion:
+		return &AndAssertion{Operands: withToleranceAll(t.Operands, profile)}
+	case *OrAssertion:
+		return &OrAssertion{Operands: withToleranceAll(t.Operands, profile)}
+	case *NotAssertion:
+		return &NotAssertion{Operand: WithTolerance(t.Operand, profile)}
+	case *ConfidenceAssertion:
+		return &ConfidenceAssertion{Operand: WithTolerance(t.Operand, profile), Min: t.Min}
+	case *EventuallyAssertion:
+		c := *t
+		c.Operand = WithTolerance(t.Operand, profile)
+		return &c
+	case *AlwaysAssertion:
+		c := *t
+		c.Operand = WithTolerance(t.Operand, profile)
+		return &c
+	case *SequenceAssertion:
+		c := *t
+		c.Steps = make([]SequenceStep, len(t.Steps))
+		for i, step := range t.Steps {
+			step.State = WithTolerance(step.State, profile)
+			c.Steps[i] = step
+		}
+		return &c
+	}
+	return a
+}
+
+func withToleranceAll(operands []Assertion, profile tolerance.Profile) []Assertion {
+	out := make([]Assertion, 0, len(operands))
+	for _, op := range operands {
+		out = append(out, WithTolerance(op, profile))
+	}
+	return out
+}
+
+// profileOrDefault resolves a leaf's tolerance profile
+func profileOrDefault(p *tolerance.Profile) tolerance.Profile {
+	if p == nil {
+		return tolerance.Default()
+	}
+	return *p
+}
@@ -0,0 +1,196 @@
+package assertions
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/perceptumx/percepta/internal/core"
+	"github.com/perceptumx/percepta/internal/tolerance"
+)
+
+func TestWithTolerance(t *testing.T) {
+	blink, fuzziness := 20.0, 0.2
+	noisy := tolerance.Merge(tolerance.Overrides{BlinkPercent: &blink, ColorMetric: tolerance.MetricHue, OCRFuzziness: &fuzziness})
+
+	// A blue LED and an LCD as seen through a cheap webcam: shifted white
+	// balance, slightly fast blink and one misread character
+	obs := observation("webcam",
+		core.LEDSignal{Name: "status", On: true, Color: core.RGB{R: 40, G: 60, B: 230}, BlinkHz: 2.3, Confidence: 0.9},
+		core.DisplaySignal{Name: "LCD", Text: "Temp 23C REA0Y", Confidence: 0.9},
+	)
+
+	tests := []string{
+		"led('status').color_rgb(0,0,255)",
+		"LED.status BLINK 2Hz",
+		`Display.LCD "READY"`,
+		"CONFIDENCE >= 0.5 (LED.status BLINK 2Hz && !LED.status OFF)",
+		"EVENTUALLY 5s LED.status BLINK 2Hz",
+	}
+
+	for _, dsl := range tests {
+		t.Run(dsl, func(t *testing.T) {
+			assertion, err := Parse(dsl)
+			if err != nil {
+				t.Fatalf("Parse failed: %v", err)
+			}
+
+			if result := assertion.Evaluate(obs); result.Passed {
+				t.Errorf("Expected default tolerances to fail, got pass: %s", result.Actual)
+			}
+
+			tolerant := WithTolerance(assertion, noisy)
+			if result := tolerant.Evaluate(obs); !result.Passed {
+				t.Errorf("Expected device tolerances to pass, got %s: %s", result.Outcome(), result.Message)
+			}
+			if tolerant.String() != assertion.String() {
+				t.Errorf("Expected tolerances to be invisible in String(), got %q", tolerant.String())
+			}
+		})
+	}
+}
+
+func TestWithTolerance_ExplainsColorDistance(t *testing.T) {
+	assertion, _ := Parse("led('status').color_rgb(0,0,255)")
+	threshold := 2.0
+	obs := observation("webcam", core.LEDSignal{Name: "status", On: true, Color: core.RGB{R: 40, G: 60, B: 230}, BlinkHz: 2.3, Confidence: 0.9})
+	result := WithTolerance(assertion, tolerance.Merge(tolerance.Overrides{ColorMetric: tolerance.MetricCIEDE2000, ColorThreshold: &threshold})).Evaluate(obs)
+
+	if result.Passed || !strings.Contains(result.Message, "ciede2000 distance") {
+		t.Errorf("Expected failure naming the ciede2000 distance, got %q", result.Message)
+	}
+}
+
+func TestSuite_Tolerance(t *testing.T) {
+	suite := &Suite{Cases: []SuiteCase{{Name: "status", Assertions: []string{"LED.status BLINK 2Hz"}}}}
+	if err := suite.Compile(); err != nil {
+		t.Fatalf("Compile failed: %v", err)
+	}
+
+	obs := observation("webcam", core.LEDSignal{Name: "status", On: true, Color: core.RGB{R: 40, G: 60, B: 230}, BlinkHz: 2.3, Confidence: 0.9})
+	if suite.RunStored("dev", obs).Passed() {
+		t.Error("Expected suite to fail with default tolerances")
+	}
+
+	suite.Tolerance = &tolerance.Profile{BlinkPercent: 20}
+	if result := suite.RunStored("dev", obs); !result.Passed() {
+		t.Errorf("Expected suite to pass with the device's tolerance: %+v", result)
+	}
+}
+
+func TestDisplayAssertion_Similar(t *testing.T) {
+	obs := &core.Observation{
+		ID: "ocr",
+		Signals: []core.Signal{
+			core.DisplaySignal{Name: "LCD", Text: "Status: Readv", Confidence: 0.9},
+		},
+	}
+
+	strict, _ := Parse(`Display.LCD "Ready"`)
+	result := strict.Evaluate(obs)
+	if result.Passed {
+		t.Fatal("Expected the default profile to reject a misread character")
+	}
+	if !strings.Contains(result.Message, `closest "Readv", similarity 0.80 < 1.00`) {
+		t.Errorf("Expected closest match and similarity in message, got %q", result.Message)
+	}
+	if result.Text == nil || result.Text.Closest != "Readv" {
+		t.Errorf("Expected structured text match, got %+v", result.Text)
+	}
+
+	similar, err := Parse(`Display.LCD "Ready" SIMILAR 0.8`)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	result = similar.Evaluate(obs)
+	if !result.Passed {
+		t.Fatalf("Expected SIMILAR 0.8 to pass, got %s", result.Message)
+	}
+	if !strings.Contains(result.Actual, `"Readv" (similarity 0.80 to "Ready")`) {
+		t.Errorf("Expected similarity in Actual, got %q", result.Actual)
+	}
+
+	// Fuzzy profiles fold confusable glyphs before counting edits
+	obs.Signals[0] = core.DisplaySignal{Name: "LCD", Text: "B00T 0K", Confidence: 0.9}
+	boot, _ := Parse(`Display.LCD "BOOT OK"`)
+	if result := WithTolerance(boot, tolerance.Profile{OCRFuzziness: 0.1}).Evaluate(obs); !result.Passed {
+		t.Errorf("Expected 0/O to be folded by a fuzzy profile, got %s", result.Message)
+	}
+	exact, _ := Parse(`display('LCD').shows('BOOT OK', 1)`)
+	if result := WithTolerance(exact, tolerance.Profile{OCRFuzziness: 0.1}).Evaluate(obs); result.Passed {
+		t.Error("Expected similarity 1 to override the profile and stay exact")
+	}
+}
+
+func TestDisplayChangedAssertion_Similar(t *testing.T) {
+	obs := &core.Observation{
+		ID: "ocr",
+		Signals: []core.Signal{
+			core.DisplaySignal{
+				Name:       "LCD",
+				Text:       "Readv",
+				Confidence: 0.9,
+				Changed:    true,
+				History: []core.DisplayTextEntry{
+					{OffsetMs: 0, Text: "B0oting", Confidence: 0.9},
+					{OffsetMs: 500, Text: "Readv", Confidence: 0.9},
+				},
+			},
+		},
+	}
+
+	strict, _ := Parse(`Display.LCD CHANGED "Booting" -> "Ready"`)
+	result := strict.Evaluate(obs)
+	if result.Passed || !strings.Contains(result.Message, `closest "B0oting"`) {
+		t.Errorf("Expected failure naming the closest reading, got %q", result.Message)
+	}
+
+	similar, err := Parse(`Display.LCD CHANGED "Booting" -> "Ready" SIMILAR 0.8`)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	result = similar.Evaluate(obs)
+	if !result.Passed {
+		t.Fatalf("Expected SIMILAR 0.8 to pass, got %s", result.Message)
+	}
+	if result.Text == nil || result.Text.Closest != "Readv" || result.Text.Similarity != 0.8 {
+		t.Errorf("Expected the weaker match to be reported, got %+v", result.Text)
+	}
+	if !strings.Contains(result.Actual, `from "B0oting" to "Readv"`) {
+		t.Errorf("Expected matched readings in Actual, got %q", result.Actual)
+	}
+}
+
+func TestParse_Similar(t *testing.T) {
+	for _, dsl := range []string{
+		`Display.LCD "Ready" SIMILAR 0.8`,
+		`Display.LCD CHANGED "Boot" -> "Ready" SIMILAR 0.75`,
+	} {
+		assertion, err := Parse(dsl)
+		if err != nil {
+			t.Fatalf("Parse(%q) failed: %v", dsl, err)
+		}
+		if assertion.String() != dsl {
+			t.Errorf("Expected String() %q, got %q", dsl, assertion.String())
+		}
+	}
+
+	predicate, err := Parse(`display('LCD').changed('Boot', 'Ready', 0.9)`)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if got := predicate.String(); got != `Display.LCD CHANGED "Boot" -> "Ready" SIMILAR 0.9` {
+		t.Errorf("Unexpected String(): %q", got)
+	}
+
+	for _, dsl := range []string{
+		`Display.LCD "Ready" SIMILAR 0`,
+		`Display.LCD "Ready" SIMILAR 1.5`,
+		`Display.LCD "Ready" SIMILAR high`,
+		`display('LCD').shows('Ready', 2)`,
+		`display('LCD').shows('Ready', 'x')`,
+	} {
+		if _, err := Parse(dsl); err == nil {
+			t.Errorf("Expected Parse(%q) to fail", dsl)
+		}
+	}
+}
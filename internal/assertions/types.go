@@ -2,18 +2,74 @@ package assertions
 
 import (
 	"fmt"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/perceptumx/percepta/internal/core"
+	"github.com/perceptumx/percepta/internal/tolerance"
+)
+
+// Op identifies the boolean operator that produced a composite result
+type Op string
+
+const (
+	OpAnd        Op = "and"
+	OpOr         Op = "or"
+	OpNot        Op = "not"
+	OpEventually Op = "eventually"
+	OpAlways     Op = "always"
+	OpSequence   Op = "sequence"
+)
+
+// Outcome is the tri-state verdict of an assertion
+type Outcome string
+
+const (
+	OutcomePass         Outcome = "PASS"
+	OutcomeFail         Outcome = "FAIL"
+	OutcomeInconclusive Outcome = "INCONCLUSIVE"
 )
 
 // AssertionResult represents the outcome of an assertion evaluation
 type AssertionResult struct {
-	Passed     bool
-	Expected   string
-	Actual     string
-	Confidence float64
-	Message    string
+	Passed       bool
+	Inconclusive bool // Evidence too weak to decide; Passed is false
+	Expected     string
+	Actual       string
+	Confidence   float64
+	Message      string
+	Op           Op                   // Empty for leaf assertions
+	Text         *tolerance.TextMatch // Closest text match, for display text assertions
+	Children     []AssertionResult    // Per-clause results for composite assertions
+
+	// Observation the result was decided on (the decisive sample for temporal operators)
+	ObservationID string
+	ObservedAt    time.Time
+
+	// Duration is the wall-clock time spent deciding the result, including
+	// observations; set by RunConclusive (zero for snapshot evaluations)
+	Duration time.Duration
+
+	// SignalMissing marks leaf results where no matching signal was observed.
+	// Confidence thresholds do not apply to them: a missing signal is a failure.
+	SignalMissing bool
+
+	// Attempts are the individual results of a quorum run, in order; the
+	// result itself is the last attempt that agreed with the vote
+	Attempts []AssertionResult
+	Quorum   Quorum // Zero unless the result was decided by vote
+}
+
+// Outcome reports whether the result passed, failed or was inconclusive
+func (r AssertionResult) Outcome() Outcome {
+	switch {
+	case r.Passed:
+		return OutcomePass
+	case r.Inconclusive:
+		return OutcomeInconclusive
+	}
+	return OutcomeFail
 }
 
 // Assertion interface - evaluates observed state
@@ -26,49 +82,32 @@ type Assertion interface {
 type LEDAssertion struct {
 	Name     string
 	Expected LEDState
+	profile  *tolerance.Profile // nil = tolerance.Default(); set via WithTolerance
 }
 
 type LEDState struct {
-	On      *bool // nil = don't care
-	Color   *core.RGB
-	BlinkHz *float64
+	On        *bool // nil = don't care
+	Color     *core.RGB
+	ColorName string   // Named color matched by hue range, e.g. "amber" ("" = don't care)
+	MaxDeltaE *float64 // Perceptual (CIEDE2000) limit for Color/ColorName instead of the profile's metric
+	BlinkHz   *float64
+	Blinking  *bool   // Blinking at any non-zero rate
+	Code      *string // Blink code decoded from a high-rate capture, e.g. "3-2"
 }
 
 func (a *LEDAssertion) Evaluate(obs *core.Observation) AssertionResult {
-	// Find matching LED signal (case-insensitive)
-	var matchedSignal *core.LEDSignal
-	lowerName := strings.ToLower(a.Name)
-
-	for _, sig := range obs.Signals {
-		if ledSig, ok := sig.(*core.LEDSignal); ok {
-			if strings.ToLower(ledSig.Name) == lowerName {
-				matchedSignal = ledSig
-				break
-			}
-		}
-	}
-
-	// Fallback: if no match and exactly one LED exists, use it
-	if matchedSignal == nil {
-		var ledSignals []*core.LEDSignal
-		for _, sig := range obs.Signals {
-			if ledSig, ok := sig.(*core.LEDSignal); ok {
-				ledSignals = append(ledSignals, ledSig)
-			}
-		}
-		if len(ledSignals) == 1 {
-			matchedSignal = ledSignals[0]
-		}
-	}
+	matchedSignal := findLED(obs, a.Name)
+	profile := profileOrDefault(a.profile)
 
 	// If still no match, fail
 	if matchedSignal == nil {
 		return AssertionResult{
-			Passed:     false,
-			Expected:   a.String(),
-			Actual:     "LED not found in observation",
-			Confidence: 0.0,
-			Message:    fmt.Sprintf("LED '%s' not found in observation", a.Name),
+			Passed:        false,
+			Expected:      a.String(),
+			Actual:        "LED not found in observation",
+			Confidence:    0.0,
+			Message:       fmt.Sprintf("LED '%s' not found in observation", a.Name),
+			SignalMissing: true,
 		}
 	}
 
@@ -86,9 +125,8 @@ func (a *LEDAssertion) Evaluate(obs *core.Observation) AssertionResult {
 	}
 
 	// Check color if specified
-	if a.Expected.Color != nil {
-		// Check if color is zero value (unset)
-		if matchedSignal.Color.R == 0 && matchedSignal.Color.G == 0 && matchedSignal.Color.B == 0 {
+	if a.Expected.Color != nil || a.Expected.ColorName != "" {
+		if !matchedSignal.HasColor() {
 			return AssertionResult{
 				Passed:     false,
 				Expected:   a.String(),
@@ -97,13 +135,27 @@ func (a *LEDAssertion) Evaluate(obs *core.Observation) AssertionResult {
 				Message:    "Expected color, but LED has no color information",
 			}
 		}
-		if !colorsMatch(*a.Expected.Color, matchedSignal.Color) {
+		if message, ok := a.checkColor(matchedSignal, profile); !ok {
 			return AssertionResult{
 				Passed:     false,
 				Expected:   a.String(),
-				Actual:     fmt.Sprintf("LED '%s' is RGB(%d,%d,%d)", matchedSignal.Name, matchedSignal.Color.R, matchedSignal.Color.G, matchedSignal.Color.B),
+				Actual:     fmt.Sprintf("LED '%s' is %s", matchedSignal.Name, describeLEDColor(matchedSignal)),
 				Confidence: matchedSignal.Confidence,
-				Message:    fmt.Sprintf("Expected RGB(%d,%d,%d), got RGB(%d,%d,%d)", a.Expected.Color.R, a.Expected.Color.G, a.Expected.Color.B, matchedSignal.Color.R, matchedSignal.Color.G, matchedSignal.Color.B),
+				Message:    message,
+			}
+		}
+	}
+
+	// Check blinking (any rate) if specified
+	if a.Expected.Blinking != nil {
+		blinking := matchedSignal.BlinkHz > 0
+		if blinking != *a.Expected.Blinking {
+			return AssertionResult{
+				Passed:     false,
+				Expected:   a.String(),
+				Actual:     fmt.Sprintf("LED '%s' is %s", matchedSignal.Name, blinkingString(blinking)),
+				Confidence: matchedSignal.Confidence,
+				Message:    fmt.Sprintf("Expected LED to be %s, but it is %s", blinkingString(*a.Expected.Blinking), blinkingString(blinking)),
 			}
 		}
 	}
@@ -119,17 +171,43 @@ func (a *LEDAssertion) Evaluate(obs *core.Observation) AssertionResult {
 				Message:    fmt.Sprintf("Expected blink rate %.2f Hz, but LED is not blinking", *a.Expected.BlinkHz),
 			}
 		}
-		// Allow 10% tolerance on blink rate
-		tolerance := *a.Expected.BlinkHz * 0.1
-		if matchedSignal.BlinkHz < *a.Expected.BlinkHz-tolerance || matchedSignal.BlinkHz > *a.Expected.BlinkHz+tolerance {
+		if !profile.BlinkMatches(*a.Expected.BlinkHz, matchedSignal.BlinkHz) {
 			return AssertionResult{
 				Passed:     false,
 				Expected:   a.String(),
 				Actual:     fmt.Sprintf("LED '%s' blinks at %.2f Hz", matchedSignal.Name, matchedSignal.BlinkHz),
 				Confidence: matchedSignal.Confidence,
-				Message:    fmt.Sprintf("Expected %.2f Hz, got %.2f Hz (outside tolerance)", *a.Expected.BlinkHz, matchedSignal.BlinkHz),
+				Message:    fmt.Sprintf("Expected %.2f Hz, got %.2f Hz (outside ±%g%% tolerance)", *a.Expected.BlinkHz, matchedSignal.BlinkHz, profile.BlinkPercent),
+			}
+		}
+	}
+
+	// Check blink code if specified
+	if a.Expected.Code != nil && matchedSignal.BlinkCode != *a.Expected.Code {
+		if len(matchedSignal.Timeline) == 0 {
+			// Regular observations cannot show a code either way
+			return AssertionResult{
+				Inconclusive: true,
+				Expected:     a.String(),
+				Actual:       fmt.Sprintf("LED '%s' has no per-frame timeline", matchedSignal.Name),
+				Confidence:   matchedSignal.Confidence,
+				Message:      "A blink code needs a high-rate capture of this LED; the observation has none",
 			}
 		}
+
+		actual := fmt.Sprintf("LED '%s' blinks code %s", matchedSignal.Name, matchedSignal.BlinkCode)
+		message := fmt.Sprintf("Expected blink code %s, got %s", *a.Expected.Code, matchedSignal.BlinkCode)
+		if matchedSignal.BlinkCode == "" {
+			actual = fmt.Sprintf("LED '%s' showed no decodable blink code", matchedSignal.Name)
+			message = fmt.Sprintf("Expected blink code %s, but none could be decoded from %d frames", *a.Expected.Code, len(matchedSignal.Timeline))
+		}
+		return AssertionResult{
+			Passed:     false,
+			Expected:   a.String(),
+			Actual:     actual,
+			Confidence: matchedSignal.Confidence,
+			Message:    message,
+		}
 	}
 
 	// All checks passed
@@ -153,13 +231,189 @@ func (a *LEDAssertion) String() string {
 		parts = append(parts, fmt.Sprintf("RGB(%d,%d,%d)", a.Expected.Color.R, a.Expected.Color.G, a.Expected.Color.B))
 	}
 
+	if a.Expected.ColorName != "" {
+		parts = append(parts, "COLOR "+a.Expected.ColorName)
+	}
+
+	if a.Expected.MaxDeltaE != nil {
+		parts = append(parts, fmt.Sprintf("WITHIN %g", *a.Expected.MaxDeltaE))
+	}
+
+	if a.Expected.Blinking != nil {
+		parts = append(parts, strings.ToUpper(blinkingString(*a.Expected.Blinking)))
+	}
+
 	if a.Expected.BlinkHz != nil {
 		parts = append(parts, fmt.Sprintf("%.2f Hz", *a.Expected.BlinkHz))
 	}
 
+	if a.Expected.Code != nil {
+		parts = append(parts, "CODE "+*a.Expected.Code)
+	}
+
 	return strings.Join(parts, " ")
 }
 
+// BlinkCodeLEDs lists the LEDs whose blink code the assertion checks (names
+// compare case-insensitively, like signal lookup).
+// Observations for such assertions need a high-rate capture of these LEDs.
+func BlinkCodeLEDs(a Assertion) []string {
+	return collectLEDs(a, func(node Assertion) (string, bool) {
+		if led, ok := node.(*LEDAssertion); ok && led.Expected.Code != nil {
+			return led.Name, true
+		}
+		return "", false
+	})
+}
+
+// TimelineLEDs lists the LEDs that need a high-rate per-frame capture: those
+// whose blink code the assertion checks and those it expects to fade
+func TimelineLEDs(a Assertion) []string {
+	return collectLEDs(a, func(node Assertion) (string, bool) {
+		switch t := node.(type) {
+		case *LEDAssertion:
+			return t.Name, t.Expected.Code != nil
+		case *LEDFadeAssertion:
+			return t.Name, true
+		}
+		return "", false
+	})
+}
+
+// SignalNames lists the LEDs and displays the assertion refers to, once each,
+// for checking them against the names of a device's calibrated signal map
+func SignalNames(a Assertion) (leds, displays []string) {
+	leds = collectLEDs(a, func(node Assertion) (string, bool) {
+		switch t := node.(type) {
+		case *LEDAssertion:
+			return t.Name, true
+		case *LEDLevelAssertion:
+			return t.Name, true
+		case *LEDFadeAssertion:
+			return t.Name, true
+		case *LEDRelationAssertion:
+			return t.Name, true
+		}
+		return "", false
+	})
+	others := collectLEDs(a, func(node Assertion) (string, bool) {
+		if r, ok := node.(*LEDRelationAssertion); ok {
+			return r.Other, true
+		}
+		return "", false
+	})
+	for _, name := range others {
+		if !slices.ContainsFunc(leds, func(led string) bool { return strings.EqualFold(led, name) }) {
+			leds = append(leds, name)
+		}
+	}
+
+	displays = collectLEDs(a, func(node Assertion) (string, bool) {
+		switch t := node.(type) {
+		case *DisplayAssertion:
+			return t.Name, true
+		case *DisplayChangedAssertion:
+			return t.Name, true
+		case *DisplayValueAssertion:
+			return t.Name, true
+		case *DisplayMatchAssertion:
+			return t.Name, true
+		}
+		return "", false
+	})
+	return leds, displays
+}
+
+// collectLEDs walks the tree and returns the LED (or display) names pick selects, once each
+func collectLEDs(a Assertion, pick func(Assertion) (string, bool)) []string {
+	var names []string
+	seen := make(map[string]bool)
+	Walk(a, func(node Assertion) bool {
+		if t, ok := node.(*thresholdAssertion); ok {
+			node = t.Assertion
+		}
+		if name, ok := pick(node); ok && !seen[strings.ToLower(name)] {
+			seen[strings.ToLower(name)] = true
+			names = append(names, name)
+		}
+		return true
+	})
+	return names
+}
+
+// checkColor compares the LED's color with the expected RGB value or name.
+// A named expectation passes when vision reported that name, or when the
+// observed color falls in the name's hue range. WITHIN replaces both the
+// hue range and the profile's metric with a CIEDE2000 distance.
+func (a *LEDAssertion) checkColor(led *core.LEDSignal, profile tolerance.Profile) (string, bool) {
+	observed, known := observedRGB(led)
+
+	expected := a.Expected.Color
+	var named tolerance.NamedColor
+	if a.Expected.ColorName != "" {
+		if strings.EqualFold(led.ColorName, a.Expected.ColorName) && a.Expected.MaxDeltaE == nil {
+			return "", true
+		}
+		named, _ = tolerance.LookupColor(a.Expected.ColorName)
+		expected = &named.RGB
+	}
+
+	if !known {
+		return fmt.Sprintf("Expected %s, but LED reported color '%s', which has no known RGB value", a.expectedColorString(), led.ColorName), false
+	}
+
+	switch {
+	case a.Expected.MaxDeltaE != nil:
+		if d := tolerance.DeltaE(*expected, observed); d > *a.Expected.MaxDeltaE {
+			return fmt.Sprintf("Expected %s, got %s (ΔE %.1f > %g)", a.expectedColorString(), describeLEDColor(led), d, *a.Expected.MaxDeltaE), false
+		}
+	case a.Expected.ColorName != "":
+		if !named.Matches(observed) {
+			return fmt.Sprintf("Expected %s, got %s (outside the %s hue range)", a.expectedColorString(), describeLEDColor(led), named.Name), false
+		}
+	default:
+		if !profile.ColorsMatch(*expected, observed) {
+			return fmt.Sprintf("Expected %s, got %s (%s)", a.expectedColorString(), describeLEDColor(led), profile.DescribeColorDistance(*expected, observed)), false
+		}
+	}
+	return "", true
+}
+
+func (a *LEDAssertion) expectedColorString() string {
+	if a.Expected.ColorName != "" {
+		return a.Expected.ColorName
+	}
+	return fmt.Sprintf("RGB(%d,%d,%d)", a.Expected.Color.R, a.Expected.Color.G, a.Expected.Color.B)
+}
+
+// observedRGB returns the LED's color, falling back to the representative
+// value of its reported name. Unknown names have no RGB value.
+func observedRGB(led *core.LEDSignal) (core.RGB, bool) {
+	if led.Color != (core.RGB{}) {
+		return led.Color, true
+	}
+	if named, ok := tolerance.LookupColor(led.ColorName); ok {
+		return named.RGB, true
+	}
+	return core.RGB{}, false
+}
+
+// describeLEDColor formats an observed color, e.g. "RGB(40,60,230) (blue)"
+func describeLEDColor(led *core.LEDSignal) string {
+	rgb, known := observedRGB(led)
+	if !known {
+		return fmt.Sprintf("'%s'", led.ColorName)
+	}
+	name := led.ColorName
+	if name == "" {
+		name = tolerance.NameOf(rgb)
+	}
+	if name == "" {
+		return fmt.Sprintf("RGB(%d,%d,%d)", rgb.R, rgb.G, rgb.B)
+	}
+	return fmt.Sprintf("RGB(%d,%d,%d) (%s)", rgb.R, rgb.G, rgb.B, name)
+}
+
 func onOffString(on bool) string {
 	if on {
 		return "ON"
@@ -167,100 +421,89 @@ func onOffString(on bool) string {
 	return "OFF"
 }
 
-func colorsMatch(a, b core.RGB) bool {
-	// Allow small tolerance for RGB values (±5)
-	tolerance := 5
-	return abs(int(a.R)-int(b.R)) <= tolerance &&
-		abs(int(a.G)-int(b.G)) <= tolerance &&
-		abs(int(a.B)-int(b.B)) <= tolerance
-}
-
-func abs(x int) int {
-	if x < 0 {
-		return -x
+func blinkingString(blinking bool) string {
+	if blinking {
+		return "blinking"
 	}
-	return x
+	return "steady"
 }
 
 // DisplayAssertion validates display content
 type DisplayAssertion struct {
-	Name     string
-	Expected string
+	Name          string
+	Expected      string
+	MinSimilarity *float64           // Overrides the profile's OCR fuzziness (0-1, 1 = exact), e.g. SIMILAR 0.8
+	profile       *tolerance.Profile // nil = tolerance.Default(); set via WithTolerance
 }
 
 func (a *DisplayAssertion) Evaluate(obs *core.Observation) AssertionResult {
 	// Find matching Display signal by name
-	var matchedSignal *core.DisplaySignal
-	for _, sig := range obs.Signals {
-		if dispSig, ok := sig.(*core.DisplaySignal); ok {
-			if dispSig.Name == a.Name {
-				matchedSignal = dispSig
-				break
-			}
-		}
-	}
+	matchedSignal := findDisplay(obs, a.Name, false)
 
 	if matchedSignal == nil {
 		return AssertionResult{
-			Passed:     false,
-			Expected:   a.String(),
-			Actual:     "Display not found in observation",
-			Confidence: 0.0,
-			Message:    fmt.Sprintf("Display '%s' not found in observation", a.Name),
+			Passed:        false,
+			Expected:      a.String(),
+			Actual:        "Display not found in observation",
+			Confidence:    0.0,
+			Message:       fmt.Sprintf("Display '%s' not found in observation", a.Name),
+			SignalMissing: true,
 		}
 	}
 
-	// Use contains() instead of exact match (OCR is noisy)
-	if !strings.Contains(matchedSignal.Text, a.Expected) {
+	// Use contains() instead of exact match (OCR is noisy), with the profile's fuzziness
+	profile := textProfile(a.profile, a.MinSimilarity)
+	match, ok := profile.MatchText(matchedSignal.Text, a.Expected)
+	if !ok {
 		return AssertionResult{
 			Passed:     false,
 			Expected:   a.String(),
 			Actual:     fmt.Sprintf("Display '%s' shows: \"%s\"", matchedSignal.Name, matchedSignal.Text),
 			Confidence: matchedSignal.Confidence,
-			Message:    fmt.Sprintf("Expected text containing \"%s\", but display shows \"%s\"", a.Expected, matchedSignal.Text),
+			Message:    fmt.Sprintf("Expected text containing \"%s\", but display shows \"%s\"%s", a.Expected, matchedSignal.Text, describeTextMatch(match, profile)),
+			Text:       &match,
 		}
 	}
 
+	actual := fmt.Sprintf("Display '%s' contains \"%s\"", matchedSignal.Name, a.Expected)
+	if match.Distance > 0 {
+		actual = fmt.Sprintf("Display '%s' contains \"%s\" (similarity %.2f to \"%s\")", matchedSignal.Name, match.Closest, match.Similarity, a.Expected)
+	}
 	return AssertionResult{
 		Passed:     true,
 		Expected:   a.String(),
-		Actual:     fmt.Sprintf("Display '%s' contains \"%s\"", matchedSignal.Name, a.Expected),
+		Actual:     actual,
 		Confidence: matchedSignal.Confidence,
 		Message:    fmt.Sprintf("Display '%s' contains expected text", matchedSignal.Name),
+		Text:       &match,
 	}
 }
 
 func (a *DisplayAssertion) String() string {
-	return fmt.Sprintf("Display.%s \"%s\"", a.Name, a.Expected)
+	return fmt.Sprintf("Display.%s \"%s\"%s", a.Name, a.Expected, similarString(a.MinSimilarity))
 }
 
 // DisplayChangedAssertion validates display state transitions
 type DisplayChangedAssertion struct {
-	Name     string
-	FromText string
-	ToText   string
+	Name          string
+	FromText      string
+	ToText        string
+	MinSimilarity *float64           // Overrides the profile's OCR fuzziness (0-1, 1 = exact)
+	profile       *tolerance.Profile // nil = tolerance.Default(); set via WithTolerance
 }
 
 func (a *DisplayChangedAssertion) Evaluate(obs *core.Observation) AssertionResult {
 	// Find matching display signal by name (case-insensitive)
-	var matchedSignal *core.DisplaySignal
-	lowerName := strings.ToLower(a.Name)
-	for _, sig := range obs.Signals {
-		if dispSig, ok := sig.(core.DisplaySignal); ok {
-			if strings.ToLower(dispSig.Name) == lowerName {
-				matchedSignal = &dispSig
-				break
-			}
-		}
-	}
+	matchedSignal := findDisplay(obs, a.Name, true)
 
 	if matchedSignal == nil {
 		return AssertionResult{
-			Passed:     false,
-			Expected:   a.String(),
-			Actual:     "Display not found in observation",
-			Confidence: 0.0,
-			Message:    fmt.Sprintf("Display '%s' not found in observation", a.Name),
+			Passed:        false,
+			Expected:      a.String(),
+			Actual:        "Display not found in observation",
+			Confidence:    0.0,
+			Message:       fmt.Sprintf("Display '%s' not found in observation", a.Name),
+			SignalMissing: true,
 		}
 	}
 
@@ -274,15 +517,26 @@ func (a *DisplayChangedAssertion) Evaluate(obs *core.Observation) AssertionResul
 		}
 	}
 
-	// Check that FromText appears before ToText in history (substring matching)
+	// Check that FromText appears before ToText in history (substring matching),
+	// keeping the closest reading of each for the report
+	profile := textProfile(a.profile, a.MinSimilarity)
 	fromIdx := -1
 	toIdx := -1
+	var fromBest, toBest tolerance.TextMatch
 	for i, entry := range matchedSignal.History {
-		if fromIdx == -1 && strings.Contains(entry.Text, a.FromText) {
-			fromIdx = i
+		fromMatch, fromOK := profile.MatchText(entry.Text, a.FromText)
+		if fromIdx == -1 && (fromOK || i == 0 || fromMatch.Similarity > fromBest.Similarity) {
+			fromBest = fromMatch
+			if fromOK {
+				fromIdx = i
+			}
 		}
-		if strings.Contains(entry.Text, a.ToText) {
-			toIdx = i
+		toMatch, toOK := profile.MatchText(entry.Text, a.ToText)
+		if toOK || (toIdx == -1 && (i == 0 || toMatch.Similarity > toBest.Similarity)) {
+			toBest = toMatch
+			if toOK {
+				toIdx = i
+			}
 		}
 	}
 
@@ -292,7 +546,8 @@ func (a *DisplayChangedAssertion) Evaluate(obs *core.Observation) AssertionResul
 			Expected:   a.String(),
 			Actual:     fmt.Sprintf("Display '%s' history does not contain \"%s\"", matchedSignal.Name, a.FromText),
 			Confidence: matchedSignal.Confidence,
-			Message:    fmt.Sprintf("Text \"%s\" not found in display history", a.FromText),
+			Message:    fmt.Sprintf("Text \"%s\" not found in display history%s", a.FromText, describeTextMatch(fromBest, profile)),
+			Text:       &fromBest,
 		}
 	}
 
@@ -302,7 +557,8 @@ func (a *DisplayChangedAssertion) Evaluate(obs *core.Observation) AssertionResul
 			Expected:   a.String(),
 			Actual:     fmt.Sprintf("Display '%s' history does not contain \"%s\"", matchedSignal.Name, a.ToText),
 			Confidence: matchedSignal.Confidence,
-			Message:    fmt.Sprintf("Text \"%s\" not found in display history", a.ToText),
+			Message:    fmt.Sprintf("Text \"%s\" not found in display history%s", a.ToText, describeTextMatch(toBest, profile)),
+			Text:       &toBest,
 		}
 	}
 
@@ -316,42 +572,84 @@ func (a *DisplayChangedAssertion) Evaluate(obs *core.Observation) AssertionResul
 		}
 	}
 
+	// Report the weaker of the two matches
+	weakest := fromBest
+	if toBest.Similarity < weakest.Similarity {
+		weakest = toBest
+	}
 	return AssertionResult{
 		Passed:     true,
 		Expected:   a.String(),
-		Actual:     fmt.Sprintf("Display '%s' changed from \"%s\" to \"%s\"", matchedSignal.Name, a.FromText, a.ToText),
+		Actual:     fmt.Sprintf("Display '%s' changed from \"%s\" to \"%s\"", matchedSignal.Name, fromBest.Closest, toBest.Closest),
 		Confidence: matchedSignal.Confidence,
 		Message:    fmt.Sprintf("Display '%s' transitioned from \"%s\" to \"%s\"", matchedSignal.Name, a.FromText, a.ToText),
+		Text:       &weakest,
 	}
 }
 
 func (a *DisplayChangedAssertion) String() string {
-	return fmt.Sprintf("Display.%s CHANGED \"%s\" -> \"%s\"", a.Name, a.FromText, a.ToText)
+	return fmt.Sprintf("Display.%s CHANGED \"%s\" -> \"%s\"%s", a.Name, a.FromText, a.ToText, similarString(a.MinSimilarity))
+}
+
+// textProfile applies an assertion's SIMILAR override to its profile
+func textProfile(p *tolerance.Profile, minSimilarity *float64) tolerance.Profile {
+	profile := profileOrDefault(p)
+	if minSimilarity != nil {
+		profile = profile.WithSimilarity(*minSimilarity)
+	}
+	return profile
+}
+
+// describeTextMatch explains a failed text match, e.g.
+// ` (closest "Rexdv", similarity 0.60 < 0.80)`
+func describeTextMatch(m tolerance.TextMatch, profile tolerance.Profile) string {
+	if m.Closest == "" {
+		return ""
+	}
+	return fmt.Sprintf(" (closest \"%s\", similarity %.2f < %.2f)", m.Closest, m.Similarity, 1-profile.OCRFuzziness)
 }
 
-// TimingAssertion validates boot timing
+func similarString(minSimilarity *float64) string {
+	if minSimilarity == nil {
+		return ""
+	}
+	return fmt.Sprintf(" SIMILAR %g", *minSimilarity)
+}
+
+// TimingAssertion validates boot timing: the whole boot, until the device
+// reached a steady state, or one milestone, e.g. BootTime.first_text < 1500ms
 type TimingAssertion struct {
+	Milestone     string // Empty for the whole boot
 	MaxDurationMs int64
 }
 
 func (a *TimingAssertion) Evaluate(obs *core.Observation) AssertionResult {
 	// Find BootTimingSignal
-	var matchedSignal *core.BootTimingSignal
-	for _, sig := range obs.Signals {
-		if bootSig, ok := sig.(*core.BootTimingSignal); ok {
-			matchedSignal = bootSig
-			break
-		}
-	}
+	matchedSignal := findBootTiming(obs)
 
 	// Graceful failure if signal missing
 	if matchedSignal == nil {
+		return AssertionResult{
+			Passed:        false,
+			Expected:      a.String(),
+			Actual:        "No boot timing signal",
+			Confidence:    0.0,
+			Message:       "boot timing signal not present (capture one with 'percepta observe <device> --boot')",
+			SignalMissing: true,
+		}
+	}
+
+	if a.Milestone != "" {
+		return a.evaluateMilestone(matchedSignal)
+	}
+
+	if matchedSignal.Incomplete {
 		return AssertionResult{
 			Passed:     false,
 			Expected:   a.String(),
-			Actual:     "No boot timing signal",
-			Confidence: 0.0,
-			Message:    "boot timing signal not present (did you capture from power-on?)",
+			Actual:     fmt.Sprintf("Boot not steady after %d ms", matchedSignal.DurationMs),
+			Confidence: matchedSignal.Confidence,
+			Message:    fmt.Sprintf("Expected boot <= %d ms, but the device had not settled when the %d ms capture ended", a.MaxDurationMs, matchedSignal.DurationMs),
 		}
 	}
 
@@ -374,6 +672,52 @@ func (a *TimingAssertion) Evaluate(obs *core.Observation) AssertionResult {
 	}
 }
 
+func (a *TimingAssertion) evaluateMilestone(boot *core.BootTimingSignal) AssertionResult {
+	result := AssertionResult{Expected: a.String(), Confidence: boot.Confidence}
+
+	milestone, ok := boot.Milestone(a.Milestone)
+	if !ok {
+		reached := make([]string, len(boot.Milestones))
+		for i, m := range boot.Milestones {
+			reached[i] = m.Name
+		}
+		if len(reached) == 0 {
+			reached = append(reached, "none")
+		}
+		result.Actual = fmt.Sprintf("Milestone '%s' not reached", a.Milestone)
+		result.Message = fmt.Sprintf("Milestone '%s' was not reached during the %d ms capture (reached: %s)", a.Milestone, boot.DurationMs, strings.Join(reached, ", "))
+		return result
+	}
+
+	result.Actual = fmt.Sprintf("Milestone '%s' at %d ms", milestone.Name, milestone.OffsetMs)
+	if milestone.OffsetMs > a.MaxDurationMs {
+		result.Message = fmt.Sprintf("Expected %s <= %d ms, but it came at %d ms", milestone.Name, a.MaxDurationMs, milestone.OffsetMs)
+		return result
+	}
+	result.Passed = true
+	result.Message = fmt.Sprintf("Milestone '%s' reached at %d ms (within %d ms limit)", milestone.Name, milestone.OffsetMs, a.MaxDurationMs)
+	return result
+}
+
 func (a *TimingAssertion) String() string {
+	if a.Milestone != "" {
+		return fmt.Sprintf("BootTime.%s < %d ms", a.Milestone, a.MaxDurationMs)
+	}
 	return fmt.Sprintf("BootTime < %d ms", a.MaxDurationMs)
 }
+
+// NeedsBootTiming reports whether the assertion checks boot timing, which
+// needs a capture started from power-on or reset
+func NeedsBootTiming(a Assertion) bool {
+	found := false
+	Walk(a, func(node Assertion) bool {
+		if t, ok := node.(*thresholdAssertion); ok {
+			node = t.Assertion
+		}
+		if _, ok := node.(*TimingAssertion); ok {
+			found = true
+		}
+		return !found
+	})
+	return found
+}
@@ -0,0 +1,172 @@
+package assertions
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/perceptumx/percepta/internal/core"
+	"github.com/perceptumx/percepta/internal/readings"
+)
+
+// Comparison is the operator of a display value assertion
+type Comparison string
+
+const (
+	CompareBetween Comparison = "BETWEEN"
+	CompareLT      Comparison = "<"
+	CompareLE      Comparison = "<="
+	CompareGT      Comparison = ">"
+	CompareGE      Comparison = ">="
+	CompareEQ      Comparison = "=="
+)
+
+// DisplayValueAssertion compares a numeric reading parsed from display text,
+// e.g. Display.lcd VALUE temp BETWEEN 20 AND 25
+type DisplayValueAssertion struct {
+	Name  string
+	Key   string
+	Op    Comparison
+	Value float64 // Operand; the lower bound for BETWEEN
+	Upper float64 // Upper bound for BETWEEN (inclusive)
+}
+
+func (a *DisplayValueAssertion) Evaluate(obs *core.Observation) AssertionResult {
+	display := findDisplay(obs, a.Name, true)
+	if display == nil {
+		return AssertionResult{
+			Passed:        false,
+			Expected:      a.String(),
+			Actual:        "Display not found in observation",
+			Confidence:    0.0,
+			Message:       fmt.Sprintf("Display '%s' not found in observation", a.Name),
+			SignalMissing: true,
+		}
+	}
+
+	value, ok := readings.Lookup(*display, a.Key)
+	if !ok || value.Number == nil {
+		actual := fmt.Sprintf("Display '%s' shows: \"%s\"", display.Name, display.Text)
+		message := fmt.Sprintf("No value '%s' found in display text", a.Key)
+		if ok {
+			message = fmt.Sprintf("Value '%s' is \"%s\", not a number", a.Key, value.Text)
+		}
+		return AssertionResult{
+			Passed:     false,
+			Expected:   a.String(),
+			Actual:     actual,
+			Confidence: display.Confidence,
+			Message:    message,
+		}
+	}
+
+	actual := fmt.Sprintf("Display '%s' %s = %s%s", display.Name, a.Key, value.Text, value.Unit)
+	if !a.holds(*value.Number) {
+		return AssertionResult{
+			Passed:     false,
+			Expected:   a.String(),
+			Actual:     actual,
+			Confidence: display.Confidence,
+			Message:    fmt.Sprintf("Expected %s %s, got %s%s", a.Key, a.condition(), value.Text, value.Unit),
+		}
+	}
+
+	return AssertionResult{
+		Passed:     true,
+		Expected:   a.String(),
+		Actual:     actual,
+		Confidence: display.Confidence,
+		Message:    fmt.Sprintf("Display '%s' value %s is %s", display.Name, a.Key, a.condition()),
+	}
+}
+
+func (a *DisplayValueAssertion) holds(v float64) bool {
+	switch a.Op {
+	case CompareBetween:
+		return v >= a.Value && v <= a.Upper
+	case CompareLT:
+		return v < a.Value
+	case CompareLE:
+		return v <= a.Value
+	case CompareGT:
+		return v > a.Value
+	case CompareGE:
+		return v >= a.Value
+	}
+	return v == a.Value
+}
+
+// condition formats the comparison, e.g. "BETWEEN 20 AND 25" or ">= 3.2"
+func (a *DisplayValueAssertion) condition() string {
+	if a.Op == CompareBetween {
+		return fmt.Sprintf("BETWEEN %g AND %g", a.Value, a.Upper)
+	}
+	return fmt.Sprintf("%s %g", a.Op, a.Value)
+}
+
+func (a *DisplayValueAssertion) String() string {
+	return fmt.Sprintf("Display.%s VALUE %s %s", a.Name, a.Key, a.condition())
+}
+
+// DisplayMatchAssertion matches display text, or one value parsed from it,
+// against a regular expression, e.g. Display.lcd MATCHES /^IP \d+\.\d+/
+type DisplayMatchAssertion struct {
+	Name    string
+	Key     string // Value to match; "" matches the whole text
+	Pattern *regexp.Regexp
+}
+
+func (a *DisplayMatchAssertion) Evaluate(obs *core.Observation) AssertionResult {
+	display := findDisplay(obs, a.Name, true)
+	if display == nil {
+		return AssertionResult{
+			Passed:        false,
+			Expected:      a.String(),
+			Actual:        "Display not found in observation",
+			Confidence:    0.0,
+			Message:       fmt.Sprintf("Display '%s' not found in observation", a.Name),
+			SignalMissing: true,
+		}
+	}
+
+	subject, what := display.Text, "text"
+	if a.Key != "" {
+		value, ok := readings.Lookup(*display, a.Key)
+		if !ok {
+			return AssertionResult{
+				Passed:     false,
+				Expected:   a.String(),
+				Actual:     fmt.Sprintf("Display '%s' shows: \"%s\"", display.Name, display.Text),
+				Confidence: display.Confidence,
+				Message:    fmt.Sprintf("No value '%s' found in display text", a.Key),
+			}
+		}
+		subject, what = value.Text+value.Unit, "value "+a.Key
+	}
+
+	if !a.Pattern.MatchString(subject) {
+		return AssertionResult{
+			Passed:     false,
+			Expected:   a.String(),
+			Actual:     fmt.Sprintf("Display '%s' %s: \"%s\"", display.Name, what, subject),
+			Confidence: display.Confidence,
+			Message:    fmt.Sprintf("Expected %s to match /%s/, got \"%s\"", what, a.Pattern, subject),
+		}
+	}
+
+	return AssertionResult{
+		Passed:     true,
+		Expected:   a.String(),
+		Actual:     fmt.Sprintf("Display '%s' %s: \"%s\"", display.Name, what, subject),
+		Confidence: display.Confidence,
+		Message:    fmt.Sprintf("Display '%s' %s matches /%s/", display.Name, what, a.Pattern),
+	}
+}
+
+func (a *DisplayMatchAssertion) String() string {
+	pattern := "/" + strings.ReplaceAll(a.Pattern.String(), "/", `\/`) + "/"
+	if a.Key != "" {
+		return fmt.Sprintf("Display.%s VALUE %s MATCHES %s", a.Name, a.Key, pattern)
+	}
+	return fmt.Sprintf("Display.%s MATCHES %s", a.Name, pattern)
+}
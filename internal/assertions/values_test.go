@@ -0,0 +1,140 @@
+package assertions
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/perceptumx/percepta/internal/core"
+)
+
+func TestDisplayValueAssertion(t *testing.T) {
+	obs := observation("readings", core.DisplaySignal{Name: "LCD", Text: "IP 10.0.0.7 TEMP 23.4C V=3.29", Confidence: 0.9})
+
+	tests := []struct {
+		dsl  string
+		want bool
+	}{
+		{"Display.lcd VALUE temp BETWEEN 20 AND 25", true},
+		{"Display.lcd VALUE temp BETWEEN 24 AND 30", false},
+		{"Display.lcd VALUE v >= 3.2", true},
+		{"Display.lcd VALUE v < 3.2", false},
+		{"Display.lcd VALUE temp > -10", true},
+		{"Display.lcd VALUE temp == 23.4", true},
+		{"display('lcd').value('temp', 20, 25)", true},
+		{"Display.lcd VALUE humidity < 50", false},
+		{"Display.lcd VALUE ip > 1", false}, // Not a number
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.dsl, func(t *testing.T) {
+			assertion, err := Parse(tt.dsl)
+			if err != nil {
+				t.Fatalf("Parse failed: %v", err)
+			}
+			result := assertion.Evaluate(obs)
+			if result.Passed != tt.want {
+				t.Errorf("Expected passed=%v, got %v: %s", tt.want, result.Passed, result.Message)
+			}
+		})
+	}
+}
+
+func TestDisplayValueAssertion_Messages(t *testing.T) {
+	obs := observation("readings", core.DisplaySignal{Name: "LCD", Text: "IP 10.0.0.7 TEMP 23.4C V=3.29", Confidence: 0.9})
+	assertion, _ := Parse("Display.lcd VALUE temp BETWEEN 24 AND 30")
+	result := assertion.Evaluate(obs)
+	if result.Message != "Expected temp BETWEEN 24 AND 30, got 23.4C" {
+		t.Errorf("Unexpected message: %q", result.Message)
+	}
+
+	assertion, _ = Parse("Display.lcd VALUE ip > 1")
+	if result := assertion.Evaluate(obs); !strings.Contains(result.Message, "not a number") {
+		t.Errorf("Expected non-numeric message, got %q", result.Message)
+	}
+
+	assertion, _ = Parse("Display.oled VALUE temp > 1")
+	if result := assertion.Evaluate(obs); !result.SignalMissing {
+		t.Error("Expected missing display to be flagged")
+	}
+}
+
+func TestDisplayValueAssertion_RecordedValues(t *testing.T) {
+	n := 3.3
+	obs := &core.Observation{
+		Signals: []core.Signal{
+			core.DisplaySignal{
+				Name:   "LCD",
+				Text:   "OUT 3.3 volts",
+				Values: map[string]core.DisplayValue{"out": {Text: "3.3", Number: &n, Unit: "V"}},
+			},
+		},
+	}
+
+	assertion, _ := Parse("Display.LCD VALUE out BETWEEN 3.2 AND 3.4")
+	if result := assertion.Evaluate(obs); !result.Passed {
+		t.Errorf("Expected recorded value to be used, got: %s", result.Message)
+	}
+}
+
+func TestDisplayMatchAssertion(t *testing.T) {
+	obs := observation("readings", core.DisplaySignal{Name: "LCD", Text: "IP 10.0.0.7 TEMP 23.4C V=3.29", Confidence: 0.9})
+
+	tests := []struct {
+		dsl  string
+		want bool
+	}{
+		{`Display.lcd MATCHES /^IP \d+\.\d+/`, true},
+		{`Display.lcd MATCHES /^TEMP/`, false},
+		{`Display.lcd VALUE ip MATCHES /^10\.0\.0\.\d+$/`, true},
+		{`Display.lcd VALUE temp MATCHES /C$/`, true},
+		{`display('lcd').matches(/V=3\.\d+/)`, true},
+		{`Display.lcd MATCHES /a\/b/`, false},
+		{`Display.lcd MATCHES /^IP/ && Display.lcd VALUE v >= 3`, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.dsl, func(t *testing.T) {
+			assertion, err := Parse(tt.dsl)
+			if err != nil {
+				t.Fatalf("Parse failed: %v", err)
+			}
+			result := assertion.Evaluate(obs)
+			if result.Passed != tt.want {
+				t.Errorf("Expected passed=%v, got %v: %s", tt.want, result.Passed, result.Message)
+			}
+		})
+	}
+}
+
+func TestParse_DisplayValues_RoundTrip(t *testing.T) {
+	for _, dsl := range []string{
+		"Display.lcd VALUE temp BETWEEN 20 AND 25",
+		"Display.lcd VALUE v >= 3.2",
+		`Display.lcd MATCHES /^IP \d+\.\d+/`,
+		`Display.lcd VALUE path MATCHES /^\/dev\/tty/`,
+	} {
+		assertion, err := Parse(dsl)
+		if err != nil {
+			t.Fatalf("Parse(%q) failed: %v", dsl, err)
+		}
+		if assertion.String() != dsl {
+			t.Errorf("Expected String() %q, got %q", dsl, assertion.String())
+		}
+	}
+}
+
+func TestParse_DisplayValues_Invalid(t *testing.T) {
+	for _, dsl := range []string{
+		"Display.lcd VALUE temp BETWEEN 25 AND 20",
+		"Display.lcd VALUE temp ABOUT 20",
+		"Display.lcd VALUE temp > warm",
+		"Display.lcd MATCHES /(/",
+		"Display.lcd MATCHES /unterminated",
+		"Display.lcd MATCHES /x/ extra",
+		"display('lcd').value(temp, 1, 2)",
+	} {
+		if _, err := Parse(dsl); err == nil {
+			t.Errorf("Expected Parse(%q) to fail", dsl)
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package assertions
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/perceptumx/percepta/internal/core"
+	"github.com/perceptumx/percepta/internal/tolerance"
+)
+
+// VisionAssertion asks the vision model a free-form question about the frame,
+// for checks that do not fit the LED, display and boot signals, e.g.
+//
+//	Vision "Is the WiFi icon shown in the status bar?" EXPECT yes
+//	Vision "Which icon is shown next to the clock?" EXPECT "battery"
+//
+// A yes/no expectation needs that exact answer; a text expectation must be
+// contained in the answer, within the profile's OCR fuzziness. The answer is
+// a QuerySignal, asked during live assertions for every question in the tree.
+type VisionAssertion struct {
+	Question string
+	Expected string // "yes", "no" or the expected text
+	Text     bool   // Expected is text rather than yes/no
+
+	profile *tolerance.Profile // nil = tolerance.Default(); set via WithTolerance
+}
+
+func (a *VisionAssertion) Evaluate(obs *core.Observation) AssertionResult {
+	query := findQuery(obs, a.Question)
+	if query == nil {
+		return AssertionResult{
+			Passed:        false,
+			Expected:      a.String(),
+			Actual:        "No answer in observation",
+			Confidence:    0.0,
+			Message:       fmt.Sprintf("Question \"%s\" was not asked for this observation (vision questions are answered during live assertions)", a.Question),
+			SignalMissing: true,
+		}
+	}
+
+	result := AssertionResult{
+		Expected:   a.String(),
+		Actual:     fmt.Sprintf("Vision answered \"%s\"%s", query.Answer, reasoningString(query.Reasoning)),
+		Confidence: query.Confidence,
+	}
+
+	if strings.EqualFold(query.Answer, "unknown") {
+		result.Inconclusive = true
+		result.Message = fmt.Sprintf("Vision could not tell from the frame: \"%s\"", a.Question)
+		return result
+	}
+
+	if !a.Text {
+		if !strings.EqualFold(query.Answer, a.Expected) {
+			result.Message = fmt.Sprintf("Expected %s, but vision answered \"%s\"", a.Expected, query.Answer)
+			return result
+		}
+		result.Passed = true
+		result.Message = fmt.Sprintf("Vision answered %s as expected", a.Expected)
+		return result
+	}
+
+	profile := profileOrDefault(a.profile)
+	match, ok := profile.MatchText(query.Answer, a.Expected)
+	result.Text = &match
+	if !ok {
+		result.Message = fmt.Sprintf("Expected an answer containing \"%s\", but vision answered \"%s\"%s", a.Expected, query.Answer, describeTextMatch(match, profile))
+		return result
+	}
+	result.Passed = true
+	result.Message = fmt.Sprintf("Vision answer contains \"%s\"", a.Expected)
+	return result
+}
+
+func (a *VisionAssertion) String() string {
+	if a.Text {
+		return fmt.Sprintf("Vision \"%s\" EXPECT \"%s\"", a.Question, a.Expected)
+	}
+	return fmt.Sprintf("Vision \"%s\" EXPECT %s", a.Question, a.Expected)
+}
+
+// VisionQuestions lists the questions the assertion asks, once each in order.
+// Observations for such assertions need the vision model to answer them.
+func VisionQuestions(a Assertion) []string {
+	var questions []string
+	seen := make(map[string]bool)
+	Walk(a, func(node Assertion) bool {
+		if t, ok := node.(*thresholdAssertion); ok {
+			node = t.Assertion
+		}
+		if v, ok := node.(*VisionAssertion); ok && !seen[v.Question] {
+			seen[v.Question] = true
+			questions = append(questions, v.Question)
+		}
+		return true
+	})
+	return questions
+}
+
+func reasoningString(reasoning string) string {
+	if reasoning == "" {
+		return ""
+	}
+	return fmt.Sprintf(" (%s)", reasoning)
+}
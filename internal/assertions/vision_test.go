@@ -0,0 +1,134 @@
+package assertions
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/perceptumx/percepta/internal/core"
+	"github.com/perceptumx/percepta/internal/tolerance"
+)
+
+func TestVisionAssertion(t *testing.T) {
+	obs := observation("vision",
+		core.LEDSignal{Name: "power", On: true, Confidence: 0.9},
+		core.QuerySignal{Question: "Is the WiFi icon shown?", Answer: "yes", Confidence: 0.85},
+		core.QuerySignal{Question: "Is the relay's indicator LED next to the USB port lit?", Answer: "no", Reasoning: "The LED by the USB port is dark", Confidence: 0.7},
+		core.QuerySignal{Question: "Which icon is next to the clock?", Answer: "A battery icon", Confidence: 0.8},
+		core.QuerySignal{Question: "Is the fan spinning?", Answer: "unknown", Confidence: 0.3},
+	)
+
+	tests := []struct {
+		dsl  string
+		want Outcome
+	}{
+		{`Vision "Is the WiFi icon shown?" EXPECT yes`, OutcomePass},
+		{`Vision "Is the WiFi icon shown?" EXPECT no`, OutcomeFail},
+		{`Vision "is the wifi icon shown? " expect YES`, OutcomePass},
+		{`Vision "Is the relay's indicator LED next to the USB port lit?" EXPECT no`, OutcomePass},
+		{`Vision "Which icon is next to the clock?" EXPECT "battery"`, OutcomePass},
+		{`Vision "Which icon is next to the clock?" EXPECT "wifi"`, OutcomeFail},
+		{`Vision "Which icon is next to the clock?" EXPECT yes`, OutcomeFail},
+		{`Vision "Is the fan spinning?" EXPECT yes`, OutcomeInconclusive},
+		{`Vision "Is the WiFi icon shown?" EXPECT yes && LED.power ON`, OutcomePass},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.dsl, func(t *testing.T) {
+			assertion, err := Parse(tt.dsl)
+			if err != nil {
+				t.Fatalf("Parse failed: %v", err)
+			}
+			result := assertion.Evaluate(obs)
+			if result.Outcome() != tt.want {
+				t.Errorf("Expected %s, got %s: %s (%s)", tt.want, result.Outcome(), result.Message, result.Actual)
+			}
+		})
+	}
+}
+
+func TestVisionAssertion_Messages(t *testing.T) {
+	obs := observation("vision",
+		core.QuerySignal{Question: "Is the WiFi icon shown?", Answer: "yes", Confidence: 0.85},
+		core.QuerySignal{Question: "Is the relay's indicator LED next to the USB port lit?", Answer: "no", Reasoning: "The LED by the USB port is dark", Confidence: 0.7},
+	)
+	assertion, _ := Parse(`Vision "Is the relay's indicator LED next to the USB port lit?" EXPECT yes`)
+	result := assertion.Evaluate(obs)
+	if result.Confidence != 0.7 || result.Actual != `Vision answered "no" (The LED by the USB port is dark)` {
+		t.Errorf("Unexpected result: %q [%.2f]", result.Actual, result.Confidence)
+	}
+	if result.Message != `Expected yes, but vision answered "no"` {
+		t.Errorf("Unexpected message: %q", result.Message)
+	}
+
+	assertion, _ = Parse(`Vision "Is the boot logo shown?" EXPECT yes`)
+	if result := assertion.Evaluate(obs); !result.SignalMissing || !strings.Contains(result.Message, "not asked") {
+		t.Errorf("Expected a missing answer to be flagged, got %+v", result)
+	}
+
+	// A confident wrong answer fails; a weak one is inconclusive under a threshold
+	assertion, _ = Parse(`Vision "Is the WiFi icon shown?" EXPECT no`)
+	if result := WithMinConfidence(assertion, 0.9).Evaluate(obs); !result.Inconclusive {
+		t.Errorf("Expected INCONCLUSIVE below the confidence threshold, got %+v", result)
+	}
+}
+
+func TestVisionAssertion_WithTolerance(t *testing.T) {
+	obs := observation("vision", core.QuerySignal{Question: "Which icon is next to the clock?", Answer: "A battery icon", Confidence: 0.8})
+	assertion, _ := Parse(`Vision "Which icon is next to the clock?" EXPECT "battary"`)
+	if result := assertion.Evaluate(obs); result.Passed {
+		t.Error("Expected the default profile to reject a misspelled answer")
+	}
+	fuzzy := tolerance.Default()
+	fuzzy.OCRFuzziness = 0.2
+	if result := WithTolerance(assertion, fuzzy).Evaluate(obs); !result.Passed {
+		t.Errorf("Expected a fuzzy profile to accept \"battery\" for \"battary\": %s", result.Message)
+	}
+}
+
+func TestVisionQuestions(t *testing.T) {
+	assertion, err := Parse(`Vision "Is the WiFi icon shown?" EXPECT yes && (LED.power ON || Vision "Is the fan spinning?" EXPECT no) && !Vision "Is the WiFi icon shown?" EXPECT no`)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	questions := VisionQuestions(WithMinConfidence(assertion, 0.5))
+	if len(questions) != 2 || questions[0] != "Is the WiFi icon shown?" || questions[1] != "Is the fan spinning?" {
+		t.Errorf("Expected both questions once each, got %v", questions)
+	}
+}
+
+func TestParse_Vision(t *testing.T) {
+	for _, dsl := range []string{
+		`Vision "Is the WiFi icon shown?" EXPECT yes`,
+		`Vision "Is the screen blank?" EXPECT no`,
+		`Vision "What does the status bar say?" EXPECT "Connected"`,
+		`Vision "Is the WiFi icon shown?" EXPECT yes && LED.power ON`,
+	} {
+		assertion, err := Parse(dsl)
+		if err != nil {
+			t.Fatalf("Parse(%q) failed: %v", dsl, err)
+		}
+		if assertion.String() != dsl {
+			t.Errorf("Expected %q to round-trip, got %q", dsl, assertion.String())
+		}
+	}
+
+	assertion, err := Parse(`Vision "Is the LED && the icon on?" expect Yes`)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if v, ok := assertion.(*VisionAssertion); !ok || v.Question != "Is the LED && the icon on?" || v.Expected != "yes" || v.Text {
+		t.Errorf("Unexpected vision assertion: %+v", assertion)
+	}
+
+	for _, dsl := range []string{
+		`Vision "Is the WiFi icon shown?"`,
+		`Vision "Is the WiFi icon shown?" EXPECT maybe`,
+		`Vision "Is the WiFi icon shown?" EXPECT ""`,
+		`Vision "" EXPECT yes`,
+		`Vision EXPECT yes`,
+	} {
+		if _, err := Parse(dsl); err == nil {
+			t.Errorf("Expected Parse(%q) to fail", dsl)
+		}
+	}
+}
@@ -0,0 +1,32 @@
+package camera
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/perceptumx/percepta/internal/core"
+)
+
+// NewCamera creates the camera driver for a device's camera ID: a file://
+// URL replays frames from disk (see NewFileCamera), anything else is a
+// platform-specific camera device
+func NewCamera(devicePath string) core.CameraDriver {
+	if IsFileCamera(devicePath) {
+		return NewFileCamera(devicePath)
+	}
+	return newDeviceCamera(devicePath)
+}
+
+// IsFileCamera reports whether a camera ID is a file:// URL
+func IsFileCamera(devicePath string) bool {
+	return strings.HasPrefix(strings.ToLower(devicePath), FileScheme)
+}
+
+// FileURL is the file:// camera ID replaying the frames at path
+func FileURL(path string) string {
+	path = filepath.ToSlash(path)
+	if filepath.IsAbs(path) && !strings.HasPrefix(path, "/") {
+		path = "/" + path // Windows drive: file:///C:/...
+	}
+	return FileScheme + path
+}
@@ -4,7 +4,7 @@ package camera
 
 import "github.com/perceptumx/percepta/internal/core"
 
-// NewCamera creates a platform-specific camera driver
-func NewCamera(devicePath string) core.CameraDriver {
+// newDeviceCamera creates the platform-specific camera driver
+func newDeviceCamera(devicePath string) core.CameraDriver {
 	return NewV4L2Camera(devicePath)
 }
@@ -10,13 +10,13 @@ import (
 
 type stubCamera struct{}
 
-// NewCamera returns a stub camera driver for unsupported platforms
-func NewCamera(devicePath string) core.CameraDriver {
+// newDeviceCamera returns a stub camera driver for unsupported platforms
+func newDeviceCamera(devicePath string) core.CameraDriver {
 	return &stubCamera{}
 }
 
 func (s *stubCamera) Open() error {
-	return fmt.Errorf("camera not supported on this platform")
+	return fmt.Errorf("camera not supported on this platform: set camera_id to a file:// URL to replay recorded frames")
 }
 
 func (s *stubCamera) CaptureFrame() ([]byte, error) {
@@ -0,0 +1,312 @@
+package camera
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/perceptumx/percepta/internal/core"
+)
+
+const (
+	// FileScheme prefixes camera IDs that replay frames from disk
+	FileScheme = "file://"
+
+	// SessionManifest is the file listing a recorded session's frames and the
+	// time each was captured, as written by Recorder
+	SessionManifest = "session.json"
+
+	// defaultFileFPS is the replay rate of JPEG directories and MJPEG files,
+	// which carry no timing of their own
+	defaultFileFPS = 20
+)
+
+// ErrRecordingEnded is returned by CaptureFrame once a replay that does not
+// loop has served its last frame
+var ErrRecordingEnded = errors.New("recording ended")
+
+// Session is the manifest of a recorded session: JPEG frames stored beside it
+// and their capture times
+type Session struct {
+	Frames []SessionFrame `json:"frames"`
+}
+
+// SessionFrame is one frame of a recorded session
+type SessionFrame struct {
+	File     string `json:"file"`      // JPEG file, relative to the manifest
+	OffsetMs int64  `json:"offset_ms"` // Capture time from the first Open
+}
+
+// FileCamera implements core.CameraDriver by replaying frames from disk with
+// their original timing, for runs without a camera (CI, demos, bug reports).
+// The replay clock starts at the first Open and keeps running across Close,
+// like the scene in front of a real camera. As with a live camera,
+// CaptureFrame returns the newest frame due, waiting for the next one when it
+// was already taken, so frames parsed slowly are skipped as they would be live.
+type FileCamera struct {
+	source  string
+	frames  [][]byte
+	offsets []time.Duration // Due time of each frame from the start
+	length  time.Duration   // Replay length, after which it loops
+	loop    bool
+	opened  bool
+	start   time.Time
+	next    int // Position of the next frame not yet served, counting loops
+
+	now   func() time.Time
+	sleep func(time.Duration)
+}
+
+// NewFileCamera creates a driver replaying the frames a file:// URL points to:
+//   - a recorded session: a directory holding session.json, or the manifest
+//   - a directory of JPEG files, replayed in name order
+//   - an MJPEG file (concatenated JPEGs, as written by ffmpeg -f mjpeg)
+//   - a single JPEG, served as a still
+//
+// Sources without timing replay at ?fps=<n> (default 20). The replay loops
+// unless ?loop=false, which ends it with ErrRecordingEnded. Relative paths
+// (file://testdata/boot) are resolved from the working directory.
+func NewFileCamera(source string) core.CameraDriver {
+	return &FileCamera{source: source, now: time.Now, sleep: time.Sleep}
+}
+
+func (c *FileCamera) Open() error {
+	if c.frames == nil {
+		path, fps, loop, err := parseFileURL(c.source)
+		if err != nil {
+			return err
+		}
+		interval := time.Second / time.Duration(fps)
+		frames, offsets, err := loadFrames(path, interval)
+		if err != nil {
+			return fmt.Errorf("failed to open camera %s: %w", c.source, err)
+		}
+
+		// The last frame lasts as long as the gap before it
+		gap := interval
+		if n := len(offsets); n > 1 && offsets[n-1] > offsets[n-2] {
+			gap = offsets[n-1] - offsets[n-2]
+		}
+		c.frames, c.offsets, c.loop = frames, offsets, loop
+		c.length = offsets[len(offsets)-1] + gap
+		c.start = c.now()
+	}
+	c.opened = true
+	return nil
+}
+
+func (c *FileCamera) CaptureFrame() ([]byte, error) {
+	if !c.opened {
+		return nil, fmt.Errorf("camera not opened")
+	}
+
+	elapsed := c.now().Sub(c.start)
+	k := c.next
+	for (c.loop || k+1 < len(c.frames)) && c.due(k+1) <= elapsed {
+		k++
+	}
+	if !c.loop && k >= len(c.frames) {
+		return nil, ErrRecordingEnded
+	}
+	if wait := c.due(k) - elapsed; wait > 0 {
+		c.sleep(wait)
+	}
+	c.next = k + 1
+	return c.frames[k%len(c.frames)], nil
+}
+
+func (c *FileCamera) Close() error {
+	c.opened = false
+	return nil
+}
+
+// due is when the frame at position k, counting loops, is captured
+func (c *FileCamera) due(k int) time.Duration {
+	n := len(c.frames)
+	return time.Duration(k/n)*c.length + c.offsets[k%n]
+}
+
+// parseFileURL splits a file:// camera ID into its path and replay options
+func parseFileURL(source string) (path string, fps int, loop bool, err error) {
+	u, err := url.Parse(source)
+	if err != nil || !strings.EqualFold(u.Scheme, "file") {
+		return "", 0, false, fmt.Errorf("invalid file camera %q: expected file:///path", source)
+	}
+
+	// file://dir/x puts "dir" in the host; file:///C:/x has a drive after the slash
+	path = u.Host + u.Path
+	if len(path) > 2 && path[0] == '/' && path[2] == ':' {
+		path = path[1:]
+	}
+	if path == "" {
+		return "", 0, false, fmt.Errorf("invalid file camera %q: no path", source)
+	}
+
+	fps, loop = defaultFileFPS, true
+	query := u.Query()
+	if v := query.Get("fps"); v != "" {
+		if fps, err = strconv.Atoi(v); err != nil || fps <= 0 {
+			return "", 0, false, fmt.Errorf("invalid file camera %q: fps must be a positive integer", source)
+		}
+	}
+	if v := query.Get("loop"); v != "" {
+		if loop, err = strconv.ParseBool(v); err != nil {
+			return "", 0, false, fmt.Errorf("invalid file camera %q: loop must be true or false", source)
+		}
+	}
+	return filepath.FromSlash(path), fps, loop, nil
+}
+
+// loadFrames reads the frames at path and when each is due. Sources without
+// timing are spaced by interval.
+func loadFrames(path string, interval time.Duration) ([][]byte, []time.Duration, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var frames [][]byte
+	switch ext := strings.ToLower(filepath.Ext(path)); {
+	case info.IsDir():
+		manifest := filepath.Join(path, SessionManifest)
+		if _, err := os.Stat(manifest); err == nil {
+			return loadSession(manifest)
+		}
+		if frames, err = loadDirectory(path); err != nil {
+			return nil, nil, err
+		}
+	case ext == ".json":
+		return loadSession(path)
+	case ext == ".jpg" || ext == ".jpeg":
+		frame, err := os.ReadFile(path)
+		if err != nil {
+			return nil, nil, err
+		}
+		frames = [][]byte{frame}
+	default:
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, nil, err
+		}
+		frames = splitMJPEG(data)
+	}
+	if len(frames) == 0 {
+		return nil, nil, fmt.Errorf("no JPEG frames in %s", path)
+	}
+
+	offsets := make([]time.Duration, len(frames))
+	for i := range offsets {
+		offsets[i] = time.Duration(i) * interval
+	}
+	return frames, offsets, nil
+}
+
+// loadDirectory reads the JPEG files of a directory in name order
+func loadDirectory(dir string) ([][]byte, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var frames [][]byte
+	for _, entry := range entries {
+		ext := strings.ToLower(filepath.Ext(entry.Name()))
+		if entry.IsDir() || (ext != ".jpg" && ext != ".jpeg") {
+			continue
+		}
+		frame, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		frames = append(frames, frame)
+	}
+	return frames, nil
+}
+
+// loadSession reads a recorded session's frames in capture order
+func loadSession(manifest string) ([][]byte, []time.Duration, error) {
+	data, err := os.ReadFile(manifest)
+	if err != nil {
+		return nil, nil, err
+	}
+	var session Session
+	if err := json.Unmarshal(data, &session); err != nil {
+		return nil, nil, fmt.Errorf("invalid session %s: %w", manifest, err)
+	}
+	if len(session.Frames) == 0 {
+		return nil, nil, fmt.Errorf("session %s has no frames", manifest)
+	}
+	sort.SliceStable(session.Frames, func(i, j int) bool { return session.Frames[i].OffsetMs < session.Frames[j].OffsetMs })
+
+	frames := make([][]byte, len(session.Frames))
+	offsets := make([]time.Duration, len(session.Frames))
+	for i, f := range session.Frames {
+		if frames[i], err = os.ReadFile(filepath.Join(filepath.Dir(manifest), filepath.FromSlash(f.File))); err != nil {
+			return nil, nil, fmt.Errorf("session frame %d: %w", i, err)
+		}
+		offsets[i] = time.Duration(f.OffsetMs) * time.Millisecond
+	}
+	return frames, offsets, nil
+}
+
+// splitMJPEG cuts an MJPEG stream into its JPEG frames. Each frame's segments
+// are walked up to its end-of-image marker, so that the end marker of an
+// embedded thumbnail does not cut it short. Bytes between frames, such as the
+// part headers of an HTTP multipart stream, and truncated frames are skipped.
+func splitMJPEG(data []byte) [][]byte {
+	var frames [][]byte
+	for i := 0; i+1 < len(data); {
+		if data[i] != 0xFF || data[i+1] != 0xD8 {
+			i++
+			continue
+		}
+		end := jpegEnd(data, i)
+		if end < 0 {
+			i += 2
+			continue
+		}
+		frames = append(frames, data[i:end])
+		i = end
+	}
+	return frames
+}
+
+// jpegEnd returns the offset just past the end-of-image marker of the JPEG
+// starting at start, or -1 when it is truncated or corrupt
+func jpegEnd(data []byte, start int) int {
+	i := start + 2
+	for i+1 < len(data) {
+		if data[i] != 0xFF {
+			return -1
+		}
+		marker := data[i+1]
+		switch {
+		case marker == 0xFF: // Fill byte
+			i++
+			continue
+		case marker == 0xD9: // End of image
+			return i + 2
+		case marker == 0x01 || (marker >= 0xD0 && marker <= 0xD7): // No length
+			i += 2
+			continue
+		}
+		if i+3 >= len(data) {
+			return -1
+		}
+		i += 2 + (int(data[i+2])<<8 | int(data[i+3]))
+		if marker == 0xDA {
+			// Scan data runs to the next marker that is neither a stuffed
+			// zero nor a restart marker
+			for i+1 < len(data) && (data[i] != 0xFF || data[i+1] == 0x00 || (data[i+1] >= 0xD0 && data[i+1] <= 0xD7)) {
+				i++
+			}
+		}
+	}
+	return -1
+}
@@ -0,0 +1,257 @@
+package camera
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/jpeg"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// fakeClock drives a FileCamera's replay without waiting
+type fakeClock struct {
+	now   time.Time
+	slept time.Duration
+}
+
+func (c *fakeClock) sleep(d time.Duration) {
+	c.now = c.now.Add(d)
+	c.slept += d
+}
+
+func (c *fakeClock) camera(source string) (*FileCamera, *fakeClock) {
+	cam := NewFileCamera(source).(*FileCamera)
+	cam.now = func() time.Time { return c.now }
+	cam.sleep = c.sleep
+	return cam, c
+}
+
+// grayFrame encodes a small JPEG of a single gray level
+func grayFrame(t *testing.T, level uint8) []byte {
+	t.Helper()
+	img := image.NewGray(image.Rect(0, 0, 16, 16))
+	for i := range img.Pix {
+		img.Pix[i] = level
+	}
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, nil); err != nil {
+		t.Fatalf("jpeg encode failed: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func writeFrames(t *testing.T, dir string, frames ...[]byte) {
+	t.Helper()
+	for i, frame := range frames {
+		if err := os.WriteFile(filepath.Join(dir, string(rune('a'+i))+".jpg"), frame, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func capture(t *testing.T, cam *FileCamera) []byte {
+	t.Helper()
+	frame, err := cam.CaptureFrame()
+	if err != nil {
+		t.Fatalf("CaptureFrame failed: %v", err)
+	}
+	return frame
+}
+
+func TestFileCamera_Directory(t *testing.T) {
+	dir := t.TempDir()
+	frames := [][]byte{grayFrame(t, 0), grayFrame(t, 128), grayFrame(t, 255)}
+	writeFrames(t, dir, frames...)
+	if err := os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("not a frame"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cam, clock := (&fakeClock{now: time.Unix(0, 0)}).camera(FileURL(dir) + "?fps=10")
+	if _, err := cam.CaptureFrame(); err == nil {
+		t.Error("Expected an error before Open")
+	}
+	if err := cam.Open(); err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+
+	// Frames in name order, 100 ms apart, then looping
+	for i := 0; i < 4; i++ {
+		if got := capture(t, cam); !bytes.Equal(got, frames[i%3]) {
+			t.Errorf("Capture %d: expected frame %d", i, i%3)
+		}
+	}
+	if clock.slept != 300*time.Millisecond {
+		t.Errorf("Expected to wait 300ms for the frames, waited %v", clock.slept)
+	}
+
+	// The clock keeps running while closed: at 450ms the frame due at 400ms
+	// (the second, looped) is the newest
+	cam.Close()
+	clock.now = clock.now.Add(150 * time.Millisecond)
+	if err := cam.Open(); err != nil {
+		t.Fatalf("Reopen failed: %v", err)
+	}
+	if got := capture(t, cam); !bytes.Equal(got, frames[1]) {
+		t.Error("Expected the newest frame due after reopening")
+	}
+}
+
+func TestFileCamera_Session(t *testing.T) {
+	dir := t.TempDir()
+	frames := [][]byte{grayFrame(t, 0), grayFrame(t, 128), grayFrame(t, 255)}
+	writeFrames(t, dir, frames...)
+	manifest := `{"frames": [{"file": "c.jpg", "offset_ms": 1000}, {"file": "a.jpg", "offset_ms": 0}, {"file": "b.jpg", "offset_ms": 100}]}`
+	if err := os.WriteFile(filepath.Join(dir, SessionManifest), []byte(manifest), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cam, clock := (&fakeClock{now: time.Unix(0, 0)}).camera(FileURL(dir) + "?loop=false")
+	if err := cam.Open(); err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	if !bytes.Equal(capture(t, cam), frames[0]) {
+		t.Error("Expected the first frame at once")
+	}
+
+	// A slow parse skips to the newest frame due; the next one is waited for
+	clock.now = clock.now.Add(500 * time.Millisecond)
+	if !bytes.Equal(capture(t, cam), frames[1]) || clock.slept != 0 {
+		t.Error("Expected the frame due at 100ms without waiting")
+	}
+	if !bytes.Equal(capture(t, cam), frames[2]) || clock.slept != 500*time.Millisecond {
+		t.Errorf("Expected to wait until 1000ms for the last frame, waited %v", clock.slept)
+	}
+	if _, err := cam.CaptureFrame(); !errors.Is(err, ErrRecordingEnded) {
+		t.Errorf("Expected ErrRecordingEnded, got %v", err)
+	}
+}
+
+func TestFileCamera_OpenErrors(t *testing.T) {
+	dir := t.TempDir()
+	for _, source := range []string{
+		FileURL(filepath.Join(dir, "missing")),
+		FileURL(dir), // No frames
+		FileURL(dir) + "?fps=0",
+		FileURL(dir) + "?loop=sometimes",
+		"file://",
+		"http://example.com/stream",
+	} {
+		if err := NewFileCamera(source).Open(); err == nil {
+			t.Errorf("Expected an error opening %s", source)
+		}
+	}
+}
+
+func TestParseFileURL(t *testing.T) {
+	tests := []struct {
+		source string
+		path   string
+		fps    int
+		loop   bool
+	}{
+		{"file:///tmp/frames", "/tmp/frames", 20, true},
+		{"FILE:///tmp/boot.mjpeg?fps=30&loop=false", "/tmp/boot.mjpeg", 30, false},
+		{"file://testdata/boot", "testdata/boot", 20, true},
+		{"file:///C:/frames", "C:/frames", 20, true},
+	}
+	for _, tt := range tests {
+		path, fps, loop, err := parseFileURL(tt.source)
+		if err != nil || path != filepath.FromSlash(tt.path) || fps != tt.fps || loop != tt.loop {
+			t.Errorf("parseFileURL(%q) = %q, %d, %v, %v", tt.source, path, fps, loop, err)
+		}
+	}
+}
+
+func TestSplitMJPEG(t *testing.T) {
+	first, second := grayFrame(t, 40), grayFrame(t, 200)
+
+	// An APP1 segment embedding a thumbnail with its own end marker
+	thumb := grayFrame(t, 90)
+	app1 := append([]byte{0xFF, 0xE1, byte((len(thumb) + 2) >> 8), byte(len(thumb) + 2)}, thumb...)
+	withThumb := append(append([]byte{0xFF, 0xD8}, app1...), first[2:]...)
+
+	var stream []byte
+	stream = append(stream, "--frame\r\nContent-Type: image/jpeg\r\n\r\n"...)
+	stream = append(stream, withThumb...)
+	stream = append(stream, "\r\n--frame\r\n\r\n"...)
+	stream = append(stream, second...)
+	stream = append(stream, first[:len(first)/2]...) // Truncated
+
+	frames := splitMJPEG(stream)
+	if len(frames) != 2 || !bytes.Equal(frames[0], withThumb) || !bytes.Equal(frames[1], second) {
+		t.Fatalf("Expected the two whole frames, got %d", len(frames))
+	}
+	if _, err := jpeg.Decode(bytes.NewReader(frames[0])); err != nil {
+		t.Errorf("Expected the frame with a thumbnail to decode, got %v", err)
+	}
+
+	// Served from a file at the given rate
+	path := filepath.Join(t.TempDir(), "capture.mjpeg")
+	if err := os.WriteFile(path, stream, 0644); err != nil {
+		t.Fatal(err)
+	}
+	cam, clock := (&fakeClock{now: time.Unix(0, 0)}).camera(FileURL(path) + "?fps=4")
+	if err := cam.Open(); err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	capture(t, cam)
+	if !bytes.Equal(capture(t, cam), second) || clock.slept != 250*time.Millisecond {
+		t.Errorf("Expected the second frame 250ms later, waited %v", clock.slept)
+	}
+}
+
+func TestRecorder(t *testing.T) {
+	src := t.TempDir()
+	frames := [][]byte{grayFrame(t, 0), grayFrame(t, 255)}
+	writeFrames(t, src, frames...)
+	out := filepath.Join(t.TempDir(), "session")
+
+	rec := NewRecorder(NewFileCamera(FileURL(src)+"?fps=50"), out)
+	for i := 0; i < 2; i++ {
+		if err := rec.Open(); err != nil {
+			t.Fatalf("Open failed: %v", err)
+		}
+		record := func() {
+			if _, err := rec.CaptureFrame(); err != nil {
+				t.Fatalf("CaptureFrame failed: %v", err)
+			}
+		}
+		record()
+		record()
+		if err := rec.Close(); err != nil {
+			t.Fatalf("Close failed: %v", err)
+		}
+	}
+
+	// The session replays the frames of both captures, in capture order
+	cam, _ := (&fakeClock{now: time.Unix(0, 0)}).camera(FileURL(out) + "?loop=false")
+	if err := cam.Open(); err != nil {
+		t.Fatalf("Opening the recording failed: %v", err)
+	}
+	if len(cam.frames) != 4 {
+		t.Fatalf("Expected 4 recorded frames, got %d", len(cam.frames))
+	}
+	for i, frame := range cam.frames {
+		if !bytes.Equal(frame, frames[i%2]) {
+			t.Errorf("Recorded frame %d differs", i)
+		}
+		if i > 0 && cam.offsets[i] < cam.offsets[i-1] {
+			t.Errorf("Expected increasing offsets, got %v", cam.offsets)
+		}
+	}
+}
+
+func TestNewCamera_File(t *testing.T) {
+	if _, ok := NewCamera("file:///tmp/frames").(*FileCamera); !ok {
+		t.Error("Expected a file camera for a file:// URL")
+	}
+	if IsFileCamera("/dev/video0") || !IsFileCamera("File:///x") {
+		t.Error("Expected only file:// URLs to be file cameras")
+	}
+	if got := FileURL("recordings/bench"); got != "file://recordings/bench" {
+		t.Errorf("Expected a relative file URL, got %s", got)
+	}
+}
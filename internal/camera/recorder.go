@@ -0,0 +1,71 @@
+package camera
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/perceptumx/percepta/internal/core"
+)
+
+// Recorder wraps a camera driver and saves every frame captured through it as
+// a session that NewFileCamera replays with the original timing. Offsets count
+// from the first Open; the manifest is rewritten on every Close, so a session
+// spanning several captures is kept whole. Frames already in the directory
+// from an earlier recording are overwritten.
+type Recorder struct {
+	camera  core.CameraDriver
+	dir     string
+	start   time.Time
+	session Session
+}
+
+// NewRecorder creates a recorder saving the frames of camera to dir
+func NewRecorder(camera core.CameraDriver, dir string) *Recorder {
+	return &Recorder{camera: camera, dir: dir}
+}
+
+func (r *Recorder) Open() error {
+	if err := os.MkdirAll(r.dir, 0755); err != nil {
+		return fmt.Errorf("failed to create recording directory: %w", err)
+	}
+	if err := r.camera.Open(); err != nil {
+		return err
+	}
+	if r.start.IsZero() {
+		r.start = time.Now()
+	}
+	return nil
+}
+
+func (r *Recorder) CaptureFrame() ([]byte, error) {
+	frame, err := r.camera.CaptureFrame()
+	if err != nil {
+		return nil, err
+	}
+	offset := time.Since(r.start)
+
+	name := fmt.Sprintf("frame-%05d.jpg", len(r.session.Frames)+1)
+	if err := os.WriteFile(filepath.Join(r.dir, name), frame, 0644); err != nil {
+		return nil, fmt.Errorf("failed to record frame: %w", err)
+	}
+	r.session.Frames = append(r.session.Frames, SessionFrame{File: name, OffsetMs: offset.Milliseconds()})
+	return frame, nil
+}
+
+func (r *Recorder) Close() error {
+	err := r.camera.Close()
+	if len(r.session.Frames) == 0 {
+		return err
+	}
+	data, jsonErr := json.MarshalIndent(r.session, "", "  ")
+	if jsonErr == nil {
+		jsonErr = os.WriteFile(filepath.Join(r.dir, SessionManifest), data, 0644)
+	}
+	if jsonErr != nil && err == nil {
+		err = fmt.Errorf("failed to save recording: %w", jsonErr)
+	}
+	return err
+}
@@ -9,6 +9,7 @@ import (
 
 type Config struct {
 	Vision  VisionConfig
+	Assert  AssertConfig
 	Devices map[string]DeviceConfig
 }
 
@@ -17,10 +18,77 @@ type VisionConfig struct {
 	APIKey   string `mapstructure:"api_key"`
 }
 
+// AssertConfig holds global defaults for 'percepta assert'
+type AssertConfig struct {
+	MinConfidence float64 `mapstructure:"min_confidence"` // Below this, results are INCONCLUSIVE (0 = off)
+}
+
 type DeviceConfig struct {
-	Type     string `mapstructure:"type" yaml:"type"`
-	CameraID string `mapstructure:"camera_id" yaml:"camera_id"`
-	Firmware string `mapstructure:"firmware" yaml:"firmware"`
+	Type          string  `mapstructure:"type" yaml:"type"`
+	CameraID      string  `mapstructure:"camera_id" yaml:"camera_id"`
+	Firmware      string  `mapstructure:"firmware" yaml:"firmware"`
+	MinConfidence float64 `mapstructure:"min_confidence" yaml:"min_confidence,omitempty"` // Overrides assert.min_confidence
+
+	// Blink-code decoding: how the device encodes codes and where its LEDs are in the frame
+	BlinkCode BlinkCodeConfig   `mapstructure:"blink_code" yaml:"blink_code,omitempty"`
+	Regions   map[string]Region `mapstructure:"regions" yaml:"regions,omitempty"` // LED name → pixel region
+
+	// Boot timing capture (percepta observe --boot)
+	Boot BootConfig `mapstructure:"boot" yaml:"boot,omitempty"`
+
+	// How much camera/OCR noise assertions and diffs ignore
+	Tolerance ToleranceConfig `mapstructure:"tolerance" yaml:"tolerance,omitempty"`
+
+	// Per-display settings, keyed by display name
+	Displays map[string]DisplayConfig `mapstructure:"displays" yaml:"displays,omitempty"`
+}
+
+// DisplayConfig describes where a display is and how readings are parsed from its text
+type DisplayConfig struct {
+	// Pixel region of the display, set by 'percepta device calibrate'
+	Region *Region `mapstructure:"region" yaml:"region,omitempty"`
+
+	// Value name → regex; the first capture group is the value, an optional
+	// second one the unit, e.g. temp: 'TEMP\s*(-?[\d.]+)\s*([CF])'
+	Values map[string]string `mapstructure:"values" yaml:"values,omitempty"`
+}
+
+// ToleranceConfig overrides the default tolerance profile for a device.
+// Unset fields fall back to the defaults of the tolerance package; 0 asks
+// for an exact match.
+type ToleranceConfig struct {
+	BlinkPercent   *float64 `mapstructure:"blink_percent" yaml:"blink_percent,omitempty"`     // Allowed blink-rate deviation in percent
+	ColorMetric    string   `mapstructure:"color_metric" yaml:"color_metric,omitempty"`       // rgb, hue or ciede2000
+	ColorThreshold *float64 `mapstructure:"color_threshold" yaml:"color_threshold,omitempty"` // Largest distance still considered a match
+	OCRFuzziness   *float64 `mapstructure:"ocr_fuzziness" yaml:"ocr_fuzziness,omitempty"`     // Share of characters (0-1) that may be misread
+
+	BrightnessPoints *float64 `mapstructure:"brightness_points" yaml:"brightness_points,omitempty"` // Allowed brightness/duty-cycle deviation, percentage points
+}
+
+// BlinkCodeConfig describes a device's blink-code scheme and capture settings.
+// Zero values fall back to the defaults of the timeline package.
+type BlinkCodeConfig struct {
+	Scheme      string `mapstructure:"scheme" yaml:"scheme,omitempty"` // groups or short-long
+	ShortMaxMs  int64  `mapstructure:"short_max_ms" yaml:"short_max_ms,omitempty"`
+	GroupGapMs  int64  `mapstructure:"group_gap_ms" yaml:"group_gap_ms,omitempty"`
+	RepeatGapMs int64  `mapstructure:"repeat_gap_ms" yaml:"repeat_gap_ms,omitempty"`
+	CaptureMs   int64  `mapstructure:"capture_ms" yaml:"capture_ms,omitempty"` // High-rate capture length
+	FPS         int    `mapstructure:"fps" yaml:"fps,omitempty"`
+}
+
+// BootConfig describes how a boot timing capture starts and how long it runs
+type BootConfig struct {
+	ResetCommand string `mapstructure:"reset_command" yaml:"reset_command,omitempty"` // Shell command that resets the device; empty waits for Enter
+	CaptureMs    int64  `mapstructure:"capture_ms" yaml:"capture_ms,omitempty"`       // High-rate capture length (default 15s)
+	FPS          int    `mapstructure:"fps" yaml:"fps,omitempty"`
+}
+
+// Region is a pixel rectangle in the camera frame
+type Region struct {
+	X int `mapstructure:"x" yaml:"x"`
+	Y int `mapstructure:"y" yaml:"y"`
+	W int `mapstructure:"w" yaml:"w"`
+	H int `mapstructure:"h" yaml:"h"`
 }
 
 func Load() (*Config, error) {
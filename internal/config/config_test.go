@@ -382,3 +382,202 @@ func TestLoad_PartialConfig(t *testing.T) {
 		t.Errorf("Expected 1 device, got %d", len(cfg.Devices))
 	}
 }
+
+func TestLoad_ConfidenceThresholds(t *testing.T) {
+	tmpDir, cleanup := setupTestConfig(t)
+	defer cleanup()
+
+	configDir := filepath.Join(tmpDir, ".config", "percepta")
+	if err := os.MkdirAll(configDir, 0755); err != nil {
+		t.Fatalf("Failed to create config dir: %v", err)
+	}
+
+	configPath := filepath.Join(configDir, "config.yaml")
+	configContent := `assert:
+  min_confidence: 0.6
+devices:
+  noisy-board:
+    type: esp32
+    camera_id: "0"
+    min_confidence: 0.85
+`
+	if err := os.WriteFile(configPath, []byte(configContent), 0644); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	if cfg.Assert.MinConfidence != 0.6 {
+		t.Errorf("Expected global min_confidence 0.6, got %v", cfg.Assert.MinConfidence)
+	}
+	if cfg.Devices["noisy-board"].MinConfidence != 0.85 {
+		t.Errorf("Expected device min_confidence 0.85, got %v", cfg.Devices["noisy-board"].MinConfidence)
+	}
+}
+
+func TestLoad_BlinkCodeSettings(t *testing.T) {
+	tmpDir, cleanup := setupTestConfig(t)
+	defer cleanup()
+
+	configDir := filepath.Join(tmpDir, ".config", "percepta")
+	if err := os.MkdirAll(configDir, 0755); err != nil {
+		t.Fatalf("Failed to create config dir: %v", err)
+	}
+
+	configPath := filepath.Join(configDir, "config.yaml")
+	configContent := `devices:
+  router:
+    type: esp32
+    blink_code:
+      scheme: short-long
+      short_max_ms: 300
+      repeat_gap_ms: 2000
+      capture_ms: 12000
+    regions:
+      err: {x: 610, y: 340, w: 12, h: 12}
+`
+	if err := os.WriteFile(configPath, []byte(configContent), 0644); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	device := cfg.Devices["router"]
+	want := BlinkCodeConfig{Scheme: "short-long", ShortMaxMs: 300, RepeatGapMs: 2000, CaptureMs: 12000}
+	if device.BlinkCode != want {
+		t.Errorf("Expected blink code settings %+v, got %+v", want, device.BlinkCode)
+	}
+	if device.Regions["err"] != (Region{X: 610, Y: 340, W: 12, H: 12}) {
+		t.Errorf("Expected err region, got %+v", device.Regions["err"])
+	}
+}
+
+func TestLoad_BootSettings(t *testing.T) {
+	tmpDir, cleanup := setupTestConfig(t)
+	defer cleanup()
+
+	configDir := filepath.Join(tmpDir, ".config", "percepta")
+	if err := os.MkdirAll(configDir, 0755); err != nil {
+		t.Fatalf("Failed to create config dir: %v", err)
+	}
+
+	configPath := filepath.Join(configDir, "config.yaml")
+	configContent := `devices:
+  router:
+    type: esp32
+    boot:
+      reset_command: esptool.py --port /dev/ttyUSB0 run
+      capture_ms: 20000
+      fps: 30
+`
+	if err := os.WriteFile(configPath, []byte(configContent), 0644); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	want := BootConfig{ResetCommand: "esptool.py --port /dev/ttyUSB0 run", CaptureMs: 20000, FPS: 30}
+	if got := cfg.Devices["router"].Boot; got != want {
+		t.Errorf("Expected boot settings %+v, got %+v", want, got)
+	}
+}
+
+func TestLoad_ToleranceSettings(t *testing.T) {
+	tmpDir, cleanup := setupTestConfig(t)
+	defer cleanup()
+
+	configDir := filepath.Join(tmpDir, ".config", "percepta")
+	if err := os.MkdirAll(configDir, 0755); err != nil {
+		t.Fatalf("Failed to create config dir: %v", err)
+	}
+
+	configPath := filepath.Join(configDir, "config.yaml")
+	configContent := `devices:
+  cheap-webcam-board:
+    type: esp32
+    tolerance:
+      blink_percent: 20
+      color_metric: hue
+      color_threshold: 25
+      ocr_fuzziness: 0.2
+      brightness_points: 15
+  lab-board:
+    type: esp32
+  exact-board:
+    type: esp32
+    tolerance:
+      blink_percent: 0
+`
+	if err := os.WriteFile(configPath, []byte(configContent), 0644); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	got := cfg.Devices["cheap-webcam-board"].Tolerance
+	if got.BlinkPercent == nil || *got.BlinkPercent != 20 || got.ColorMetric != "hue" || got.ColorThreshold == nil || *got.ColorThreshold != 25 ||
+		got.OCRFuzziness == nil || *got.OCRFuzziness != 0.2 || got.BrightnessPoints == nil || *got.BrightnessPoints != 15 {
+		t.Errorf("Expected the configured tolerance, got %+v", got)
+	}
+	if got := cfg.Devices["lab-board"].Tolerance; got != (ToleranceConfig{}) {
+		t.Errorf("Expected unset tolerance to be zero, got %+v", got)
+	}
+	if got := cfg.Devices["exact-board"].Tolerance; got.BlinkPercent == nil || *got.BlinkPercent != 0 {
+		t.Errorf("Expected an explicit 0 to be kept apart from unset, got %+v", got)
+	}
+}
+
+func TestLoad_DisplayValuePatterns(t *testing.T) {
+	tmpDir, cleanup := setupTestConfig(t)
+	defer cleanup()
+
+	configDir := filepath.Join(tmpDir, ".config", "percepta")
+	if err := os.MkdirAll(configDir, 0755); err != nil {
+		t.Fatalf("Failed to create config dir: %v", err)
+	}
+
+	configPath := filepath.Join(configDir, "config.yaml")
+	configContent := `devices:
+  thermostat:
+    type: esp32
+    displays:
+      lcd:
+        region: {x: 180, y: 96, w: 240, h: 80}
+        values:
+          temp: 'TEMP\s*(-?[\d.]+)\s*([CF])'
+      oled:
+        values:
+          v: 'V=([\d.]+)'
+`
+	if err := os.WriteFile(configPath, []byte(configContent), 0644); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	want := `TEMP\s*(-?[\d.]+)\s*([CF])`
+	if got := cfg.Devices["thermostat"].Displays["lcd"].Values["temp"]; got != want {
+		t.Errorf("Expected temp pattern %q, got %q", want, got)
+	}
+	if r := cfg.Devices["thermostat"].Displays["lcd"].Region; r == nil || *r != (Region{X: 180, Y: 96, W: 240, H: 80}) {
+		t.Errorf("Expected the lcd region, got %+v", r)
+	}
+	if r := cfg.Devices["thermostat"].Displays["oled"].Region; r != nil {
+		t.Errorf("Expected an uncalibrated display to have no region, got %+v", r)
+	}
+}
@@ -126,6 +126,8 @@ func parseSignals(rawSignals []interface{}) ([]Signal, error) {
 				signalType = "display"
 			} else if _, hasDuration := signalMap["duration_ms"]; hasDuration {
 				signalType = "boot_timing"
+			} else if _, hasQuestion := signalMap["question"]; hasQuestion {
+				signalType = "query"
 			}
 		}
 
@@ -154,6 +156,12 @@ func parseSignals(rawSignals []interface{}) ([]Signal, error) {
 				return nil, fmt.Errorf("failed to unmarshal boot timing signal: %w", err)
 			}
 			signals = append(signals, boot)
+		case "query":
+			var query QuerySignal
+			if err := json.Unmarshal(rawBytes, &query); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal query signal: %w", err)
+			}
+			signals = append(signals, query)
 		}
 	}
 
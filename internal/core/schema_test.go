@@ -251,6 +251,33 @@ func TestDisplaySignal_BackwardCompat(t *testing.T) {
 	}
 }
 
+func TestSchemaValidator_QuerySignal(t *testing.T) {
+	obs := Observation{
+		SchemaVersion: CurrentSchemaVersion,
+		ID:            "query-1",
+		Signals: []Signal{
+			LEDSignal{Name: "LED1", On: true, Confidence: 0.95},
+			QuerySignal{Question: "Is the WiFi icon shown?", Answer: "yes", Confidence: 0.8},
+		},
+	}
+	data, err := json.Marshal(obs)
+	if err != nil {
+		t.Fatalf("Failed to marshal observation: %v", err)
+	}
+
+	validated, err := NewSchemaValidator().ValidateAndMigrate(data)
+	if err != nil {
+		t.Fatalf("Validation failed: %v", err)
+	}
+	if len(validated.Signals) != 2 {
+		t.Fatalf("Expected 2 signals, got %d", len(validated.Signals))
+	}
+	query, ok := validated.Signals[1].(QuerySignal)
+	if !ok || query != obs.Signals[1] {
+		t.Errorf("Expected the query signal to round-trip, got %#v", validated.Signals[1])
+	}
+}
+
 func contains(s, substr string) bool {
 	return len(s) >= len(substr) && (s == substr || len(s) > len(substr) && containsHelper(s, substr))
 }
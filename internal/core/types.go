@@ -1,8 +1,11 @@
 package core
 
-import "time"
+import (
+	"strings"
+	"time"
+)
 
-// Signal interface - LED, Display, or Boot timing
+// Signal interface - LED, Display, Boot timing or an answered vision query
 type Signal interface {
 	Type() string
 	State() interface{}
@@ -13,14 +16,42 @@ type LEDSignal struct {
 	Name       string  `json:"name"`
 	On         bool    `json:"on"`
 	Color      RGB     `json:"color,omitempty"`
-	Brightness uint8   `json:"brightness,omitempty"`
+	ColorName  string  `json:"color_name,omitempty"` // Name reported by vision (e.g. "amber"), before mapping to RGB
+	Brightness uint8   `json:"brightness,omitempty"` // Percent of full intensity (1-100) while lit; 0 = not measured
 	BlinkHz    float64 `json:"blink_hz,omitempty"`
+	DutyCycle  float64 `json:"duty_cycle,omitempty"` // Share of a blink period spent lit, percent (0-100); 0 when steady
 	Confidence float64 `json:"confidence"`
+
+	// Why a blink rate measured from frame timestamps may be off, e.g. a rate
+	// near the Nyquist limit of the frame rate; empty when it is sound
+	BlinkWarning string `json:"blink_warning,omitempty"`
+
+	// Per-frame on/off history from a high-rate capture, and the blink code
+	// decoded from it (e.g. "3-2"). Both are empty for regular observations.
+	Timeline  []LEDSample `json:"timeline,omitempty"`
+	BlinkCode string      `json:"blink_code,omitempty"`
+
+	// Per-frame on/off state from the regular multi-frame capture, offset from
+	// its first frame; lets LEDs be compared frame by frame (phase, alternation)
+	Frames []LEDSample `json:"frames,omitempty"`
+}
+
+// LEDSample is the state of an LED in one frame of a capture
+type LEDSample struct {
+	OffsetMs   int64 `json:"offset_ms"`
+	On         bool  `json:"on"`
+	Brightness uint8 `json:"brightness,omitempty"` // Percent of full intensity, or the mean luminance of the region in high-rate captures (for following fades); 0 when dark or not measured
 }
 
 func (l LEDSignal) Type() string       { return "led" }
 func (l LEDSignal) State() interface{} { return l }
 
+// HasColor reports whether a color was observed. A reported name counts even
+// when its RGB is (0,0,0), e.g. "black"; an unnamed (0,0,0) means unknown.
+func (l LEDSignal) HasColor() bool {
+	return l.ColorName != "" || l.Color != (RGB{})
+}
+
 // RGB color
 type RGB struct {
 	R uint8 `json:"r"`
@@ -42,20 +73,70 @@ type DisplaySignal struct {
 	Confidence float64            `json:"confidence"`
 	History    []DisplayTextEntry `json:"history,omitempty"`
 	Changed    bool               `json:"changed,omitempty"`
+
+	// Named readings parsed from Text (e.g. "temp" → 23.4 C), keyed in lower case
+	Values map[string]DisplayValue `json:"values,omitempty"`
+}
+
+// DisplayValue is one reading shown on a display
+type DisplayValue struct {
+	Text   string   `json:"text"`             // As shown, e.g. "23.4" or "192.168.1.4"
+	Number *float64 `json:"number,omitempty"` // Parsed number; nil for non-numeric values
+	Unit   string   `json:"unit,omitempty"`   // e.g. "C", "V", "%"
 }
 
 func (d DisplaySignal) Type() string       { return "display" }
 func (d DisplaySignal) State() interface{} { return d }
 
-// BootTimingSignal represents boot sequence timing
+// BootTimingSignal represents boot sequence timing, measured from a start
+// trigger (power-on or reset) until the device reaches a steady state
 type BootTimingSignal struct {
 	DurationMs int64   `json:"duration_ms"`
 	Confidence float64 `json:"confidence"`
+
+	Milestones []BootMilestone `json:"milestones,omitempty"` // In order of offset
+	Incomplete bool            `json:"incomplete,omitempty"` // No steady state before the capture ended; DurationMs is the capture length
+}
+
+// Boot milestones detected by every boot capture; configured LED regions add
+// one "led.<name>" milestone each
+const (
+	MilestoneFirstLED  = "first_led"  // Something in the picture first lit up
+	MilestoneFirstText = "first_text" // A display first showed text
+	MilestoneSteady    = "steady"     // The picture stopped changing
+)
+
+// BootMilestone is a point in the boot sequence, offset from the start trigger
+type BootMilestone struct {
+	Name     string `json:"name"`
+	OffsetMs int64  `json:"offset_ms"`
 }
 
 func (b BootTimingSignal) Type() string       { return "boot_timing" }
 func (b BootTimingSignal) State() interface{} { return b }
 
+// Milestone returns the named milestone, matched case-insensitively
+func (b BootTimingSignal) Milestone(name string) (BootMilestone, bool) {
+	for _, m := range b.Milestones {
+		if strings.EqualFold(m.Name, name) {
+			return m, true
+		}
+	}
+	return BootMilestone{}, false
+}
+
+// QuerySignal is the vision model's answer to a free-form question about
+// the frame, e.g. "Is the WiFi icon shown?"
+type QuerySignal struct {
+	Question   string  `json:"question"`
+	Answer     string  `json:"answer"` // "yes", "no", "unknown" or a short text answer
+	Reasoning  string  `json:"reasoning,omitempty"`
+	Confidence float64 `json:"confidence"`
+}
+
+func (q QuerySignal) Type() string       { return "query" }
+func (q QuerySignal) State() interface{} { return q }
+
 // Observation is a snapshot of hardware state at a point in time
 type Observation struct {
 	SchemaVersion string    `json:"schema_version"` // Schema version for compatibility
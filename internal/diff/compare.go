@@ -3,12 +3,20 @@ package diff
 import (
 	"fmt"
 	"math"
+	"sort"
 
 	"github.com/perceptumx/percepta/internal/core"
+	"github.com/perceptumx/percepta/internal/tolerance"
 )
 
-// Compare compares two observations and returns detected changes
+// Compare compares two observations exactly and returns detected changes
 func Compare(from, to *core.Observation) *DiffResult {
+	return CompareWithTolerance(from, to, tolerance.Exact())
+}
+
+// CompareWithTolerance compares two observations, ignoring differences in blink
+// rate, color and display text that the tolerance profile considers noise
+func CompareWithTolerance(from, to *core.Observation, profile tolerance.Profile) *DiffResult {
 	result := &DiffResult{
 		DeviceID:      from.DeviceID,
 		FromFirmware:  from.FirmwareHash,
@@ -61,8 +69,8 @@ func Compare(from, to *core.Observation) *DiffResult {
 			})
 		} else {
 			// Signal exists in both - check for modifications
-			if !signalsEqual(fromSig, toSig) {
-				details := describeChange(fromSig, toSig)
+			if !signalsEqual(fromSig, toSig, profile) {
+				details := describeChange(fromSig, toSig, profile)
 				result.Changes = append(result.Changes, SignalChange{
 					Type:      ChangeModified,
 					Name:      name,
@@ -77,6 +85,19 @@ func Compare(from, to *core.Observation) *DiffResult {
 	return result
 }
 
+// Describe lists an observation's signals in the form diffs report them,
+// sorted by name, e.g. "LED1: ON green solid"
+func Describe(obs *core.Observation) []string {
+	signals := normalizeSignals(obs.Signals)
+	sort.SliceStable(signals, func(i, j int) bool { return signals[i].Name < signals[j].Name })
+
+	lines := make([]string, len(signals))
+	for i, sig := range signals {
+		lines[i] = fmt.Sprintf("%s: %s", sig.Name, formatSignalState(sig))
+	}
+	return lines
+}
+
 // normalizeSignals converts signals to normalized form for comparison
 func normalizeSignals(signals []core.Signal) []NormalizedSignal {
 	normalized := make([]NormalizedSignal, 0, len(signals))
@@ -114,6 +135,32 @@ func normalizeSignals(signals []core.Signal) []NormalizedSignal {
 	return normalized
 }
 
+func blinkCodeOrNone(code string) string {
+	if code == "" {
+		return "none"
+	}
+	return code
+}
+
+// blinkEqual reports whether two blink rates are the same after rounding, or
+// within the profile's tolerance of each other. Blinking never equals steady.
+func blinkEqual(a, b float64, profile tolerance.Profile) bool {
+	if normalizeBlinkHz(a) == normalizeBlinkHz(b) {
+		return true
+	}
+	return a > 0 && b > 0 && profile.BlinkMatches(a, b)
+}
+
+// brightnessEqual reports whether two LEDs are equally bright within the
+// profile's tolerance. Brightness is only compared when both LEDs are lit and
+// measured, so observations without brightness never differ by it.
+func brightnessEqual(a, b core.LEDSignal, profile tolerance.Profile) bool {
+	if !a.On || !b.On || a.Brightness == 0 || b.Brightness == 0 {
+		return true
+	}
+	return profile.BrightnessMatches(float64(a.Brightness), float64(b.Brightness))
+}
+
 // normalizeBlinkHz rounds blink rate to 1 decimal place to handle Claude Vision fluctuations
 func normalizeBlinkHz(hz float64) float64 {
 	if hz == 0 {
@@ -122,8 +169,8 @@ func normalizeBlinkHz(hz float64) float64 {
 	return math.Round(hz*10) / 10
 }
 
-// signalsEqual compares two normalized signals for equality
-func signalsEqual(a, b NormalizedSignal) bool {
+// signalsEqual compares two normalized signals for equality within the profile's tolerances
+func signalsEqual(a, b NormalizedSignal, profile tolerance.Profile) bool {
 	if a.Type != b.Type {
 		return false
 	}
@@ -139,12 +186,22 @@ func signalsEqual(a, b NormalizedSignal) bool {
 		}
 
 		// Compare blinking (normalized)
-		if normalizeBlinkHz(aLED.BlinkHz) != normalizeBlinkHz(bLED.BlinkHz) {
+		if !blinkEqual(aLED.BlinkHz, bLED.BlinkHz, profile) {
+			return false
+		}
+
+		// Compare color
+		if !profile.ColorsMatch(aLED.Color, bLED.Color) {
+			return false
+		}
+
+		// Compare decoded blink codes
+		if aLED.BlinkCode != bLED.BlinkCode {
 			return false
 		}
 
-		// Compare color exactly
-		if aLED.Color != bLED.Color {
+		// Compare brightness
+		if !brightnessEqual(aLED, bLED, profile) {
 			return false
 		}
 
@@ -164,22 +221,22 @@ func signalsEqual(a, b NormalizedSignal) bool {
 				return false
 			}
 			for i := range aDisplay.History {
-				if aDisplay.History[i].Text != bDisplay.History[i].Text {
+				if !profile.TextEquals(aDisplay.History[i].Text, bDisplay.History[i].Text) {
 					return false
 				}
 			}
 			return true
 		}
 
-		// Compare text exactly for static displays
-		return aDisplay.Text == bDisplay.Text
+		// Compare text for static displays
+		return profile.TextEquals(aDisplay.Text, bDisplay.Text)
 
 	case "boot_timing":
 		aBoot := a.Signal.(core.BootTimingSignal)
 		bBoot := b.Signal.(core.BootTimingSignal)
 
 		// Compare duration exactly
-		return aBoot.DurationMs == bBoot.DurationMs
+		return aBoot.DurationMs == bBoot.DurationMs && aBoot.Incomplete == bBoot.Incomplete
 	}
 
 	return false
@@ -198,8 +255,10 @@ func formatSignalState(sig NormalizedSignal) string {
 		var parts []string
 		parts = append(parts, state)
 
-		// Add color if present
-		if led.Color.R > 0 || led.Color.G > 0 || led.Color.B > 0 {
+		// Add color if present, preferring the name vision reported
+		if led.ColorName != "" {
+			parts = append(parts, led.ColorName)
+		} else if led.HasColor() {
 			parts = append(parts, formatColor(led.Color))
 		}
 
@@ -210,6 +269,16 @@ func formatSignalState(sig NormalizedSignal) string {
 			parts = append(parts, "solid")
 		}
 
+		// Add decoded blink code
+		if led.BlinkCode != "" {
+			parts = append(parts, "code "+led.BlinkCode)
+		}
+
+		// Add measured brightness
+		if led.On && led.Brightness > 0 {
+			parts = append(parts, fmt.Sprintf("%d%%", led.Brightness))
+		}
+
 		result := ""
 		for i, part := range parts {
 			if i > 0 {
@@ -227,8 +296,7 @@ func formatSignalState(sig NormalizedSignal) string {
 		return fmt.Sprintf("\"%s\"", display.Text)
 
 	case "boot_timing":
-		boot := sig.Signal.(core.BootTimingSignal)
-		return fmt.Sprintf("%dms", boot.DurationMs)
+		return formatBootDuration(sig.Signal.(core.BootTimingSignal))
 	}
 
 	return ""
@@ -270,7 +338,7 @@ func formatColor(rgb core.RGB) string {
 }
 
 // describeChange creates a detailed description of what changed
-func describeChange(from, to NormalizedSignal) string {
+func describeChange(from, to NormalizedSignal, profile tolerance.Profile) string {
 	if from.Type != to.Type {
 		return fmt.Sprintf("type changed: %s → %s", from.Type, to.Type)
 	}
@@ -296,14 +364,14 @@ func describeChange(from, to NormalizedSignal) string {
 		}
 
 		// Color change
-		if fromLED.Color != toLED.Color {
+		if !profile.ColorsMatch(fromLED.Color, toLED.Color) {
 			changes = append(changes, fmt.Sprintf("color: %s→%s", formatColor(fromLED.Color), formatColor(toLED.Color)))
 		}
 
 		// Blink rate change
 		fromHz := normalizeBlinkHz(fromLED.BlinkHz)
 		toHz := normalizeBlinkHz(toLED.BlinkHz)
-		if fromHz != toHz {
+		if !blinkEqual(fromLED.BlinkHz, toLED.BlinkHz, profile) {
 			if fromHz == 0 && toHz > 0 {
 				changes = append(changes, fmt.Sprintf("solid→blinking %.1fHz", toHz))
 			} else if fromHz > 0 && toHz == 0 {
@@ -313,6 +381,16 @@ func describeChange(from, to NormalizedSignal) string {
 			}
 		}
 
+		// Blink code change
+		if fromLED.BlinkCode != toLED.BlinkCode {
+			changes = append(changes, fmt.Sprintf("code: %s→%s", blinkCodeOrNone(fromLED.BlinkCode), blinkCodeOrNone(toLED.BlinkCode)))
+		}
+
+		// Brightness change
+		if !brightnessEqual(fromLED, toLED, profile) {
+			changes = append(changes, fmt.Sprintf("brightness: %d%%→%d%%", fromLED.Brightness, toLED.Brightness))
+		}
+
 		if len(changes) == 0 {
 			return ""
 		}
@@ -346,12 +424,27 @@ func describeChange(from, to NormalizedSignal) string {
 	case "boot_timing":
 		fromBoot := from.Signal.(core.BootTimingSignal)
 		toBoot := to.Signal.(core.BootTimingSignal)
-		return fmt.Sprintf("duration: %dms→%dms", fromBoot.DurationMs, toBoot.DurationMs)
+		change := fmt.Sprintf("duration: %s→%s", formatBootDuration(fromBoot), formatBootDuration(toBoot))
+		for _, m := range toBoot.Milestones {
+			if prev, ok := fromBoot.Milestone(m.Name); ok && prev.OffsetMs != m.OffsetMs {
+				change += fmt.Sprintf(", %s: %dms→%dms", m.Name, prev.OffsetMs, m.OffsetMs)
+			}
+		}
+		return change
 	}
 
 	return ""
 }
 
+// formatBootDuration renders a boot time, or how long a boot that never
+// settled was watched
+func formatBootDuration(boot core.BootTimingSignal) string {
+	if boot.Incomplete {
+		return fmt.Sprintf("not steady after %dms", boot.DurationMs)
+	}
+	return fmt.Sprintf("%dms", boot.DurationMs)
+}
+
 func formatDisplayHistory(history []core.DisplayTextEntry) string {
 	if len(history) == 0 {
 		return ""
@@ -1,10 +1,12 @@
 package diff
 
 import (
+	"reflect"
 	"testing"
 	"time"
 
 	"github.com/perceptumx/percepta/internal/core"
+	"github.com/perceptumx/percepta/internal/tolerance"
 )
 
 func TestCompare_NoChanges(t *testing.T) {
@@ -216,6 +218,98 @@ func TestCompare_LEDBlinkRateChange(t *testing.T) {
 	}
 }
 
+func TestCompare_LEDBlinkCodeChange(t *testing.T) {
+	from := &core.Observation{
+		ID:       "obs1",
+		DeviceID: "router",
+		Signals: []core.Signal{
+			core.LEDSignal{Name: "err", On: true, BlinkHz: 1.0, BlinkCode: "3-2"},
+		},
+	}
+
+	to := &core.Observation{
+		ID:       "obs2",
+		DeviceID: "router",
+		Signals: []core.Signal{
+			core.LEDSignal{Name: "err", On: true, BlinkHz: 1.0, BlinkCode: "1-4"},
+		},
+	}
+
+	result := Compare(from, to)
+
+	if !result.HasChanges() {
+		t.Fatal("Expected blink code change, but got none")
+	}
+	if got := result.Changes[0].Details; got != "code: 3-2→1-4" {
+		t.Errorf("Expected code change in description, got %q", got)
+	}
+}
+
+func TestCompareWithTolerance_LEDBrightnessChange(t *testing.T) {
+	from := &core.Observation{
+		ID:       "obs1",
+		DeviceID: "panel",
+		Signals: []core.Signal{
+			core.LEDSignal{Name: "backlight", On: true, Brightness: 50},
+			core.LEDSignal{Name: "power", On: true, Brightness: 90},
+			core.LEDSignal{Name: "status", On: true},
+		},
+	}
+
+	to := &core.Observation{
+		ID:       "obs2",
+		DeviceID: "panel",
+		Signals: []core.Signal{
+			core.LEDSignal{Name: "backlight", On: true, Brightness: 25},
+			core.LEDSignal{Name: "power", On: true, Brightness: 84},  // Within ±10 points
+			core.LEDSignal{Name: "status", On: true, Brightness: 60}, // Not measured before
+		},
+	}
+
+	result := CompareWithTolerance(from, to, tolerance.Default())
+	if len(result.Changes) != 1 || result.Changes[0].Name != "backlight" {
+		t.Fatalf("Expected only the backlight to change, got %+v", result.Changes)
+	}
+	if got := result.Changes[0].Details; got != "brightness: 50%→25%" {
+		t.Errorf("Expected brightness change in description, got %q", got)
+	}
+	if got := result.Changes[0].ToState; got != "ON solid 25%" {
+		t.Errorf("Expected brightness in state, got %q", got)
+	}
+}
+
+func TestCompare_BootTimingChange(t *testing.T) {
+	boot := func(steady, text int64) core.BootTimingSignal {
+		return core.BootTimingSignal{
+			DurationMs: steady,
+			Confidence: 0.9,
+			Milestones: []core.BootMilestone{
+				{Name: core.MilestoneFirstLED, OffsetMs: 100},
+				{Name: core.MilestoneFirstText, OffsetMs: text},
+				{Name: core.MilestoneSteady, OffsetMs: steady},
+			},
+		}
+	}
+	from := &core.Observation{ID: "obs1", DeviceID: "board", Signals: []core.Signal{boot(2400, 1450)}}
+	to := &core.Observation{ID: "obs2", DeviceID: "board", Signals: []core.Signal{boot(3100, 2150)}}
+
+	result := Compare(from, to)
+	if len(result.Changes) != 1 {
+		t.Fatalf("Expected the boot timing to change, got %+v", result.Changes)
+	}
+	want := "duration: 2400ms→3100ms, first_text: 1450ms→2150ms, steady: 2400ms→3100ms"
+	if got := result.Changes[0].Details; got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+
+	incomplete := boot(15000, 1450)
+	incomplete.Incomplete = true
+	to.Signals = []core.Signal{incomplete}
+	if got := Compare(from, to).Changes[0].ToState; got != "not steady after 15000ms" {
+		t.Errorf("Expected an unsettled boot in the state, got %q", got)
+	}
+}
+
 func TestCompare_BlinkHzNormalization(t *testing.T) {
 	// Test that slight variations in BlinkHz are normalized (rounded to 1 decimal)
 	from := &core.Observation{
@@ -279,6 +373,63 @@ func TestCompare_ExactColorComparison(t *testing.T) {
 	}
 }
 
+func TestCompareWithTolerance_IgnoresCameraNoise(t *testing.T) {
+	from := &core.Observation{
+		ID:       "obs1",
+		DeviceID: "board",
+		Signals: []core.Signal{
+			core.LEDSignal{Name: "status", On: true, Color: core.RGB{B: 255}, BlinkHz: 2.0},
+			core.DisplaySignal{Name: "LCD", Text: "Connecting"},
+		},
+	}
+
+	to := &core.Observation{
+		ID:       "obs2",
+		DeviceID: "board",
+		Signals: []core.Signal{
+			core.LEDSignal{Name: "status", On: true, Color: core.RGB{R: 40, G: 60, B: 230}, BlinkHz: 2.3},
+			core.DisplaySignal{Name: "LCD", Text: "Connectinq"},
+		},
+	}
+
+	if result := Compare(from, to); len(result.Changes) != 2 {
+		t.Fatalf("Expected exact comparison to report both signals, got %d changes", len(result.Changes))
+	}
+
+	blink, fuzziness := 20.0, 0.2
+	noisy := tolerance.Merge(tolerance.Overrides{BlinkPercent: &blink, ColorMetric: tolerance.MetricHue, OCRFuzziness: &fuzziness})
+	if result := CompareWithTolerance(from, to, noisy); result.HasChanges() {
+		t.Errorf("Expected differences within tolerance to be ignored, got %+v", result.Changes)
+	}
+}
+
+func TestCompareWithTolerance_ReportsChangesBeyondTolerance(t *testing.T) {
+	from := &core.Observation{
+		ID:       "obs1",
+		DeviceID: "board",
+		Signals: []core.Signal{
+			core.LEDSignal{Name: "status", On: true, Color: core.RGB{B: 255}, BlinkHz: 2.0},
+		},
+	}
+
+	to := &core.Observation{
+		ID:       "obs2",
+		DeviceID: "board",
+		Signals: []core.Signal{
+			core.LEDSignal{Name: "status", On: true, Color: core.RGB{B: 250}, BlinkHz: 3.0},
+		},
+	}
+
+	result := CompareWithTolerance(from, to, tolerance.Default())
+	if len(result.Changes) != 1 {
+		t.Fatalf("Expected 1 change, got %d", len(result.Changes))
+	}
+	// The color drift is within ±5 and must not be described as a change
+	if got := result.Changes[0].Details; got != "blink: 2.0Hz→3.0Hz" {
+		t.Errorf("Expected only the blink change, got %q", got)
+	}
+}
+
 func TestCompare_ConfidenceIgnored(t *testing.T) {
 	// Test that confidence values are ignored in comparison
 	from := &core.Observation{
@@ -381,6 +532,39 @@ func TestCompare_CountByType(t *testing.T) {
 	}
 }
 
+func TestSignalChange_String(t *testing.T) {
+	tests := []struct {
+		change SignalChange
+		want   string
+	}{
+		{SignalChange{Type: ChangeAdded, Name: "LED4", ToState: "ON"}, "+ LED4: ON (ADDED)"},
+		{SignalChange{Type: ChangeRemoved, Name: "LED3", FromState: "ON"}, "- LED3: ON (REMOVED)"},
+		{SignalChange{Type: ChangeModified, Name: "LED1", FromState: "ON", ToState: "OFF"}, "~ LED1: ON → OFF (MODIFIED)"},
+		{SignalChange{Type: ChangeModified, Name: "LED1", FromState: "ON", ToState: "OFF", Details: "turned off"}, "~ LED1: turned off (MODIFIED)"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.change.String(); got != tt.want {
+			t.Errorf("Expected %q, got %q", tt.want, got)
+		}
+	}
+}
+
+func TestDescribe(t *testing.T) {
+	obs := &core.Observation{
+		Signals: []core.Signal{
+			core.LEDSignal{Name: "status", On: true, BlinkHz: 2},
+			core.DisplaySignal{Name: "LCD", Text: "Ready"},
+			core.LEDSignal{Name: "power", On: true, ColorName: "green"},
+		},
+	}
+
+	want := []string{`LCD: "Ready"`, "power: ON green solid", "status: ON blinking 2.0Hz"}
+	if got := Describe(obs); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+}
+
 func TestCompare_DisplayHistoryChange(t *testing.T) {
 	from := &core.Observation{
 		ID:           "obs1",
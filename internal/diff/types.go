@@ -1,6 +1,10 @@
 package diff
 
-import "github.com/perceptumx/percepta/internal/core"
+import (
+	"fmt"
+
+	"github.com/perceptumx/percepta/internal/core"
+)
 
 // ChangeType represents the type of change detected
 type ChangeType string
@@ -20,6 +24,21 @@ type SignalChange struct {
 	Details   string // Additional details about the change
 }
 
+// String formats the change as listed by `percepta diff`,
+// e.g. "~ LED1: ON → OFF (MODIFIED)"
+func (c SignalChange) String() string {
+	switch c.Type {
+	case ChangeAdded:
+		return fmt.Sprintf("+ %s: %s (ADDED)", c.Name, c.ToState)
+	case ChangeRemoved:
+		return fmt.Sprintf("- %s: %s (REMOVED)", c.Name, c.FromState)
+	}
+	if c.Details != "" {
+		return fmt.Sprintf("~ %s: %s (MODIFIED)", c.Name, c.Details)
+	}
+	return fmt.Sprintf("~ %s: %s → %s (MODIFIED)", c.Name, c.FromState, c.ToState)
+}
+
 // DiffResult contains all changes detected between two observations
 type DiffResult struct {
 	DeviceID      string
@@ -10,6 +10,7 @@ type UserError struct {
 	Message    string
 	Suggestion string
 	DocsURL    string
+	Err        error // underlying cause, if any, for errors.Is
 }
 
 func (e *UserError) Error() string {
@@ -26,6 +27,10 @@ func (e *UserError) Error() string {
 	return b.String()
 }
 
+func (e *UserError) Unwrap() error {
+	return e.Err
+}
+
 // Common error constructors
 
 func MissingAPIKey(service string) error {
@@ -61,6 +66,14 @@ func CameraNotFound(cameraPath string) error {
 	}
 }
 
+func VisionInitFailed(err error) error {
+	return &UserError{
+		Message:    fmt.Sprintf("Failed to initialize vision: %v", err),
+		Suggestion: "Set ANTHROPIC_API_KEY, or set 'vision.provider: local' and configure the device's LED regions to observe without network access",
+		DocsURL:    "https://github.com/Perceptax/percepta/blob/main/docs/installation.md#vision-providers",
+	}
+}
+
 func ConfigNotFound() error {
 	return &UserError{
 		Message:    "No config file found at ~/.config/percepta/config.yaml",
@@ -95,12 +108,37 @@ func ObservationFailed(err error) error {
 
 func AssertionTimeout(signal string) error {
 	return &UserError{
-		Message:    fmt.Sprintf("Assertion timeout: signal '%s' not found in observation", signal),
-		Suggestion: "Run 'percepta observe <device>' first to see available signals",
+		Message:    fmt.Sprintf("Assertion timeout: '%s' was not satisfied before the deadline", signal),
+		Suggestion: "Run 'percepta observe <device>' to check the signal is visible, or widen the time window",
 		DocsURL:    "https://github.com/Perceptax/percepta/blob/main/docs/getting-started.md#step-4-your-first-assertion",
 	}
 }
 
+func StoredObservationNotFound(err error) error {
+	return &UserError{
+		Message:    fmt.Sprintf("Stored observation not found: %v", err),
+		Suggestion: "Capture one with 'percepta observe <device>' or check the firmware tag with 'percepta device list'",
+		DocsURL:    "https://github.com/Perceptax/percepta/blob/main/docs/commands.md#percepta-assert",
+	}
+}
+
+func GoldenNotSet(deviceID string, err error) error {
+	return &UserError{
+		Message:    fmt.Sprintf("No golden observation for device '%s'", deviceID),
+		Suggestion: fmt.Sprintf("Mark one with 'percepta golden set %s' or 'percepta assert %s --matches-golden --update-golden'", deviceID, deviceID),
+		DocsURL:    "https://github.com/Perceptax/percepta/blob/main/docs/commands.md#percepta-golden",
+		Err:        err,
+	}
+}
+
+func BootTriggerMissing(deviceID string) error {
+	return &UserError{
+		Message:    fmt.Sprintf("Boot timing of '%s' needs a reset command: stdin is not a terminal, so Enter cannot be pressed at power-on", deviceID),
+		Suggestion: fmt.Sprintf("Set boot.reset_command for device '%s' in ~/.config/percepta/config.yaml, or pass --reset-cmd to 'percepta observe --boot'", deviceID),
+		DocsURL:    "https://github.com/Perceptax/percepta/blob/main/docs/commands.md#percepta-observe",
+	}
+}
+
 func InvalidSpec(err error) error {
 	return &UserError{
 		Message:    fmt.Sprintf("Invalid specification: %v", err),
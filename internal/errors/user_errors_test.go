@@ -1,6 +1,7 @@
 package errors
 
 import (
+	stderrors "errors"
 	"strings"
 	"testing"
 )
@@ -185,6 +186,21 @@ func TestCameraNotFound(t *testing.T) {
 	}
 }
 
+func TestVisionInitFailed(t *testing.T) {
+	err := VisionInitFailed(&UserError{Message: "ANTHROPIC_API_KEY not set"})
+	errMsg := err.Error()
+
+	// Verify message wraps the cause
+	if !strings.Contains(errMsg, "Failed to initialize vision: ANTHROPIC_API_KEY not set") {
+		t.Errorf("Expected message to contain the cause, got: %s", errMsg)
+	}
+
+	// Verify suggestion offers the offline provider
+	if !strings.Contains(errMsg, "vision.provider: local") {
+		t.Errorf("Expected suggestion to mention the local provider, got: %s", errMsg)
+	}
+}
+
 func TestConfigNotFound(t *testing.T) {
 	err := ConfigNotFound()
 	errMsg := err.Error()
@@ -302,6 +318,51 @@ func TestAssertionTimeout(t *testing.T) {
 	}
 }
 
+func TestStoredObservationNotFound(t *testing.T) {
+	err := StoredObservationNotFound(&UserError{Message: "no observation with id abc"})
+	errMsg := err.Error()
+
+	if !strings.Contains(errMsg, "no observation with id abc") {
+		t.Errorf("Expected original error to be included, got: %s", errMsg)
+	}
+
+	// Verify suggestion mentions observe command
+	if !strings.Contains(errMsg, "percepta observe") {
+		t.Errorf("Expected suggestion to mention 'percepta observe', got: %s", errMsg)
+	}
+
+	if !strings.Contains(errMsg, "Docs:") {
+		t.Errorf("Expected docs URL, got: %s", errMsg)
+	}
+}
+
+func TestGoldenNotSet(t *testing.T) {
+	errMsg := GoldenNotSet("my-board", nil).Error()
+
+	if !strings.Contains(errMsg, "'my-board'") {
+		t.Errorf("Expected device name in message, got: %s", errMsg)
+	}
+	if !strings.Contains(errMsg, "percepta golden set my-board") {
+		t.Errorf("Expected suggestion to mention 'percepta golden set', got: %s", errMsg)
+	}
+
+	cause := stderrors.New("no golden observation")
+	if !stderrors.Is(GoldenNotSet("my-board", cause), cause) {
+		t.Error("Expected the cause to stay reachable with errors.Is")
+	}
+}
+
+func TestBootTriggerMissing(t *testing.T) {
+	errMsg := BootTriggerMissing("my-board").Error()
+
+	if !strings.Contains(errMsg, "'my-board'") {
+		t.Errorf("Expected device name in message, got: %s", errMsg)
+	}
+	if !strings.Contains(errMsg, "boot.reset_command") || !strings.Contains(errMsg, "--reset-cmd") {
+		t.Errorf("Expected suggestion to mention both ways to set a reset command, got: %s", errMsg)
+	}
+}
+
 func TestInvalidSpec(t *testing.T) {
 	originalErr := &UserError{Message: "unexpected token"}
 	err := InvalidSpec(originalErr)
@@ -421,11 +482,15 @@ func TestAllErrors_HaveDocsURL(t *testing.T) {
 		DeviceNotFound("test"),
 		InvalidBoardType("test"),
 		CameraNotFound("test"),
+		VisionInitFailed(&UserError{Message: "test"}),
 		ConfigNotFound(),
 		NoDevicesConfigured(),
 		StorageInitFailed(&UserError{Message: "test"}),
 		ObservationFailed(&UserError{Message: "test"}),
 		AssertionTimeout("test"),
+		StoredObservationNotFound(&UserError{Message: "test"}),
+		GoldenNotSet("test", nil),
+		BootTriggerMissing("test"),
 		InvalidSpec(&UserError{Message: "test"}),
 		CodeGenerationFailed(&UserError{Message: "test"}),
 		StyleCheckFailed(1),
@@ -449,11 +514,15 @@ func TestAllErrors_HaveSuggestion(t *testing.T) {
 		DeviceNotFound("test"),
 		InvalidBoardType("test"),
 		CameraNotFound("test"),
+		VisionInitFailed(&UserError{Message: "test"}),
 		ConfigNotFound(),
 		NoDevicesConfigured(),
 		StorageInitFailed(&UserError{Message: "test"}),
 		ObservationFailed(&UserError{Message: "test"}),
 		AssertionTimeout("test"),
+		StoredObservationNotFound(&UserError{Message: "test"}),
+		GoldenNotSet("test", nil),
+		BootTriggerMissing("test"),
 		InvalidSpec(&UserError{Message: "test"}),
 		CodeGenerationFailed(&UserError{Message: "test"}),
 		StyleCheckFailed(1),
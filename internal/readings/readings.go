@@ -0,0 +1,130 @@
+// Package readings parses named values such as "TEMP 23.4C" or "V=3.29" out
+// of display text, so assertions can compare numbers instead of substrings.
+package readings
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/perceptumx/percepta/internal/core"
+)
+
+// keyValuePattern finds "<key> <number><unit>" pairs: a word, an optional ':'
+// or '=', a number (dotted sequences like IP addresses are kept as text) and
+// a unit written directly after the number.
+var keyValuePattern = regexp.MustCompile(`([A-Za-z][A-Za-z_]*)\s*[:=]?\s*(-?\d+(?:\.\d+)*)(%|°[CF]?|[A-Za-z]{1,3}\b)?`)
+
+// Pattern extracts one named value with a configured regular expression.
+// The first capture group is the value, an optional second one the unit.
+type Pattern struct {
+	Key string
+	re  *regexp.Regexp
+}
+
+// Compile validates configured patterns (value name → regex), sorted by name
+func Compile(patterns map[string]string) ([]Pattern, error) {
+	keys := make([]string, 0, len(patterns))
+	for key := range patterns {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	compiled := make([]Pattern, 0, len(keys))
+	for _, key := range keys {
+		re, err := regexp.Compile(patterns[key])
+		if err != nil {
+			return nil, fmt.Errorf("value '%s': %w", key, err)
+		}
+		if re.NumSubexp() < 1 {
+			return nil, fmt.Errorf("value '%s': pattern needs a capture group for the value", key)
+		}
+		compiled = append(compiled, Pattern{Key: strings.ToLower(key), re: re})
+	}
+	return compiled, nil
+}
+
+// Extract finds key/value pairs in display text. Keys are lower case; when a
+// key appears twice the first occurrence wins.
+func Extract(text string) map[string]core.DisplayValue {
+	values := make(map[string]core.DisplayValue)
+	for _, m := range keyValuePattern.FindAllStringSubmatch(text, -1) {
+		key := strings.ToLower(m[1])
+		if _, seen := values[key]; !seen {
+			values[key] = NewValue(m[2], m[3])
+		}
+	}
+	return values
+}
+
+// Match applies configured patterns to display text
+func Match(text string, patterns []Pattern) map[string]core.DisplayValue {
+	values := make(map[string]core.DisplayValue)
+	for _, p := range patterns {
+		m := p.re.FindStringSubmatch(text)
+		if m == nil {
+			continue
+		}
+		unit := ""
+		if len(m) > 2 {
+			unit = m[2]
+		}
+		values[p.Key] = NewValue(strings.TrimSpace(m[1]), strings.TrimSpace(unit))
+	}
+	return values
+}
+
+// NewValue builds a display value, parsing the text as a number when possible
+func NewValue(text, unit string) core.DisplayValue {
+	v := core.DisplayValue{Text: text, Unit: unit}
+	if n, err := strconv.ParseFloat(text, 64); err == nil {
+		v.Number = &n
+	}
+	return v
+}
+
+// Lookup returns a display's value by key (case-insensitive): values recorded
+// on the signal first, then pairs found in its text.
+func Lookup(display core.DisplaySignal, key string) (core.DisplayValue, bool) {
+	key = strings.ToLower(key)
+	if v, ok := display.Values[key]; ok {
+		return v, true
+	}
+	v, ok := Extract(display.Text)[key]
+	return v, ok
+}
+
+// Apply records the values matched by each display's configured patterns
+// (display name → patterns, compared case-insensitively) on the observation.
+// Configured values replace values of the same key reported by vision.
+func Apply(obs *core.Observation, patterns map[string][]Pattern) {
+	if len(patterns) == 0 {
+		return
+	}
+	for i, sig := range obs.Signals {
+		display, ok := sig.(core.DisplaySignal)
+		if !ok {
+			continue
+		}
+		for name, displayPatterns := range patterns {
+			if !strings.EqualFold(name, display.Name) {
+				continue
+			}
+			matched := Match(display.Text, displayPatterns)
+			if len(matched) == 0 {
+				continue
+			}
+			values := make(map[string]core.DisplayValue, len(display.Values)+len(matched))
+			for k, v := range display.Values {
+				values[k] = v
+			}
+			for k, v := range matched {
+				values[k] = v
+			}
+			display.Values = values
+			obs.Signals[i] = display
+		}
+	}
+}
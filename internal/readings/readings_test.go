@@ -0,0 +1,102 @@
+package readings
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/perceptumx/percepta/internal/core"
+)
+
+func TestExtract(t *testing.T) {
+	values := Extract("TEMP 23.4C V=3.29 Hum: 45% IP 192.168.1.4 ERR42")
+
+	tests := []struct {
+		key    string
+		text   string
+		number float64
+		unit   string
+	}{
+		{"temp", "23.4", 23.4, "C"},
+		{"v", "3.29", 3.29, ""},
+		{"hum", "45", 45, "%"},
+		{"err", "42", 42, ""},
+	}
+	for _, tt := range tests {
+		v, ok := values[tt.key]
+		if !ok {
+			t.Errorf("Expected value %q in %+v", tt.key, values)
+			continue
+		}
+		if v.Text != tt.text || v.Number == nil || *v.Number != tt.number || v.Unit != tt.unit {
+			t.Errorf("Value %q = %+v, want %s%s", tt.key, v, tt.text, tt.unit)
+		}
+	}
+
+	ip := values["ip"]
+	if ip.Text != "192.168.1.4" || ip.Number != nil {
+		t.Errorf("Expected IP address to be kept as text, got %+v", ip)
+	}
+}
+
+func TestExtract_NegativeAndFirstWins(t *testing.T) {
+	values := Extract("T -4.5C T 20C")
+	if v := values["t"]; v.Number == nil || *v.Number != -4.5 {
+		t.Errorf("Expected first negative reading -4.5, got %+v", v)
+	}
+}
+
+func TestCompileAndMatch(t *testing.T) {
+	patterns, err := Compile(map[string]string{
+		"Temp":    `T(?:EMP)?\s*(-?[\d.]+)\s*°?([CF])`,
+		"voltage": `(\d\.\d+)\s*V`,
+	})
+	if err != nil {
+		t.Fatalf("Compile failed: %v", err)
+	}
+
+	values := Match("ROOM T 21.5 °C / 3.31 V", patterns)
+	if v := values["temp"]; v.Number == nil || *v.Number != 21.5 || v.Unit != "C" {
+		t.Errorf("Expected temp 21.5 C, got %+v", v)
+	}
+	if v := values["voltage"]; v.Number == nil || *v.Number != 3.31 || v.Unit != "" {
+		t.Errorf("Expected voltage 3.31, got %+v", v)
+	}
+
+	if _, err := Compile(map[string]string{"temp": `TEMP [\d.]+`}); err == nil || !strings.Contains(err.Error(), "capture group") {
+		t.Errorf("Expected missing capture group error, got %v", err)
+	}
+	if _, err := Compile(map[string]string{"temp": `(`}); err == nil {
+		t.Error("Expected invalid regex error")
+	}
+}
+
+func TestApplyAndLookup(t *testing.T) {
+	patterns, _ := Compile(map[string]string{"volts": `(\d+\.\d+)V`})
+	obs := &core.Observation{
+		Signals: []core.Signal{
+			core.DisplaySignal{
+				Name:   "LCD",
+				Text:   "OUT 3.30V TEMP 22C",
+				Values: map[string]core.DisplayValue{"volts": NewValue("3.3", "V"), "mode": NewValue("eco", "")},
+			},
+			core.DisplaySignal{Name: "OLED", Text: "1.25V"},
+		},
+	}
+
+	Apply(obs, map[string][]Pattern{"lcd": patterns})
+
+	lcd := obs.Signals[0].(core.DisplaySignal)
+	if v := lcd.Values["volts"]; v.Text != "3.30" {
+		t.Errorf("Expected configured pattern to replace the vision value, got %+v", v)
+	}
+	if _, ok := lcd.Values["mode"]; !ok {
+		t.Error("Expected other vision values to be kept")
+	}
+	if v, ok := Lookup(lcd, "TEMP"); !ok || *v.Number != 22 {
+		t.Errorf("Expected Lookup to fall back to pairs in the text, got %+v, %v", v, ok)
+	}
+
+	if oled := obs.Signals[1].(core.DisplaySignal); oled.Values != nil {
+		t.Errorf("Expected display without patterns to be left alone, got %+v", oled.Values)
+	}
+}
@@ -0,0 +1,139 @@
+package report
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/perceptumx/percepta/internal/assertions"
+)
+
+// JUnit XML, in the dialect understood by Jenkins and GitLab: one testsuite
+// per case, one testcase per assertion. INCONCLUSIVE maps to skipped.
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Errors   int              `xml:"errors,attr"`
+	Skipped  int              `xml:"skipped,attr"`
+	Time     string           `xml:"time,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name       string          `xml:"name,attr"`
+	Tests      int             `xml:"tests,attr"`
+	Failures   int             `xml:"failures,attr"`
+	Errors     int             `xml:"errors,attr"`
+	Skipped    int             `xml:"skipped,attr"`
+	Time       string          `xml:"time,attr"`
+	Timestamp  string          `xml:"timestamp,attr"`
+	Properties []junitProperty `xml:"properties>property"`
+	Cases      []junitTestCase `xml:"testcase"`
+}
+
+type junitProperty struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitMessage `xml:"failure,omitempty"`
+	Error     *junitMessage `xml:"error,omitempty"`
+	Skipped   *junitMessage `xml:"skipped,omitempty"`
+	SystemOut *junitCData   `xml:"system-out,omitempty"`
+}
+
+type junitCData struct {
+	Text string `xml:",cdata"`
+}
+
+type junitMessage struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr,omitempty"`
+	Body    string `xml:",cdata"`
+}
+
+func (r *Report) writeJUnit(w io.Writer) error {
+	doc := junitTestSuites{
+		Name:     "percepta",
+		Tests:    r.Summary.Total,
+		Failures: r.Summary.Failed,
+		Errors:   r.Summary.Errors,
+		Skipped:  r.Summary.Inconclusive,
+		Time:     seconds(r.DurationMs),
+	}
+
+	for _, c := range r.Cases {
+		suite := junitTestSuite{
+			Name:       r.Device + "/" + c.Name,
+			Time:       seconds(c.DurationMs),
+			Timestamp:  r.StartedAt.UTC().Format(time.RFC3339),
+			Properties: []junitProperty{{Name: "device", Value: r.Device}},
+		}
+		for _, p := range []junitProperty{
+			{Name: "firmware", Value: r.Firmware},
+			{Name: "observation_id", Value: c.ObservationID},
+			{Name: "suite", Value: r.Suite},
+		} {
+			if p.Value != "" {
+				suite.Properties = append(suite.Properties, p)
+			}
+		}
+
+		classname := "percepta." + r.Device
+		for _, res := range c.Results {
+			tc := junitTestCase{
+				Name:      res.Assertion,
+				ClassName: classname,
+				Time:      seconds(res.DurationMs),
+			}
+			switch res.Outcome {
+			case assertions.OutcomeFail:
+				tc.Failure = &junitMessage{Message: res.Message, Type: string(res.Outcome), Body: res.details()}
+				suite.Failures++
+			case assertions.OutcomeInconclusive:
+				tc.Skipped = &junitMessage{Message: "INCONCLUSIVE: " + res.Message}
+				tc.SystemOut = &junitCData{Text: res.details()}
+				suite.Skipped++
+			default:
+				tc.SystemOut = &junitCData{Text: res.details()}
+			}
+			suite.Cases = append(suite.Cases, tc)
+		}
+
+		// Assertions not evaluated because the observation failed
+		for _, name := range c.NotRun {
+			suite.Cases = append(suite.Cases, junitTestCase{
+				Name:      name,
+				ClassName: classname,
+				Time:      seconds(0),
+				Error:     &junitMessage{Message: c.Error, Type: "ERROR"},
+			})
+			suite.Errors++
+		}
+		suite.Tests = len(suite.Cases)
+		doc.Suites = append(doc.Suites, suite)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+func seconds(ms int64) string {
+	return fmt.Sprintf("%.3f", float64(ms)/1000)
+}
@@ -0,0 +1,265 @@
+// Package report renders assertion results for CI systems as JSON, JUnit XML
+// or TAP, so hardware checks show up next to unit tests in test dashboards.
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/perceptumx/percepta/internal/assertions"
+)
+
+// Format selects how a report is rendered
+type Format string
+
+const (
+	FormatText  Format = "text" // Human-readable output; no report is written
+	FormatJSON  Format = "json"
+	FormatJUnit Format = "junit"
+	FormatTAP   Format = "tap"
+)
+
+// ParseFormat validates a --format value (case-insensitive)
+func ParseFormat(s string) (Format, error) {
+	switch f := Format(strings.ToLower(strings.TrimSpace(s))); f {
+	case FormatText, FormatJSON, FormatJUnit, FormatTAP:
+		return f, nil
+	case "":
+		return FormatText, nil
+	}
+	return "", fmt.Errorf("unknown report format %q (expected text, json, junit or tap)", s)
+}
+
+// FormatForPath guesses the format from a report file's extension
+// (.json, .xml, .tap), or returns "" if it cannot tell
+func FormatForPath(path string) Format {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return FormatJSON
+	case ".xml":
+		return FormatJUnit
+	case ".tap":
+		return FormatTAP
+	}
+	return ""
+}
+
+// Report is the outcome of one `percepta assert` run
+type Report struct {
+	Device     string             `json:"device"`
+	Firmware   string             `json:"firmware,omitempty"`
+	Suite      string             `json:"suite,omitempty"` // Suite file, if one was run
+	StartedAt  time.Time          `json:"started_at"`
+	DurationMs int64              `json:"duration_ms"`
+	Outcome    assertions.Outcome `json:"outcome"`
+	Summary    Summary            `json:"summary"`
+	Cases      []Case             `json:"cases"`
+}
+
+// Summary counts assertions by outcome; assertions not run because their
+// case failed to observe count as errors
+type Summary struct {
+	Total        int `json:"total"`
+	Passed       int `json:"passed"`
+	Failed       int `json:"failed"`
+	Inconclusive int `json:"inconclusive"`
+	Errors       int `json:"errors"`
+}
+
+// Case is a named group of assertions evaluated together
+type Case struct {
+	Name          string             `json:"name"`
+	ObservationID string             `json:"observation_id,omitempty"`
+	DurationMs    int64              `json:"duration_ms"`
+	Outcome       assertions.Outcome `json:"outcome"`
+	Error         string             `json:"error,omitempty"`
+	Results       []Result           `json:"results"`
+	NotRun        []string           `json:"not_run,omitempty"` // Assertions skipped because of Error
+}
+
+// Result mirrors assertions.AssertionResult, adding the assertion text and outcome
+type Result struct {
+	Assertion     string             `json:"assertion,omitempty"` // Empty for clauses, which are named by Expected
+	Outcome       assertions.Outcome `json:"outcome"`
+	Passed        bool               `json:"passed"`
+	Inconclusive  bool               `json:"inconclusive"`
+	Expected      string             `json:"expected"`
+	Actual        string             `json:"actual"`
+	Confidence    float64            `json:"confidence"`
+	Message       string             `json:"message,omitempty"`
+	Op            assertions.Op      `json:"op,omitempty"`
+	Text          *TextMatch         `json:"text,omitempty"`
+	ObservationID string             `json:"observation_id,omitempty"`
+	ObservedAt    *time.Time         `json:"observed_at,omitempty"`
+	DurationMs    int64              `json:"duration_ms"`
+	SignalMissing bool               `json:"signal_missing,omitempty"`
+	Children      []Result           `json:"children,omitempty"`
+	Quorum        string             `json:"quorum,omitempty"`   // K-of-N vote that decided the result, e.g. 2-of-3
+	Attempts      []Result           `json:"attempts,omitempty"` // Each attempt of the vote, in order
+}
+
+// TextMatch is the closest display text found by a text assertion
+type TextMatch struct {
+	Closest    string  `json:"closest"`
+	Distance   int     `json:"distance"`
+	Similarity float64 `json:"similarity"`
+}
+
+// New builds a report from suite results. compiled lists each case's
+// assertions (as returned by SuiteCase.Compiled), in the same order as the
+// results; cases that stopped early on an error report the remaining
+// assertions as errors.
+func New(device, firmware string, result assertions.SuiteResult, compiled [][]assertions.Assertion, started time.Time, duration time.Duration) *Report {
+	r := &Report{
+		Device:     device,
+		Firmware:   firmware,
+		StartedAt:  started,
+		DurationMs: duration.Milliseconds(),
+		Outcome:    result.Outcome(),
+	}
+
+	for i, c := range result.Cases {
+		rc := Case{
+			Name:          c.Name,
+			ObservationID: c.ObservationID,
+			DurationMs:    c.Duration.Milliseconds(),
+			Outcome:       c.Outcome(),
+			Results:       []Result{},
+		}
+		if c.Err != nil {
+			rc.Error = c.Err.Error()
+		}
+
+		for j, a := range compiled[i] {
+			if j >= len(c.Results) {
+				rc.NotRun = append(rc.NotRun, a.String())
+				r.Summary.Errors++
+				continue
+			}
+			res := newResult(c.Results[j])
+			res.Assertion = a.String()
+			rc.Results = append(rc.Results, res)
+
+			switch res.Outcome {
+			case assertions.OutcomePass:
+				r.Summary.Passed++
+			case assertions.OutcomeFail:
+				r.Summary.Failed++
+			case assertions.OutcomeInconclusive:
+				r.Summary.Inconclusive++
+			}
+		}
+		r.Summary.Total += len(compiled[i])
+		r.Cases = append(r.Cases, rc)
+	}
+
+	return r
+}
+
+// Single builds a report for one assertion evaluated on its own
+func Single(device, firmware string, assertion assertions.Assertion, result assertions.AssertionResult, started time.Time, duration time.Duration) *Report {
+	suite := assertions.SuiteResult{
+		Device: device,
+		Cases: []assertions.CaseResult{{
+			Name:          assertion.String(),
+			ObservationID: result.ObservationID,
+			Results:       []assertions.AssertionResult{result},
+			Duration:      duration,
+		}},
+	}
+	return New(device, firmware, suite, [][]assertions.Assertion{{assertion}}, started, duration)
+}
+
+func newResult(a assertions.AssertionResult) Result {
+	r := Result{
+		Outcome:       a.Outcome(),
+		Passed:        a.Passed,
+		Inconclusive:  a.Inconclusive,
+		Expected:      a.Expected,
+		Actual:        a.Actual,
+		Confidence:    a.Confidence,
+		Message:       a.Message,
+		Op:            a.Op,
+		ObservationID: a.ObservationID,
+		DurationMs:    a.Duration.Milliseconds(),
+		SignalMissing: a.SignalMissing,
+	}
+	if !a.ObservedAt.IsZero() {
+		observedAt := a.ObservedAt
+		r.ObservedAt = &observedAt
+	}
+	if a.Text != nil {
+		r.Text = &TextMatch{Closest: a.Text.Closest, Distance: a.Text.Distance, Similarity: a.Text.Similarity}
+	}
+	for _, child := range a.Children {
+		r.Children = append(r.Children, newResult(child))
+	}
+	if len(a.Attempts) > 0 {
+		r.Quorum = a.Quorum.String()
+	}
+	for _, attempt := range a.Attempts {
+		r.Attempts = append(r.Attempts, newResult(attempt))
+	}
+	return r
+}
+
+// Write renders the report in the given format
+func (r *Report) Write(w io.Writer, format Format) error {
+	switch format {
+	case FormatJSON:
+		return r.writeJSON(w)
+	case FormatJUnit:
+		return r.writeJUnit(w)
+	case FormatTAP:
+		return r.writeTAP(w)
+	}
+	return fmt.Errorf("format %q does not produce a report", format)
+}
+
+func (r *Report) writeJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r)
+}
+
+// details is the multi-line explanation of a result used by JUnit and TAP
+func (r Result) details() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Expected: %s\n", r.Expected)
+	fmt.Fprintf(&b, "Actual: %s\n", r.Actual)
+	fmt.Fprintf(&b, "Confidence: %.2f\n", r.Confidence)
+	if r.ObservationID != "" {
+		fmt.Fprintf(&b, "Observation: %s\n", r.ObservationID)
+	}
+	if r.Message != "" {
+		fmt.Fprintf(&b, "Details: %s\n", r.Message)
+	}
+	writeClauses(&b, r.Children, 1)
+	if len(r.Attempts) > 0 {
+		fmt.Fprintf(&b, "Attempts (%s required):\n", r.Quorum)
+		for _, line := range r.attemptLines() {
+			fmt.Fprintf(&b, "  %s\n", line)
+		}
+	}
+	return b.String()
+}
+
+// attemptLines describes each attempt of a vote, e.g. "2. FAIL obs_42 [0.83] LED 'power' is OFF"
+func (r Result) attemptLines() []string {
+	lines := make([]string, len(r.Attempts))
+	for i, a := range r.Attempts {
+		lines[i] = fmt.Sprintf("%d. %s %s [%.2f] %s", i+1, a.Outcome, a.ObservationID, a.Confidence, a.Actual)
+	}
+	return lines
+}
+
+func writeClauses(b *strings.Builder, children []Result, depth int) {
+	for _, c := range children {
+		fmt.Fprintf(b, "%s%s %s [%.2f]\n", strings.Repeat("  ", depth), c.Outcome, c.Expected, c.Confidence)
+		writeClauses(b, c.Children, depth+1)
+	}
+}
@@ -0,0 +1,232 @@
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/perceptumx/percepta/internal/assertions"
+	"github.com/perceptumx/percepta/internal/core"
+)
+
+var started = time.Date(2026, 1, 15, 10, 30, 0, 0, time.UTC)
+
+// sampleReport evaluates a pass, a compound failure, an inconclusive result
+// and a case whose observation failed
+func sampleReport(t *testing.T) *Report {
+	t.Helper()
+	suite := &assertions.Suite{
+		MinConfidence: 0.5,
+		Cases: []assertions.SuiteCase{
+			{Name: "boot", Assertions: []string{"LED.power ON", "LED.error OFF && Display.LCD \"Ready\"", "LED.wifi ON"}},
+			{Name: "wifi", Assertions: []string{"LED.wifi BLINKING"}},
+		},
+	}
+	if err := suite.Compile(); err != nil {
+		t.Fatalf("Compile failed: %v", err)
+	}
+
+	result := suite.Run("bench-1", func(c *assertions.SuiteCase, resample bool) (*core.Observation, error) {
+		if c.Name == "wifi" {
+			return nil, errors.New("camera unplugged")
+		}
+		return &core.Observation{
+			ID:        "obs-1",
+			Timestamp: started,
+			Signals: []core.Signal{
+				core.LEDSignal{Name: "power", On: true, Confidence: 0.9},
+				core.LEDSignal{Name: "error", On: true, Confidence: 0.9},
+				core.LEDSignal{Name: "wifi", On: true, Confidence: 0.3},
+				core.DisplaySignal{Name: "LCD", Text: "Readv", Confidence: 0.8},
+			},
+		}, nil
+	})
+
+	compiled := [][]assertions.Assertion{suite.Cases[0].Compiled(), suite.Cases[1].Compiled()}
+	return New("bench-1", "v1.2", result, compiled, started, 1500*time.Millisecond)
+}
+
+func TestNew_Summary(t *testing.T) {
+	r := sampleReport(t)
+
+	want := Summary{Total: 4, Passed: 1, Failed: 1, Inconclusive: 1, Errors: 1}
+	if r.Summary != want {
+		t.Errorf("Expected summary %+v, got %+v", want, r.Summary)
+	}
+	if r.Outcome != assertions.OutcomeFail || r.DurationMs != 1500 {
+		t.Errorf("Unexpected outcome/duration: %s %dms", r.Outcome, r.DurationMs)
+	}
+	if len(r.Cases) != 2 || r.Cases[1].Error != "camera unplugged" || len(r.Cases[1].NotRun) != 1 {
+		t.Errorf("Expected the failed case to list its unevaluated assertion, got %+v", r.Cases)
+	}
+
+	failed := r.Cases[0].Results[1]
+	if failed.Assertion != `LED.error OFF && Display.LCD "Ready"` || len(failed.Children) != 2 {
+		t.Errorf("Expected the compound result with its clauses, got %+v", failed)
+	}
+	if failed.ObservationID != "obs-1" || failed.ObservedAt == nil || !failed.ObservedAt.Equal(started) {
+		t.Errorf("Expected observation details, got %+v", failed)
+	}
+	if text := failed.Children[1].Text; text == nil || text.Closest != "Readv" {
+		t.Errorf("Expected the display clause's closest text, got %+v", text)
+	}
+}
+
+func TestWrite_JSON(t *testing.T) {
+	var buf bytes.Buffer
+	if err := sampleReport(t).Write(&buf, FormatJSON); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+
+	var decoded map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("Invalid JSON: %v\n%s", err, buf.String())
+	}
+	for _, key := range []string{"device", "firmware", "started_at", "duration_ms", "outcome", "summary", "cases"} {
+		if _, ok := decoded[key]; !ok {
+			t.Errorf("Expected key %q in JSON report", key)
+		}
+	}
+	for _, want := range []string{`"observation_id": "obs-1"`, `"confidence": 0.3`, `"inconclusive": true`, `"closest": "Readv"`} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("Expected %s in JSON report", want)
+		}
+	}
+}
+
+func TestWrite_JUnit(t *testing.T) {
+	var buf bytes.Buffer
+	if err := sampleReport(t).Write(&buf, FormatJUnit); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+
+	var doc junitTestSuites
+	if err := xml.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("Invalid XML: %v\n%s", err, buf.String())
+	}
+	if doc.Tests != 4 || doc.Failures != 1 || doc.Skipped != 1 || doc.Errors != 1 || doc.Time != "1.500" {
+		t.Errorf("Unexpected totals: %+v", doc)
+	}
+	if len(doc.Suites) != 2 || doc.Suites[0].Name != "bench-1/boot" {
+		t.Fatalf("Expected one testsuite per case, got %+v", doc.Suites)
+	}
+
+	cases := doc.Suites[0].Cases
+	if cases[0].Failure != nil || cases[1].Failure == nil || cases[2].Skipped == nil {
+		t.Errorf("Expected pass, failure and skipped test cases, got %+v", cases)
+	}
+	if !strings.Contains(cases[1].Failure.Body, "Observation: obs-1") {
+		t.Errorf("Expected failure details, got %q", cases[1].Failure.Body)
+	}
+	if errCase := doc.Suites[1].Cases[0]; errCase.Error == nil || errCase.Error.Message != "camera unplugged" {
+		t.Errorf("Expected an error test case, got %+v", errCase)
+	}
+
+	props := doc.Suites[0].Properties
+	if len(props) < 3 || props[1].Name != "firmware" || props[1].Value != "v1.2" {
+		t.Errorf("Expected device/firmware/observation properties, got %+v", props)
+	}
+}
+
+func TestWrite_TAP(t *testing.T) {
+	var buf bytes.Buffer
+	if err := sampleReport(t).Write(&buf, FormatTAP); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	out := buf.String()
+
+	for _, want := range []string{
+		"TAP version 13\n1..4\n",
+		"ok 1 - boot: LED.power ON\n",
+		"not ok 2 - boot: LED.error OFF && Display.LCD \"Ready\"\n",
+		"ok 3 - boot: LED.wifi ON # SKIP inconclusive: ",
+		"not ok 4 - wifi: LED.wifi BLINKING\n",
+		"  observation_id: obs-1\n",
+		"  firmware: v1.2\n",
+		"  error: \"camera unplugged\"\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected %q in TAP output:\n%s", want, out)
+		}
+	}
+}
+
+func TestTAPEscape(t *testing.T) {
+	got := tapEscape("display shows \"#3\"\nretry C:\\x\n")
+	if want := `display shows "\#3" retry C:\\x`; got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+}
+
+func TestSingle(t *testing.T) {
+	assertion, _ := assertions.Parse("LED.power ON")
+	obs := &core.Observation{ID: "obs-2", Signals: []core.Signal{core.LEDSignal{Name: "power", On: true, Confidence: 0.9}}}
+	r := Single("dev", "", assertion, assertions.EvaluateSnapshot(assertion, obs), started, time.Second)
+
+	if r.Summary.Total != 1 || r.Summary.Passed != 1 || r.Cases[0].ObservationID != "obs-2" {
+		t.Errorf("Unexpected single-assertion report: %+v", r)
+	}
+
+	var buf bytes.Buffer
+	if err := r.Write(&buf, FormatTAP); err != nil || !strings.Contains(buf.String(), "ok 1 - LED.power ON\n") {
+		t.Errorf("Expected unprefixed TAP line, got %q (%v)", buf.String(), err)
+	}
+}
+
+func TestSingle_Attempts(t *testing.T) {
+	assertion, _ := assertions.Parse("LED.power ON")
+	calls := 0
+	observe := func() (*core.Observation, error) {
+		calls++
+		return &core.Observation{
+			ID:      fmt.Sprintf("obs-%d", calls),
+			Signals: []core.Signal{core.LEDSignal{Name: "power", On: calls > 1, Confidence: 0.9}},
+		}, nil
+	}
+	result, err := assertions.RunQuorum(assertion, nil, observe, 0, assertions.RetryQuorum(2))
+	if err != nil {
+		t.Fatalf("RunQuorum failed: %v", err)
+	}
+	r := Single("dev", "", assertion, result, started, time.Second)
+
+	res := r.Cases[0].Results[0]
+	if res.Quorum != "1-of-3" || len(res.Attempts) != 2 || res.Attempts[0].Outcome != assertions.OutcomeFail {
+		t.Fatalf("Expected both attempts in the report, got %+v", res)
+	}
+
+	var buf bytes.Buffer
+	if err := r.Write(&buf, FormatTAP); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	for _, want := range []string{"  quorum: 1-of-3\n", "    - 1. FAIL obs-1 [0.90] LED 'power' is OFF\n", "    - 2. PASS obs-2 [0.90]"} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("Expected %q in TAP output:\n%s", want, buf.String())
+		}
+	}
+	if !strings.Contains(res.details(), "Attempts (1-of-3 required):\n  1. FAIL obs-1") {
+		t.Errorf("Expected the votes in the JUnit details, got %q", res.details())
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	for input, want := range map[string]Format{"": FormatText, "JSON": FormatJSON, "junit": FormatJUnit, "tap": FormatTAP} {
+		if got, err := ParseFormat(input); err != nil || got != want {
+			t.Errorf("ParseFormat(%q) = %q, %v; want %q", input, got, err, want)
+		}
+	}
+	if _, err := ParseFormat("html"); err == nil {
+		t.Error("Expected unknown format to fail")
+	}
+
+	if FormatForPath("out/report.xml") != FormatJUnit || FormatForPath("r.JSON") != FormatJSON || FormatForPath("r.txt") != "" {
+		t.Error("Unexpected format guessed from path")
+	}
+	if err := (&Report{}).Write(&bytes.Buffer{}, FormatText); err == nil {
+		t.Error("Expected text format to have no report")
+	}
+}
@@ -0,0 +1,115 @@
+package report
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/perceptumx/percepta/internal/assertions"
+	"go.yaml.in/yaml/v3"
+)
+
+// tapDiagnostic is the YAML block after each TAP 13 test line
+type tapDiagnostic struct {
+	Outcome       assertions.Outcome `yaml:"outcome"`
+	Expected      string             `yaml:"expected"`
+	Actual        string             `yaml:"actual"`
+	Confidence    float64            `yaml:"confidence"`
+	Message       string             `yaml:"message,omitempty"`
+	Device        string             `yaml:"device"`
+	Firmware      string             `yaml:"firmware,omitempty"`
+	ObservationID string             `yaml:"observation_id,omitempty"`
+	ObservedAt    string             `yaml:"observed_at,omitempty"`
+	DurationMs    int64              `yaml:"duration_ms"`
+	Text          *TextMatch         `yaml:"text,omitempty"`
+	Clauses       []string           `yaml:"clauses,omitempty"`
+	Quorum        string             `yaml:"quorum,omitempty"`
+	Attempts      []string           `yaml:"attempts,omitempty"`
+}
+
+// writeTAP renders TAP version 13: one test point per assertion, numbered
+// across cases. INCONCLUSIVE results are reported as skipped.
+func (r *Report) writeTAP(w io.Writer) error {
+	var b strings.Builder
+	b.WriteString("TAP version 13\n")
+	fmt.Fprintf(&b, "1..%d\n", r.Summary.Total)
+
+	n := 0
+	for _, c := range r.Cases {
+		prefix := ""
+		if len(r.Cases) > 1 {
+			prefix = c.Name + ": "
+		}
+
+		for _, res := range c.Results {
+			n++
+			status, directive := "ok", ""
+			switch res.Outcome {
+			case assertions.OutcomeFail:
+				status = "not ok"
+			case assertions.OutcomeInconclusive:
+				directive = " # SKIP inconclusive: " + tapEscape(res.Message)
+			}
+			fmt.Fprintf(&b, "%s %d - %s%s\n", status, n, tapEscape(prefix+res.Assertion), directive)
+			if err := writeTAPDiagnostic(&b, r.diagnostic(res)); err != nil {
+				return err
+			}
+		}
+
+		for _, name := range c.NotRun {
+			n++
+			fmt.Fprintf(&b, "not ok %d - %s\n", n, tapEscape(prefix+name))
+			fmt.Fprintf(&b, "  ---\n  error: %q\n  ...\n", c.Error)
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// tapEscape keeps text on its test line: newlines would end the line and an
+// unescaped '#' would start a directive
+func tapEscape(s string) string {
+	s = strings.NewReplacer("\\", "\\\\", "#", "\\#", "\r\n", " ", "\n", " ", "\r", " ").Replace(s)
+	return strings.TrimSpace(s)
+}
+
+func (r *Report) diagnostic(res Result) tapDiagnostic {
+	d := tapDiagnostic{
+		Outcome:       res.Outcome,
+		Expected:      res.Expected,
+		Actual:        res.Actual,
+		Confidence:    res.Confidence,
+		Message:       res.Message,
+		Device:        r.Device,
+		Firmware:      r.Firmware,
+		ObservationID: res.ObservationID,
+		DurationMs:    res.DurationMs,
+		Text:          res.Text,
+		Quorum:        res.Quorum,
+		Attempts:      res.attemptLines(),
+	}
+	if res.ObservedAt != nil {
+		d.ObservedAt = res.ObservedAt.Format("2006-01-02T15:04:05.000Z07:00")
+	}
+	var clauses strings.Builder
+	writeClauses(&clauses, res.Children, 0)
+	if s := strings.TrimRight(clauses.String(), "\n"); s != "" {
+		d.Clauses = strings.Split(s, "\n")
+	}
+	return d
+}
+
+// writeTAPDiagnostic writes a YAML block indented by two spaces
+func writeTAPDiagnostic(b *strings.Builder, d tapDiagnostic) error {
+	data, err := yaml.Marshal(d)
+	if err != nil {
+		return fmt.Errorf("failed to encode TAP diagnostics: %w", err)
+	}
+	b.WriteString("  ---\n")
+	for _, line := range strings.Split(strings.TrimRight(string(data), "\n"), "\n") {
+		b.WriteString("  " + line + "\n")
+	}
+	b.WriteString("  ...\n")
+	return nil
+}
@@ -0,0 +1,68 @@
+package storage
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrNoGolden is returned when a device has no golden observation
+var ErrNoGolden = errors.New("no golden observation")
+
+// Golden marks the stored observation that a device's snapshot assertions
+// compare against
+type Golden struct {
+	DeviceID      string
+	ObservationID string
+	SetAt         time.Time
+}
+
+// SetGolden makes a stored observation the device's golden, replacing any
+// previous one. The observation must belong to the device.
+func (s *SQLiteStorage) SetGolden(deviceID, observationID string) (*Golden, error) {
+	obs, err := s.GetByID(observationID)
+	if err != nil {
+		return nil, err
+	}
+	if obs.DeviceID != deviceID {
+		return nil, fmt.Errorf("observation %s was captured on device '%s', not '%s'", observationID, obs.DeviceID, deviceID)
+	}
+
+	golden := &Golden{DeviceID: deviceID, ObservationID: observationID, SetAt: time.Now()}
+	query := `
+	INSERT INTO goldens (device_id, observation_id, set_at)
+	VALUES (?, ?, ?)
+	ON CONFLICT(device_id) DO UPDATE SET observation_id = excluded.observation_id, set_at = excluded.set_at
+	`
+	if _, err := s.db.Exec(query, golden.DeviceID, golden.ObservationID, golden.SetAt); err != nil {
+		return nil, fmt.Errorf("failed to set golden observation: %w", err)
+	}
+	return golden, nil
+}
+
+// GetGolden returns the device's golden, or an error wrapping ErrNoGolden
+func (s *SQLiteStorage) GetGolden(deviceID string) (*Golden, error) {
+	golden := &Golden{}
+	err := s.db.QueryRow(`SELECT device_id, observation_id, set_at FROM goldens WHERE device_id = ?`, deviceID).
+		Scan(&golden.DeviceID, &golden.ObservationID, &golden.SetAt)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("%w for device %s", ErrNoGolden, deviceID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get golden observation: %w", err)
+	}
+	return golden, nil
+}
+
+// ClearGolden removes the device's golden; the observation itself is kept
+func (s *SQLiteStorage) ClearGolden(deviceID string) error {
+	result, err := s.db.Exec(`DELETE FROM goldens WHERE device_id = ?`, deviceID)
+	if err != nil {
+		return fmt.Errorf("failed to clear golden observation: %w", err)
+	}
+	if n, err := result.RowsAffected(); err == nil && n == 0 {
+		return fmt.Errorf("%w for device %s", ErrNoGolden, deviceID)
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package storage
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/perceptumx/percepta/internal/core"
+)
+
+func TestSQLiteStorage_Golden(t *testing.T) {
+	storage, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	for _, obs := range []core.Observation{
+		{ID: "obs-1", DeviceID: "board", Timestamp: time.Now().Add(-time.Hour)},
+		{ID: "obs-2", DeviceID: "board", Timestamp: time.Now()},
+		{ID: "obs-3", DeviceID: "other", Timestamp: time.Now()},
+	} {
+		if err := storage.Save(obs); err != nil {
+			t.Fatalf("Failed to save observation: %v", err)
+		}
+	}
+
+	if _, err := storage.GetGolden("board"); !errors.Is(err, ErrNoGolden) {
+		t.Fatalf("Expected ErrNoGolden before one is set, got %v", err)
+	}
+
+	if _, err := storage.SetGolden("board", "obs-1"); err != nil {
+		t.Fatalf("SetGolden failed: %v", err)
+	}
+	// Re-blessing replaces the previous golden
+	if _, err := storage.SetGolden("board", "obs-2"); err != nil {
+		t.Fatalf("SetGolden failed: %v", err)
+	}
+
+	golden, err := storage.GetGolden("board")
+	if err != nil {
+		t.Fatalf("GetGolden failed: %v", err)
+	}
+	if golden.ObservationID != "obs-2" || golden.DeviceID != "board" {
+		t.Errorf("Unexpected golden: %+v", golden)
+	}
+	if time.Since(golden.SetAt) > time.Minute {
+		t.Errorf("Expected a recent SetAt, got %v", golden.SetAt)
+	}
+
+	if err := storage.ClearGolden("board"); err != nil {
+		t.Fatalf("ClearGolden failed: %v", err)
+	}
+	if _, err := storage.GetGolden("board"); !errors.Is(err, ErrNoGolden) {
+		t.Errorf("Expected ErrNoGolden after clearing, got %v", err)
+	}
+	if err := storage.ClearGolden("board"); !errors.Is(err, ErrNoGolden) {
+		t.Errorf("Expected clearing twice to report ErrNoGolden, got %v", err)
+	}
+	if _, err := storage.GetByID("obs-2"); err != nil {
+		t.Errorf("Expected the observation to survive clearing its golden: %v", err)
+	}
+}
+
+func TestSQLiteStorage_SetGoldenValidates(t *testing.T) {
+	storage, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	if err := storage.Save(core.Observation{ID: "obs-1", DeviceID: "other", Timestamp: time.Now()}); err != nil {
+		t.Fatalf("Failed to save observation: %v", err)
+	}
+
+	if _, err := storage.SetGolden("board", "missing"); err == nil {
+		t.Error("Expected an unknown observation to be rejected")
+	}
+	if _, err := storage.SetGolden("board", "obs-1"); err == nil {
+		t.Error("Expected another device's observation to be rejected")
+	}
+}
@@ -50,7 +50,7 @@ func NewSQLiteStorage() (*SQLiteStorage, error) {
 	return storage, nil
 }
 
-// initSchema creates the observations table and indexes
+// initSchema creates the observations and goldens tables and indexes
 func (s *SQLiteStorage) initSchema() error {
 	schema := `
 	CREATE TABLE IF NOT EXISTS observations (
@@ -63,6 +63,12 @@ func (s *SQLiteStorage) initSchema() error {
 
 	CREATE INDEX IF NOT EXISTS idx_device_firmware
 	ON observations(device_id, firmware, timestamp);
+
+	CREATE TABLE IF NOT EXISTS goldens (
+		device_id TEXT PRIMARY KEY,
+		observation_id TEXT NOT NULL REFERENCES observations(id),
+		set_at DATETIME NOT NULL
+	);
 	`
 
 	_, err := s.db.Exec(schema)
@@ -144,13 +150,53 @@ func (s *SQLiteStorage) GetLatestForFirmware(deviceID, firmware string) (*core.O
 	LIMIT 1
 	`
 
-	row := s.db.QueryRow(query, deviceID, firmware)
+	obs, err := s.scanObservation(s.db.QueryRow(query, deviceID, firmware))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("no observation found for device %s with firmware %s", deviceID, firmware)
+	}
+	return obs, err
+}
+
+// GetLatest retrieves the most recent observation for a device, whatever its firmware
+func (s *SQLiteStorage) GetLatest(deviceID string) (*core.Observation, error) {
+	query := `
+	SELECT id, device_id, firmware, timestamp, signals_json
+	FROM observations
+	WHERE device_id = ?
+	ORDER BY timestamp DESC
+	LIMIT 1
+	`
 
+	obs, err := s.scanObservation(s.db.QueryRow(query, deviceID))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("no observation found for device %s", deviceID)
+	}
+	return obs, err
+}
+
+// GetByID retrieves a single observation by its ID
+func (s *SQLiteStorage) GetByID(id string) (*core.Observation, error) {
+	query := `
+	SELECT id, device_id, firmware, timestamp, signals_json
+	FROM observations
+	WHERE id = ?
+	`
+
+	obs, err := s.scanObservation(s.db.QueryRow(query, id))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("no observation found with id %s", id)
+	}
+	return obs, err
+}
+
+// scanObservation scans a single row into a validated observation.
+// sql.ErrNoRows is returned unwrapped so callers can report what was missing.
+func (s *SQLiteStorage) scanObservation(row *sql.Row) (*core.Observation, error) {
 	var id, devID, fw, timestamp, signalsJSON string
 
 	err := row.Scan(&id, &devID, &fw, &timestamp, &signalsJSON)
 	if err == sql.ErrNoRows {
-		return nil, fmt.Errorf("no observation found for device %s with firmware %s", deviceID, firmware)
+		return nil, err
 	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to scan observation: %w", err)
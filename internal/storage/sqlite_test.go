@@ -209,6 +209,43 @@ func TestSQLiteStorage_GetLatestForFirmware_NotFound(t *testing.T) {
 	}
 }
 
+func TestSQLiteStorage_GetLatestAndGetByID(t *testing.T) {
+	storage, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	now := time.Now()
+	storage.Save(core.Observation{ID: "old", DeviceID: "fpga", FirmwareHash: "v2", Timestamp: now,
+		Signals: []core.Signal{core.LEDSignal{Name: "LED1", On: true}}})
+	storage.Save(core.Observation{ID: "new", DeviceID: "fpga", FirmwareHash: "v1", Timestamp: now.Add(time.Minute),
+		Signals: []core.Signal{core.LEDSignal{Name: "LED1", On: false}}})
+	storage.Save(core.Observation{ID: "other", DeviceID: "esp32", Timestamp: now.Add(time.Hour),
+		Signals: []core.Signal{core.LEDSignal{Name: "LED1", On: true}}})
+
+	// Latest ignores firmware but not device
+	latest, err := storage.GetLatest("fpga")
+	if err != nil {
+		t.Fatalf("GetLatest failed: %v", err)
+	}
+	if latest.ID != "new" {
+		t.Errorf("Expected latest fpga observation 'new', got %s", latest.ID)
+	}
+
+	obs, err := storage.GetByID("old")
+	if err != nil {
+		t.Fatalf("GetByID failed: %v", err)
+	}
+	if obs.DeviceID != "fpga" || obs.FirmwareHash != "v2" || len(obs.Signals) != 1 {
+		t.Errorf("Unexpected observation: %+v", obs)
+	}
+
+	if _, err := storage.GetByID("missing"); err == nil {
+		t.Error("Expected error for unknown ID")
+	}
+	if _, err := storage.GetLatest("stm32"); err == nil {
+		t.Error("Expected error for device without observations")
+	}
+}
+
 func TestSQLiteStorage_EmptyFirmwareTag(t *testing.T) {
 	storage, cleanup := setupTestDB(t)
 	defer cleanup()
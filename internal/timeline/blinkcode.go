@@ -0,0 +1,225 @@
+package timeline
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/perceptumx/percepta/internal/core"
+)
+
+// Blink-code schemes
+const (
+	// SchemeGroups counts pulses in groups separated by a longer pause:
+	// three blinks, pause, two blinks is code "3-2"
+	SchemeGroups = "groups"
+
+	// SchemeShortLong counts runs of short and long pulses:
+	// three short pulses followed by two long ones is code "3-2"
+	SchemeShortLong = "short-long"
+)
+
+// ErrIncompleteCode means the capture did not contain a whole repetition of the code
+var ErrIncompleteCode = errors.New("no complete blink code in capture")
+
+// Scheme describes how a device encodes numbers as LED pulses.
+// Zero fields fall back to DefaultScheme.
+type Scheme struct {
+	Name        string // SchemeGroups or SchemeShortLong
+	ShortMaxMs  int64  // Pulses up to this long are short (short-long scheme)
+	GroupGapMs  int64  // Off gaps at least this long separate digit groups (groups scheme)
+	RepeatGapMs int64  // Off gaps at least this long separate repetitions of the whole code
+}
+
+// DefaultScheme matches the common "blink N times, pause, repeat" error codes
+func DefaultScheme() Scheme {
+	return Scheme{
+		Name:        SchemeGroups,
+		ShortMaxMs:  400,
+		GroupGapMs:  700,
+		RepeatGapMs: 1500,
+	}
+}
+
+// WithDefaults fills unset fields from DefaultScheme
+func (s Scheme) WithDefaults() Scheme {
+	d := DefaultScheme()
+	if s.Name == "" {
+		s.Name = d.Name
+	}
+	if s.ShortMaxMs <= 0 {
+		s.ShortMaxMs = d.ShortMaxMs
+	}
+	if s.GroupGapMs <= 0 {
+		s.GroupGapMs = d.GroupGapMs
+	}
+	if s.RepeatGapMs <= 0 {
+		s.RepeatGapMs = d.RepeatGapMs
+	}
+	return s
+}
+
+// Validate checks the scheme name and that the gaps are ordered sensibly
+func (s Scheme) Validate() error {
+	switch s.Name {
+	case SchemeGroups:
+		if s.GroupGapMs >= s.RepeatGapMs {
+			return fmt.Errorf("blink code group gap (%dms) must be shorter than repeat gap (%dms)", s.GroupGapMs, s.RepeatGapMs)
+		}
+	case SchemeShortLong:
+		if s.ShortMaxMs >= s.RepeatGapMs {
+			return fmt.Errorf("blink code short pulse limit (%dms) must be shorter than repeat gap (%dms)", s.ShortMaxMs, s.RepeatGapMs)
+		}
+	default:
+		return fmt.Errorf("unknown blink code scheme %q (expected %s or %s)", s.Name, SchemeGroups, SchemeShortLong)
+	}
+	return nil
+}
+
+// Code is a blink code decoded from a timeline
+type Code struct {
+	Digits     []int
+	Repeats    int     // Complete repetitions seen in the capture
+	Agreeing   int     // Repetitions that decoded to this code
+	Confidence float64 // Agreeing / Repeats
+}
+
+// String formats the digits as "3-2"
+func (c Code) String() string {
+	parts := make([]string, len(c.Digits))
+	for i, d := range c.Digits {
+		parts[i] = strconv.Itoa(d)
+	}
+	return strings.Join(parts, "-")
+}
+
+// Decode measures pulse widths and gaps in a per-frame timeline and returns
+// the blink code it repeats. Only repetitions bounded by a repeat gap on both
+// sides count, so the capture should span at least two repeat gaps. When
+// repetitions disagree the most frequent code wins, with lower confidence.
+func Decode(samples []core.LEDSample, scheme Scheme) (Code, error) {
+	scheme = scheme.WithDefaults()
+	if err := scheme.Validate(); err != nil {
+		return Code{}, err
+	}
+
+	runs := Runs(samples)
+	if !hasState(runs, true) {
+		return Code{}, fmt.Errorf("LED never turned on during the capture")
+	}
+	if !hasState(runs, false) {
+		return Code{}, fmt.Errorf("LED never turned off during the capture")
+	}
+
+	counts := make(map[string]int)
+	codes := make(map[string]Code)
+	repeats := 0
+	for _, frame := range repetitions(runs, scheme.RepeatGapMs) {
+		code := Code{Digits: digits(frame, scheme)}
+		key := code.String()
+		if _, ok := codes[key]; !ok {
+			codes[key] = code
+		}
+		counts[key]++
+		repeats++
+	}
+
+	if repeats == 0 {
+		return Code{}, fmt.Errorf("%w: capture must include the pause before and after the code (gaps >= %dms)", ErrIncompleteCode, scheme.RepeatGapMs)
+	}
+
+	best := ""
+	for key, n := range counts {
+		if best == "" || n > counts[best] || (n == counts[best] && key < best) {
+			best = key
+		}
+	}
+
+	code := codes[best]
+	code.Repeats = repeats
+	code.Agreeing = counts[best]
+	code.Confidence = float64(code.Agreeing) / float64(repeats)
+	return code, nil
+}
+
+func hasState(runs []Run, on bool) bool {
+	for _, r := range runs {
+		if r.On == on {
+			return true
+		}
+	}
+	return false
+}
+
+// repetitions returns the pulse runs of every repetition that is enclosed by
+// repeat gaps. Gaps and pulses touching the edges of the capture only count
+// as separators when their observed part is already long enough.
+func repetitions(runs []Run, repeatGapMs int64) [][]Run {
+	var frames [][]Run
+	var current []Run
+	bounded := false // A repeat gap precedes the current repetition
+
+	for _, r := range runs {
+		if !r.On && r.DurationMs() >= repeatGapMs {
+			if bounded && len(current) > 0 {
+				frames = append(frames, current)
+			}
+			current = nil
+			bounded = true
+			continue
+		}
+		if r.On && r.Open {
+			// A pulse cut off by the capture cannot be measured
+			current = nil
+			bounded = false
+			continue
+		}
+		current = append(current, r)
+	}
+
+	return frames
+}
+
+// digits converts one repetition into its code digits according to the scheme
+func digits(frame []Run, scheme Scheme) []int {
+	var out []int
+
+	switch scheme.Name {
+	case SchemeShortLong:
+		lastShort, count := false, 0
+		for _, r := range frame {
+			if !r.On {
+				continue
+			}
+			short := r.DurationMs() <= scheme.ShortMaxMs
+			if count > 0 && short != lastShort {
+				out = append(out, count)
+				count = 0
+			}
+			lastShort = short
+			count++
+		}
+		if count > 0 {
+			out = append(out, count)
+		}
+
+	default: // SchemeGroups
+		count := 0
+		for _, r := range frame {
+			if r.On {
+				count++
+				continue
+			}
+			if r.DurationMs() >= scheme.GroupGapMs && count > 0 {
+				out = append(out, count)
+				count = 0
+			}
+		}
+		if count > 0 {
+			out = append(out, count)
+		}
+	}
+
+	return out
+}
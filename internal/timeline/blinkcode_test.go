@@ -0,0 +1,149 @@
+package timeline
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/perceptumx/percepta/internal/core"
+)
+
+// sampled renders alternating off/on durations (starting with off) as a
+// timeline sampled every frameMs
+func sampled(frameMs int64, durations ...int64) []core.LEDSample {
+	var samples []core.LEDSample
+	var offset int64
+	on := false
+	for _, d := range durations {
+		for end := offset + d; offset < end; offset += frameMs {
+			samples = append(samples, core.LEDSample{OffsetMs: offset, On: on})
+		}
+		on = !on
+	}
+	return samples
+}
+
+// groupsCode is "3-2" in the groups scheme: 3 blinks, group gap, 2 blinks, repeat gap
+var groupsCode = []int64{250, 250, 250, 250, 250, 1000, 250, 250, 250}
+
+// repeat lays out an off lead-in followed by the code repeated with gaps.
+// Codes start and end with a pulse so states keep alternating.
+func repeat(lead, gap int64, code []int64, times int) []int64 {
+	out := []int64{lead}
+	for i := 0; i < times; i++ {
+		out = append(out, code...)
+		out = append(out, gap)
+	}
+	return out
+}
+
+func TestRuns(t *testing.T) {
+	runs := Runs(sampled(50, 200, 100, 300))
+	if len(runs) != 3 {
+		t.Fatalf("Expected 3 runs, got %d", len(runs))
+	}
+	if runs[0].On || !runs[0].Open || runs[0].DurationMs() != 175 {
+		t.Errorf("Unexpected leading run: %+v", runs[0])
+	}
+	if !runs[1].On || runs[1].Open || runs[1].DurationMs() != 100 || runs[1].Frames != 2 {
+		t.Errorf("Unexpected pulse: %+v", runs[1])
+	}
+	if !runs[2].Open {
+		t.Errorf("Expected trailing run to be open: %+v", runs[2])
+	}
+	if Transitions(sampled(50, 200, 100, 300)) != 2 {
+		t.Error("Expected 2 transitions")
+	}
+	if Runs(nil) != nil {
+		t.Error("Expected no runs for an empty timeline")
+	}
+}
+
+func TestDecode_Groups(t *testing.T) {
+	samples := sampled(50, repeat(2000, 2000, groupsCode, 3)...)
+
+	code, err := Decode(samples, Scheme{})
+	if err != nil {
+		t.Fatalf("Decode failed: %v", err)
+	}
+	if code.String() != "3-2" {
+		t.Errorf("Expected code 3-2, got %s", code)
+	}
+	if code.Repeats != 3 || code.Confidence != 1 {
+		t.Errorf("Expected 3 agreeing repeats, got %d/%d", code.Agreeing, code.Repeats)
+	}
+}
+
+func TestDecode_ShortLong(t *testing.T) {
+	// 3 short (200ms) then 2 long (800ms) pulses, 300ms apart
+	shortLong := []int64{200, 300, 200, 300, 200, 300, 800, 300, 800}
+	samples := sampled(40, repeat(3000, 2500, shortLong, 2)...)
+
+	code, err := Decode(samples, Scheme{Name: SchemeShortLong, RepeatGapMs: 2000})
+	if err != nil {
+		t.Fatalf("Decode failed: %v", err)
+	}
+	if code.String() != "3-2" || code.Repeats != 2 {
+		t.Errorf("Expected 3-2 twice, got %s x%d", code, code.Repeats)
+	}
+}
+
+func TestDecode_PartialRepetitionIgnored(t *testing.T) {
+	// Capture starts halfway through the code: the leading pulses are not enclosed
+	samples := sampled(50, append([]int64{100, 250, 1000, 250, 250, 250, 2000}, append(groupsCode, 2000)...)...)
+
+	code, err := Decode(samples, DefaultScheme())
+	if err != nil {
+		t.Fatalf("Decode failed: %v", err)
+	}
+	if code.String() != "3-2" || code.Repeats != 1 {
+		t.Errorf("Expected a single complete 3-2, got %s x%d", code, code.Repeats)
+	}
+}
+
+func TestDecode_Disagreement(t *testing.T) {
+	other := []int64{250, 250, 250, 250, 250, 1000, 250} // 3-1
+	durations := repeat(2000, 2000, groupsCode, 2)
+	durations = append(durations, other...)
+	durations = append(durations, 2000)
+
+	code, err := Decode(sampled(50, durations...), DefaultScheme())
+	if err != nil {
+		t.Fatalf("Decode failed: %v", err)
+	}
+	if code.String() != "3-2" || code.Agreeing != 2 || code.Repeats != 3 {
+		t.Errorf("Expected majority 3-2 (2 of 3), got %s (%d of %d)", code, code.Agreeing, code.Repeats)
+	}
+	if code.Confidence < 0.66 || code.Confidence > 0.67 {
+		t.Errorf("Expected confidence 2/3, got %.2f", code.Confidence)
+	}
+}
+
+func TestDecode_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		samples []core.LEDSample
+		scheme  Scheme
+		wantErr string
+	}{
+		{"never on", sampled(50, 3000), Scheme{}, "never turned on"},
+		{"never off", sampled(50, 0, 3000), Scheme{}, "never turned off"},
+		{"too short", sampled(50, 500, 250, 250, 250, 500), Scheme{}, "no complete blink code"},
+		{"bad scheme", sampled(50, 500, 250, 500), Scheme{Name: "morse"}, "unknown blink code scheme"},
+		{"gaps out of order", sampled(50, 500, 250, 500), Scheme{GroupGapMs: 2000, RepeatGapMs: 1000}, "must be shorter than repeat gap"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Decode(tt.samples, tt.scheme)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+
+	_, err := Decode(sampled(50, 500, 250, 500), Scheme{})
+	if !errors.Is(err, ErrIncompleteCode) {
+		t.Errorf("Expected ErrIncompleteCode, got %v", err)
+	}
+}
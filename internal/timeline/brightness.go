@@ -0,0 +1,102 @@
+package timeline
+
+import (
+	"sort"
+
+	"github.com/perceptumx/percepta/internal/core"
+)
+
+// DutyCycle is the share of the timeline, in percent, during which the LED
+// was lit, measured on the runs so uneven frame spacing is weighted by time.
+// ok is false for an empty timeline.
+func DutyCycle(samples []core.LEDSample) (percent float64, ok bool) {
+	runs := Runs(samples)
+	if len(runs) == 0 {
+		return 0, false
+	}
+
+	var lit, total int64
+	litFrames := 0
+	for _, run := range runs {
+		total += run.DurationMs()
+		if run.On {
+			lit += run.DurationMs()
+			litFrames += run.Frames
+		}
+	}
+	if total == 0 {
+		// A single instant: count frames instead
+		return float64(litFrames) * 100 / float64(len(samples)), true
+	}
+	return float64(lit) * 100 / float64(total), true
+}
+
+// Fade summarises how an LED's brightness moved over a timeline
+type Fade struct {
+	Min, Max     uint8 // Dimmest and brightest sample, percent
+	Rises        int   // Upward swings of at least the minimum swing
+	Falls        int   // Downward swings of at least the minimum swing
+	Intermediate int   // Samples caught mid-fade: in the middle half of a range spanning a swing
+}
+
+// Gradual reports that the brightness passed through intermediate levels
+// rather than only jumping between off and on, as a blinking LED does
+func (f Fade) Gradual() bool {
+	return f.Intermediate > 0
+}
+
+// Fades finds the brightness swings in a timeline. A swing counts once the
+// brightness has moved at least minSwing percentage points from the last
+// extreme, so sample noise smaller than that is ignored.
+func Fades(samples []core.LEDSample, minSwing uint8) Fade {
+	if len(samples) == 0 {
+		return Fade{}
+	}
+
+	sorted := make([]core.LEDSample, len(samples))
+	copy(sorted, samples)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].OffsetMs < sorted[j].OffsetMs })
+
+	fade := Fade{Min: sorted[0].Brightness, Max: sorted[0].Brightness}
+	swing := int(minSwing)
+	low, high := int(sorted[0].Brightness), int(sorted[0].Brightness)
+	direction := 0 // +1 rising, -1 falling, 0 undecided
+	for _, s := range sorted[1:] {
+		b := int(s.Brightness)
+		fade.Min, fade.Max = min(fade.Min, s.Brightness), max(fade.Max, s.Brightness)
+
+		switch direction {
+		case 0:
+			low, high = min(low, b), max(high, b)
+			if b-low >= swing {
+				direction, fade.Rises, high = 1, fade.Rises+1, b
+			} else if high-b >= swing {
+				direction, fade.Falls, low = -1, fade.Falls+1, b
+			}
+		case 1:
+			if b > high {
+				high = b
+			} else if high-b >= swing {
+				direction, fade.Falls, low = -1, fade.Falls+1, b
+			}
+		case -1:
+			if b < low {
+				low = b
+			} else if b-low >= swing {
+				direction, fade.Rises, high = 1, fade.Rises+1, b
+			}
+		}
+	}
+
+	if int(fade.Max)-int(fade.Min) < swing {
+		return fade
+	}
+	quarter := (int(fade.Max) - int(fade.Min)) / 4
+	for _, s := range sorted {
+		b := int(s.Brightness)
+		if b > int(fade.Min)+quarter && b < int(fade.Max)-quarter {
+			fade.Intermediate++
+		}
+	}
+	return fade
+}
@@ -0,0 +1,75 @@
+package timeline
+
+import (
+	"testing"
+
+	"github.com/perceptumx/percepta/internal/core"
+)
+
+// levels renders brightness percentages as a timeline sampled every 100ms;
+// non-zero levels are lit
+func levels(brightness ...uint8) []core.LEDSample {
+	samples := make([]core.LEDSample, len(brightness))
+	for i, b := range brightness {
+		samples[i] = core.LEDSample{OffsetMs: int64(i) * 100, On: b > 0, Brightness: b}
+	}
+	return samples
+}
+
+func TestDutyCycle(t *testing.T) {
+	tests := []struct {
+		name    string
+		samples []core.LEDSample
+		want    float64
+	}{
+		{"square wave", sampled(50, 200, 200, 200, 200), 50},
+		{"short pulses", sampled(50, 300, 100, 300, 100, 300), 20},
+		{"steady on", levels(80, 80, 80), 100},
+		{"steady off", levels(0, 0, 0), 0},
+		{"single frame", levels(80), 100},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := DutyCycle(tt.samples)
+			if !ok || got < tt.want-3 || got > tt.want+3 {
+				t.Errorf("Expected duty cycle ≈ %v%%, got %.1f%% (ok=%v)", tt.want, got, ok)
+			}
+		})
+	}
+
+	if _, ok := DutyCycle(nil); ok {
+		t.Error("Expected an empty timeline to have no duty cycle")
+	}
+}
+
+func TestFades(t *testing.T) {
+	tests := []struct {
+		name         string
+		samples      []core.LEDSample
+		rises, falls int
+		gradual      bool
+	}{
+		{"breathing", levels(10, 30, 60, 90, 60, 30, 10, 30, 60), 2, 1, true},
+		{"fade in", levels(5, 20, 40, 60, 80, 100), 1, 0, true},
+		{"fade out", levels(100, 75, 50, 25, 5), 0, 1, true},
+		{"blinking", levels(80, 0, 80, 0, 80), 2, 2, false},
+		{"noise", levels(50, 55, 48, 52, 50), 0, 0, false},
+		{"steady", levels(60, 60, 60), 0, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fade := Fades(tt.samples, 20)
+			if fade.Rises != tt.rises || fade.Falls != tt.falls || fade.Gradual() != tt.gradual {
+				t.Errorf("Expected %d rises, %d falls, gradual=%v; got %+v", tt.rises, tt.falls, tt.gradual, fade)
+			}
+		})
+	}
+
+	fade := Fades(levels(40, 10, 90), 20)
+	if fade.Min != 10 || fade.Max != 90 {
+		t.Errorf("Expected range 10-90, got %d-%d", fade.Min, fade.Max)
+	}
+	if (Fades(nil, 20) != Fade{}) {
+		t.Error("Expected an empty timeline to have no fade")
+	}
+}
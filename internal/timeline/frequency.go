@@ -0,0 +1,212 @@
+package timeline
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/cmplx"
+	"sort"
+
+	"github.com/perceptumx/percepta/internal/core"
+)
+
+const (
+	// irregularPeriods is the spread of the measured periods (standard
+	// deviation over mean) above which the spectrum gives the rate instead:
+	// edges are then jittered by noise or dropped frames
+	irregularPeriods = 0.25
+
+	// aliasShare of the Nyquist rate from which a measured rate may be an
+	// alias of faster blinking
+	aliasShare = 0.8
+
+	// minBrightnessSwing is the brightness range (percentage points) from which
+	// the spectrum is taken on brightness rather than on/off states
+	minBrightnessSwing = 10
+
+	// spectrumSteps is the number of frequencies tried per 1/duration, the
+	// resolution of a plain DFT
+	spectrumSteps = 8
+)
+
+// ErrTooFewPeriods means the timeline holds less than one full blink period,
+// so the rate cannot be measured
+var ErrTooFewPeriods = errors.New("less than one full blink period captured")
+
+// Method is how a blink rate was measured
+type Method string
+
+const (
+	MethodZeroCrossing Method = "zero-crossing" // Mean time between edges of the same direction
+	MethodSpectrum     Method = "spectrum"      // Peak of the timeline's Fourier transform
+)
+
+// Blink is a blink rate and duty cycle measured from a timeline
+type Blink struct {
+	Hz        float64 // 0 when steady
+	DutyCycle float64 // Share of each period spent lit, percent; 0 when steady
+	Periods   int     // Whole periods the measurement spans
+	Method    Method
+	FrameHz   float64  // Frame rate of the timeline (median frame interval)
+	Warnings  []string // Sampling problems that make the rate doubtful, e.g. aliasing
+}
+
+// NyquistHz is the fastest blink rate the timeline's frame rate can capture
+func (b Blink) NyquistHz() float64 {
+	return b.FrameHz / 2
+}
+
+// Aliased reports whether the rate is close enough to the Nyquist rate that
+// the LED may really blink faster: blinking at f Hz filmed at r fps looks like
+// blinking at |f - k·r| Hz
+func (b Blink) Aliased() bool {
+	return b.Hz > 0 && b.Hz >= aliasShare*b.NyquistHz()
+}
+
+// EstimateBlink measures an LED's blink rate and duty cycle from its
+// per-frame timeline, using the samples' own timestamps so that uneven frame
+// spacing does not skew it. The rate is the mean period between rising edges
+// (or falling edges, when there are fewer than two rising ones); when those
+// periods vary by more than a quarter, the peak of the timeline's spectrum is
+// used instead, as it averages over the whole capture. A timeline without
+// transitions is steady (Hz 0). Blinking at a multiple of the frame rate also
+// looks steady: no timeline can tell them apart.
+func EstimateBlink(samples []core.LEDSample) (Blink, error) {
+	if len(samples) < 3 {
+		return Blink{}, fmt.Errorf("need at least 3 frames, got %d", len(samples))
+	}
+	sorted := make([]core.LEDSample, len(samples))
+	copy(sorted, samples)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].OffsetMs < sorted[j].OffsetMs })
+
+	spacing := make([]int64, len(sorted)-1)
+	for i := 1; i < len(sorted); i++ {
+		spacing[i-1] = sorted[i].OffsetMs - sorted[i-1].OffsetMs
+	}
+	sort.Slice(spacing, func(i, j int) bool { return spacing[i] < spacing[j] })
+	median, longest := spacing[len(spacing)/2], spacing[len(spacing)-1]
+	if median <= 0 {
+		return Blink{}, fmt.Errorf("frames have no time between them")
+	}
+	blink := Blink{FrameHz: 1000 / float64(median)}
+	if longest > 2*median {
+		blink.Warnings = append(blink.Warnings, fmt.Sprintf("uneven frame spacing (up to %d ms against a median of %d ms): dropped frames blur the measurement", longest, median))
+	}
+
+	runs := Runs(sorted)
+	if len(runs) == 1 {
+		return blink, nil
+	}
+	edges := edgeTimes(runs, true)
+	if len(edges) < 2 {
+		edges = edgeTimes(runs, false)
+	}
+	if len(edges) < 2 {
+		return blink, ErrTooFewPeriods
+	}
+
+	periods := make([]float64, len(edges)-1)
+	var sum float64
+	for i := 1; i < len(edges); i++ {
+		periods[i-1] = float64(edges[i] - edges[i-1])
+		sum += periods[i-1]
+	}
+	mean := sum / float64(len(periods))
+	blink.Hz, blink.Periods, blink.Method = 1000/mean, len(periods), MethodZeroCrossing
+	blink.DutyCycle = windowDuty(runs, edges[0], edges[len(edges)-1])
+
+	if spread := stddev(periods, mean) / mean; spread > irregularPeriods {
+		if hz, ok := spectrumPeak(sorted, blink.NyquistHz()); ok {
+			blink.Hz, blink.Method = hz, MethodSpectrum
+			blink.Warnings = append(blink.Warnings, fmt.Sprintf("periods vary by %.0f%%: rate taken from the spectrum", spread*100))
+		}
+	}
+
+	if blink.Aliased() {
+		blink.Warnings = append(blink.Warnings, fmt.Sprintf("%.2f Hz is close to the %.2f Hz Nyquist limit of %.1f fps: the LED may blink faster and alias to this rate; capture at a higher frame rate", blink.Hz, blink.NyquistHz(), blink.FrameHz))
+	}
+	for _, run := range runs[1 : len(runs)-1] {
+		if run.Frames == 1 {
+			blink.Warnings = append(blink.Warnings, fmt.Sprintf("pulses last a single frame: pulses shorter than the %d ms frame interval may be missed", median))
+			break
+		}
+	}
+	return blink, nil
+}
+
+// edgeTimes lists the times of the rising (or falling) edges between runs
+func edgeTimes(runs []Run, rising bool) []int64 {
+	var edges []int64
+	for _, run := range runs[1:] {
+		if run.On == rising {
+			edges = append(edges, run.StartMs)
+		}
+	}
+	return edges
+}
+
+// windowDuty is the share of [from, to], in percent, covered by lit runs.
+// from and to are run boundaries, so only whole periods are weighed.
+func windowDuty(runs []Run, from, to int64) float64 {
+	if to <= from {
+		return 0
+	}
+	var lit int64
+	for _, run := range runs {
+		if run.On && run.StartMs >= from && run.EndMs <= to {
+			lit += run.DurationMs()
+		}
+	}
+	return float64(lit) * 100 / float64(to-from)
+}
+
+func stddev(values []float64, mean float64) float64 {
+	var sq float64
+	for _, v := range values {
+		sq += (v - mean) * (v - mean)
+	}
+	return math.Sqrt(sq / float64(len(values)))
+}
+
+// spectrumPeak returns the frequency, between one cycle per capture and
+// maxHz, at which the Fourier transform of the timeline peaks. The transform
+// is evaluated at the samples' own timestamps, so uneven spacing is allowed.
+// Brightness is transformed when it varies, else the on/off states.
+func spectrumPeak(sorted []core.LEDSample, maxHz float64) (float64, bool) {
+	duration := float64(sorted[len(sorted)-1].OffsetMs-sorted[0].OffsetMs) / 1000
+	if duration <= 0 {
+		return 0, false
+	}
+
+	lo, hi := sorted[0].Brightness, sorted[0].Brightness
+	for _, s := range sorted {
+		lo, hi = min(lo, s.Brightness), max(hi, s.Brightness)
+	}
+	levels := make([]float64, len(sorted))
+	var mean float64
+	for i, s := range sorted {
+		levels[i] = float64(s.Brightness)
+		if hi-lo < minBrightnessSwing {
+			levels[i] = 0
+			if s.On {
+				levels[i] = 100
+			}
+		}
+		mean += levels[i]
+	}
+	mean /= float64(len(levels))
+
+	step := 1 / (duration * spectrumSteps)
+	best, bestPower := 0.0, 0.0
+	for f := 1 / duration; f <= maxHz; f += step {
+		var sum complex128
+		for i, s := range sorted {
+			t := float64(s.OffsetMs-sorted[0].OffsetMs) / 1000
+			sum += complex(levels[i]-mean, 0) * cmplx.Exp(complex(0, -2*math.Pi*f*t))
+		}
+		if power := cmplx.Abs(sum); power > bestPower {
+			best, bestPower = f, power
+		}
+	}
+	return best, bestPower > 0
+}
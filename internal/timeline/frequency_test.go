@@ -0,0 +1,135 @@
+package timeline
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/perceptumx/percepta/internal/core"
+)
+
+// wave samples a square wave lit for onMs of every periodMs, every frameMs
+// for totalMs
+func wave(periodMs, onMs, frameMs, totalMs int64) []core.LEDSample {
+	var samples []core.LEDSample
+	for t := int64(0); t < totalMs; t += frameMs {
+		samples = append(samples, core.LEDSample{OffsetMs: t, On: t%periodMs < onMs})
+	}
+	return samples
+}
+
+func TestEstimateBlink(t *testing.T) {
+	blink, err := EstimateBlink(wave(500, 150, 50, 3000))
+	if err != nil {
+		t.Fatalf("EstimateBlink failed: %v", err)
+	}
+	if math.Abs(blink.Hz-2) > 0.01 || blink.Method != MethodZeroCrossing || blink.Periods != 4 {
+		t.Errorf("Expected 2 Hz over 4 periods by zero-crossing, got %+v", blink)
+	}
+	if math.Abs(blink.DutyCycle-30) > 0.5 {
+		t.Errorf("Expected 30%% duty, got %.1f%%", blink.DutyCycle)
+	}
+	if blink.FrameHz != 20 || blink.NyquistHz() != 10 || blink.Aliased() || len(blink.Warnings) != 0 {
+		t.Errorf("Expected a clean 20 fps measurement, got %+v", blink)
+	}
+
+	// Samples out of order are sorted first
+	reversed := wave(500, 150, 50, 3000)
+	for i, j := 0, len(reversed)-1; i < j; i, j = i+1, j-1 {
+		reversed[i], reversed[j] = reversed[j], reversed[i]
+	}
+	if again, _ := EstimateBlink(reversed); again.Hz != blink.Hz {
+		t.Errorf("Expected the same rate from unsorted samples, got %.3f", again.Hz)
+	}
+}
+
+func TestEstimateBlink_Steady(t *testing.T) {
+	blink, err := EstimateBlink(wave(1000, 1000, 50, 2000))
+	if err != nil || blink.Hz != 0 || blink.DutyCycle != 0 || blink.FrameHz != 20 {
+		t.Errorf("Expected a steady LED, got %+v (%v)", blink, err)
+	}
+}
+
+func TestEstimateBlink_Errors(t *testing.T) {
+	// One pulse has no full period
+	if _, err := EstimateBlink(sampled(50, 200, 300, 500)); !errors.Is(err, ErrTooFewPeriods) {
+		t.Errorf("Expected ErrTooFewPeriods, got %v", err)
+	}
+	if _, err := EstimateBlink(wave(500, 250, 50, 100)); err == nil {
+		t.Error("Expected an error for two frames")
+	}
+	same := []core.LEDSample{{OffsetMs: 0}, {OffsetMs: 0, On: true}, {OffsetMs: 0}}
+	if _, err := EstimateBlink(same); err == nil {
+		t.Error("Expected an error for frames without time between them")
+	}
+}
+
+func TestEstimateBlink_UnevenFrames(t *testing.T) {
+	// Dropped frames leave gaps, but timestamps keep the rate right
+	var samples []core.LEDSample
+	for i, s := range wave(1000, 500, 50, 5000) {
+		if i%20 != 7 {
+			samples = append(samples, s)
+		}
+	}
+	samples = append(samples[:30], samples[34:]...)
+
+	blink, err := EstimateBlink(samples)
+	if err != nil {
+		t.Fatalf("EstimateBlink failed: %v", err)
+	}
+	if math.Abs(blink.Hz-1) > 0.02 {
+		t.Errorf("Expected 1 Hz, got %.3f", blink.Hz)
+	}
+	if len(blink.Warnings) != 1 || !strings.Contains(blink.Warnings[0], "uneven frame spacing") {
+		t.Errorf("Expected an uneven spacing warning, got %v", blink.Warnings)
+	}
+}
+
+func TestEstimateBlink_Nyquist(t *testing.T) {
+	// 5 Hz filmed at 10 fps: every other frame lit, right at the Nyquist limit
+	blink, err := EstimateBlink(wave(200, 100, 100, 3000))
+	if err != nil {
+		t.Fatalf("EstimateBlink failed: %v", err)
+	}
+	if math.Abs(blink.Hz-5) > 0.01 || !blink.Aliased() {
+		t.Errorf("Expected an aliasing-prone 5 Hz, got %+v", blink)
+	}
+	if len(blink.Warnings) != 2 || !strings.Contains(blink.Warnings[0], "Nyquist limit of 10.0 fps") || !strings.Contains(blink.Warnings[1], "single frame") {
+		t.Errorf("Expected Nyquist and single-frame warnings, got %v", blink.Warnings)
+	}
+
+	// 2 Hz at 10 fps has 5 frames per period: no warning
+	if blink, _ := EstimateBlink(wave(500, 200, 100, 3000)); blink.Aliased() || len(blink.Warnings) != 0 {
+		t.Errorf("Expected a clean measurement, got %+v", blink)
+	}
+}
+
+func TestEstimateBlink_Spectrum(t *testing.T) {
+	// A 1 Hz blink with a one-frame glitch splits a period in two: zero-crossing
+	// periods become irregular and the spectrum gives the rate
+	samples := wave(1000, 500, 50, 6000)
+	samples[15].On = true
+	samples[35].On = true
+
+	blink, err := EstimateBlink(samples)
+	if err != nil {
+		t.Fatalf("EstimateBlink failed: %v", err)
+	}
+	if blink.Method != MethodSpectrum || math.Abs(blink.Hz-1) > 0.05 {
+		t.Errorf("Expected 1 Hz from the spectrum, got %+v", blink)
+	}
+	if !strings.Contains(strings.Join(blink.Warnings, "; "), "rate taken from the spectrum") {
+		t.Errorf("Expected a warning about irregular periods, got %v", blink.Warnings)
+	}
+
+	// Brightness is transformed when it varies
+	faded := wave(400, 200, 50, 4000)
+	for i := range faded {
+		faded[i].Brightness = uint8(50 + 40*math.Sin(2*math.Pi*float64(faded[i].OffsetMs)/400))
+	}
+	if hz, ok := spectrumPeak(faded, 10); !ok || math.Abs(hz-2.5) > 0.05 {
+		t.Errorf("Expected a 2.5 Hz brightness peak, got %.3f", hz)
+	}
+}
@@ -0,0 +1,61 @@
+package timeline
+
+import (
+	"sort"
+
+	"github.com/perceptumx/percepta/internal/core"
+)
+
+// Run is a stretch of consecutive frames in which an LED kept the same state.
+// Boundaries sit halfway between the last frame of one run and the first frame
+// of the next, since the real transition happened somewhere in between.
+type Run struct {
+	On      bool
+	StartMs int64
+	EndMs   int64
+	Frames  int
+	Open    bool // Touches the start or end of the capture, so its true length is unknown
+}
+
+// DurationMs is the length of the run
+func (r Run) DurationMs() int64 {
+	return r.EndMs - r.StartMs
+}
+
+// Runs splits a per-frame timeline into runs of equal state.
+// Samples are sorted by offset first; an empty timeline has no runs.
+func Runs(samples []core.LEDSample) []Run {
+	if len(samples) == 0 {
+		return nil
+	}
+
+	sorted := make([]core.LEDSample, len(samples))
+	copy(sorted, samples)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].OffsetMs < sorted[j].OffsetMs })
+
+	runs := []Run{{On: sorted[0].On, StartMs: sorted[0].OffsetMs, Frames: 1, Open: true}}
+	for i := 1; i < len(sorted); i++ {
+		current := &runs[len(runs)-1]
+		if sorted[i].On == current.On {
+			current.Frames++
+			continue
+		}
+		boundary := (sorted[i-1].OffsetMs + sorted[i].OffsetMs) / 2
+		current.EndMs = boundary
+		runs = append(runs, Run{On: sorted[i].On, StartMs: boundary, Frames: 1})
+	}
+
+	last := &runs[len(runs)-1]
+	last.EndMs = sorted[len(sorted)-1].OffsetMs
+	last.Open = true
+	return runs
+}
+
+// Transitions counts the state changes in a timeline
+func Transitions(samples []core.LEDSample) int {
+	runs := Runs(samples)
+	if len(runs) == 0 {
+		return 0
+	}
+	return len(runs) - 1
+}
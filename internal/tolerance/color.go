@@ -0,0 +1,159 @@
+package tolerance
+
+import (
+	"math"
+
+	"github.com/perceptumx/percepta/internal/core"
+)
+
+// achromaticSaturation is the HSV saturation below which a color has no
+// meaningful hue (white, grey, black)
+const achromaticSaturation = 0.15
+
+// rgbDistance is the largest per-channel difference
+func rgbDistance(a, b core.RGB) float64 {
+	d := math.Abs(float64(a.R) - float64(b.R))
+	d = math.Max(d, math.Abs(float64(a.G)-float64(b.G)))
+	return math.Max(d, math.Abs(float64(a.B)-float64(b.B)))
+}
+
+// HSV converts a color to hue (degrees), saturation and value (0-1)
+func HSV(c core.RGB) (h, s, v float64) {
+	r, g, b := float64(c.R)/255, float64(c.G)/255, float64(c.B)/255
+	maxC := math.Max(r, math.Max(g, b))
+	minC := math.Min(r, math.Min(g, b))
+	delta := maxC - minC
+
+	v = maxC
+	if maxC > 0 {
+		s = delta / maxC
+	}
+	if delta == 0 {
+		return 0, s, v
+	}
+
+	switch maxC {
+	case r:
+		h = 60 * math.Mod((g-b)/delta, 6)
+	case g:
+		h = 60 * ((b-r)/delta + 2)
+	default:
+		h = 60 * ((r-g)/delta + 4)
+	}
+	if h < 0 {
+		h += 360
+	}
+	return h, s, v
+}
+
+// hueDistance is the angle between two hues (0-180). Hue is meaningless for
+// greys, so two achromatic colors compare by brightness instead, and an
+// achromatic color never matches a saturated one.
+func hueDistance(a, b core.RGB) float64 {
+	ha, sa, va := HSV(a)
+	hb, sb, vb := HSV(b)
+
+	aGrey, bGrey := sa < achromaticSaturation, sb < achromaticSaturation
+	switch {
+	case aGrey && bGrey:
+		return math.Abs(va-vb) * 180
+	case aGrey != bGrey:
+		return 180
+	}
+
+	d := math.Abs(ha - hb)
+	if d > 180 {
+		d = 360 - d
+	}
+	return d
+}
+
+// lab is a color in CIE L*a*b* (D65 white point)
+type lab struct{ L, A, B float64 }
+
+// toLab converts an sRGB color to CIE L*a*b*
+func toLab(c core.RGB) lab {
+	linear := func(v uint8) float64 {
+		x := float64(v) / 255
+		if x <= 0.04045 {
+			return x / 12.92
+		}
+		return math.Pow((x+0.055)/1.055, 2.4)
+	}
+	r, g, b := linear(c.R), linear(c.G), linear(c.B)
+
+	// sRGB → XYZ, normalised by the D65 reference white
+	x := (0.4124564*r + 0.3575761*g + 0.1804375*b) / 0.95047
+	y := 0.2126729*r + 0.7151522*g + 0.0721750*b
+	z := (0.0193339*r + 0.1191920*g + 0.9503041*b) / 1.08883
+
+	f := func(t float64) float64 {
+		if t > 216.0/24389 {
+			return math.Cbrt(t)
+		}
+		return (24389.0/27*t + 16) / 116
+	}
+	fx, fy, fz := f(x), f(y), f(z)
+	return lab{L: 116*fy - 16, A: 500 * (fx - fy), B: 200 * (fy - fz)}
+}
+
+// ciede2000 is the CIEDE2000 color difference between two L*a*b* colors
+func ciede2000(c1, c2 lab) float64 {
+	const pow25to7 = 6103515625.0 // 25^7
+	rad := math.Pi / 180
+
+	cab := (math.Hypot(c1.A, c1.B) + math.Hypot(c2.A, c2.B)) / 2
+	g := 0.5 * (1 - math.Sqrt(math.Pow(cab, 7)/(math.Pow(cab, 7)+pow25to7)))
+
+	a1, a2 := (1+g)*c1.A, (1+g)*c2.A
+	cp1, cp2 := math.Hypot(a1, c1.B), math.Hypot(a2, c2.B)
+	hp := func(b, a float64) float64 {
+		if a == 0 && b == 0 {
+			return 0
+		}
+		h := math.Atan2(b, a) / rad
+		if h < 0 {
+			h += 360
+		}
+		return h
+	}
+	hp1, hp2 := hp(c1.B, a1), hp(c2.B, a2)
+
+	dL := c2.L - c1.L
+	dC := cp2 - cp1
+	var dh float64
+	if cp1*cp2 != 0 {
+		dh = hp2 - hp1
+		if dh > 180 {
+			dh -= 360
+		} else if dh < -180 {
+			dh += 360
+		}
+	}
+	dH := 2 * math.Sqrt(cp1*cp2) * math.Sin(dh/2*rad)
+
+	lMean := (c1.L + c2.L) / 2
+	cMean := (cp1 + cp2) / 2
+	hMean := hp1 + hp2
+	if cp1*cp2 != 0 {
+		switch {
+		case math.Abs(hp1-hp2) <= 180:
+			hMean /= 2
+		case hp1+hp2 < 360:
+			hMean = (hMean + 360) / 2
+		default:
+			hMean = (hMean - 360) / 2
+		}
+	}
+
+	t := 1 - 0.17*math.Cos((hMean-30)*rad) + 0.24*math.Cos(2*hMean*rad) +
+		0.32*math.Cos((3*hMean+6)*rad) - 0.20*math.Cos((4*hMean-63)*rad)
+	dTheta := 30 * math.Exp(-math.Pow((hMean-275)/25, 2))
+	rc := 2 * math.Sqrt(math.Pow(cMean, 7)/(math.Pow(cMean, 7)+pow25to7))
+	sl := 1 + 0.015*math.Pow(lMean-50, 2)/math.Sqrt(20+math.Pow(lMean-50, 2))
+	sc := 1 + 0.045*cMean
+	sh := 1 + 0.015*cMean*t
+	rt := -math.Sin(2*dTheta*rad) * rc
+
+	return math.Sqrt(math.Pow(dL/sl, 2) + math.Pow(dC/sc, 2) + math.Pow(dH/sh, 2) + rt*(dC/sc)*(dH/sh))
+}
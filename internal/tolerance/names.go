@@ -0,0 +1,101 @@
+package tolerance
+
+import (
+	"strings"
+
+	"github.com/perceptumx/percepta/internal/core"
+)
+
+// darkValue is the HSV value below which a color reads as black: hue and
+// saturation of a dark pixel are mostly sensor noise
+const darkValue = 0.2
+
+// whiteValue is the smallest HSV value of an unsaturated color still called white
+const whiteValue = 0.6
+
+// NamedColor is a color name backed by a hue range, so "blue" still matches
+// when white balance drifts. White and black match on saturation and
+// brightness instead of hue.
+type NamedColor struct {
+	Name string
+	RGB  core.RGB // Representative value, used when only the name is known
+
+	hueMin, hueMax float64 // Degrees; hueMin > hueMax wraps through 0 (red). Unused for white and black.
+}
+
+// namedColors in lookup order. Ranges may overlap: amber LEDs sit between
+// orange and yellow and are reported as either.
+var namedColors = []NamedColor{
+	{Name: "red", RGB: core.RGB{R: 255}, hueMin: 345, hueMax: 15},
+	{Name: "orange", RGB: core.RGB{R: 255, G: 165}, hueMin: 15, hueMax: 40},
+	{Name: "amber", RGB: core.RGB{R: 255, G: 191}, hueMin: 35, hueMax: 50},
+	{Name: "yellow", RGB: core.RGB{R: 255, G: 255}, hueMin: 50, hueMax: 70},
+	{Name: "green", RGB: core.RGB{G: 255}, hueMin: 70, hueMax: 165},
+	{Name: "cyan", RGB: core.RGB{G: 255, B: 255}, hueMin: 165, hueMax: 195},
+	{Name: "blue", RGB: core.RGB{B: 255}, hueMin: 195, hueMax: 260},
+	{Name: "purple", RGB: core.RGB{R: 128, B: 128}, hueMin: 260, hueMax: 320},
+	{Name: "pink", RGB: core.RGB{R: 255, G: 105, B: 180}, hueMin: 320, hueMax: 345},
+	{Name: "white", RGB: core.RGB{R: 255, G: 255, B: 255}},
+	{Name: "black", RGB: core.RGB{}},
+}
+
+// colorAliases maps alternative spellings to a named color
+var colorAliases = map[string]string{
+	"violet":  "purple",
+	"magenta": "purple",
+}
+
+// LookupColor finds a named color (case-insensitive, aliases allowed)
+func LookupColor(name string) (NamedColor, bool) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if alias, ok := colorAliases[name]; ok {
+		name = alias
+	}
+	for _, c := range namedColors {
+		if c.Name == name {
+			return c, true
+		}
+	}
+	return NamedColor{}, false
+}
+
+// ColorNames lists the known color names in lookup order
+func ColorNames() []string {
+	names := make([]string, len(namedColors))
+	for i, c := range namedColors {
+		names[i] = c.Name
+	}
+	return names
+}
+
+// Matches reports whether an observed color falls in the name's range
+func (n NamedColor) Matches(c core.RGB) bool {
+	h, s, v := HSV(c)
+	switch {
+	case n.Name == "black":
+		return v < darkValue
+	case n.Name == "white":
+		return s < achromaticSaturation && v >= whiteValue
+	case v < darkValue || s < achromaticSaturation:
+		return false
+	case n.hueMin > n.hueMax:
+		return h >= n.hueMin || h < n.hueMax
+	}
+	return h >= n.hueMin && h < n.hueMax
+}
+
+// NameOf returns the first named color whose range contains c, or "" if none does
+func NameOf(c core.RGB) string {
+	for _, n := range namedColors {
+		if n.Matches(c) {
+			return n.Name
+		}
+	}
+	return ""
+}
+
+// DeltaE is the perceptual CIEDE2000 difference between two colors (about 2
+// is barely visible), independent of any profile's metric
+func DeltaE(a, b core.RGB) float64 {
+	return ciede2000(toLab(a), toLab(b))
+}
@@ -0,0 +1,56 @@
+package tolerance
+
+import (
+	"testing"
+
+	"github.com/perceptumx/percepta/internal/core"
+)
+
+func TestLookupColor(t *testing.T) {
+	if c, ok := LookupColor(" Amber "); !ok || c.Name != "amber" {
+		t.Errorf("Expected case-insensitive lookup of amber, got %+v, %v", c, ok)
+	}
+	if c, ok := LookupColor("violet"); !ok || c.Name != "purple" {
+		t.Errorf("Expected violet to alias purple, got %+v, %v", c, ok)
+	}
+	if _, ok := LookupColor("teal"); ok {
+		t.Error("Expected unknown color to be rejected")
+	}
+}
+
+func TestNamedColorRanges(t *testing.T) {
+	// Every representative value must be inside its own range
+	for _, name := range ColorNames() {
+		c, _ := LookupColor(name)
+		if !c.Matches(c.RGB) {
+			t.Errorf("Expected %s to match its own RGB %+v", name, c.RGB)
+		}
+	}
+
+	tests := []struct {
+		rgb  core.RGB
+		want string
+	}{
+		{core.RGB{R: 40, G: 60, B: 230}, "blue"},
+		{core.RGB{R: 255, G: 20, B: 40}, "red"}, // Hue wraps through 0
+		{core.RGB{R: 250, G: 180, B: 20}, "amber"},
+		{core.RGB{R: 30, G: 200, B: 60}, "green"},
+		{core.RGB{R: 240, G: 240, B: 235}, "white"},
+		{core.RGB{R: 20, G: 20, B: 25}, "black"},
+		{core.RGB{R: 120, G: 120, B: 120}, ""}, // Mid grey is neither white nor black
+	}
+	for _, tt := range tests {
+		if got := NameOf(tt.rgb); got != tt.want {
+			t.Errorf("NameOf(%+v) = %q, want %q", tt.rgb, got, tt.want)
+		}
+	}
+}
+
+func TestDeltaE(t *testing.T) {
+	if d := DeltaE(core.RGB{B: 255}, core.RGB{B: 255}); d != 0 {
+		t.Errorf("Expected identical colors to have ΔE 0, got %v", d)
+	}
+	if DeltaE(core.RGB{B: 255}, core.RGB{R: 40, G: 60, B: 230}) >= DeltaE(core.RGB{B: 255}, core.RGB{G: 255, B: 255}) {
+		t.Error("Expected drifted blue to be closer to blue than cyan is")
+	}
+}
@@ -0,0 +1,214 @@
+// Package tolerance decides when two observed values are "the same" despite
+// camera and OCR noise. Assertions and diffs share one Profile per device so
+// they agree on what counts as a change.
+package tolerance
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/perceptumx/percepta/internal/core"
+)
+
+// Metric selects how color distance is measured
+type Metric string
+
+const (
+	MetricRGB       Metric = "rgb"       // Largest per-channel difference (0-255)
+	MetricHue       Metric = "hue"       // HSV hue difference in degrees (0-180)
+	MetricCIEDE2000 Metric = "ciede2000" // Perceptual CIEDE2000 ΔE (about 2 is barely visible)
+)
+
+// Default color thresholds per metric
+var defaultColorThreshold = map[Metric]float64{
+	MetricRGB:       5,
+	MetricHue:       15,
+	MetricCIEDE2000: 10,
+}
+
+// Profile holds the tolerances for one device. The zero value is exact:
+// identical blink rates, colors and text.
+type Profile struct {
+	BlinkPercent   float64 // Allowed blink-rate deviation, percent of the expected rate
+	ColorMetric    Metric  // Color distance metric (empty = rgb)
+	ColorThreshold float64 // Largest color distance still considered a match
+	OCRFuzziness   float64 // Fraction of characters (0-1) that may be misread
+
+	BrightnessPoints float64 // Allowed brightness and duty-cycle deviation, percentage points
+}
+
+// Default is the profile used when a device does not configure one:
+// ±10% blink rate, ±5 per RGB channel, exact text, ±10 points brightness
+func Default() Profile {
+	return Profile{
+		BlinkPercent:     10,
+		ColorMetric:      MetricRGB,
+		ColorThreshold:   defaultColorThreshold[MetricRGB],
+		BrightnessPoints: 10,
+	}
+}
+
+// Exact is the zero profile, spelled out for readability at call sites
+func Exact() Profile {
+	return Profile{}
+}
+
+// Overrides are the tolerances a device configures. Nil fields keep the
+// default, so an explicit 0 asks for an exact match.
+type Overrides struct {
+	BlinkPercent     *float64
+	ColorMetric      Metric
+	ColorThreshold   *float64
+	OCRFuzziness     *float64
+	BrightnessPoints *float64
+}
+
+// Merge applies configured overrides to Default. A color metric without a
+// threshold gets that metric's default threshold.
+func Merge(configured Overrides) Profile {
+	p := Default()
+	if configured.BlinkPercent != nil {
+		p.BlinkPercent = *configured.BlinkPercent
+	}
+	if configured.ColorMetric != "" {
+		p.ColorMetric = Metric(strings.ToLower(string(configured.ColorMetric)))
+		p.ColorThreshold = defaultColorThreshold[p.ColorMetric]
+	}
+	if configured.ColorThreshold != nil {
+		p.ColorThreshold = *configured.ColorThreshold
+	}
+	if configured.OCRFuzziness != nil {
+		p.OCRFuzziness = *configured.OCRFuzziness
+	}
+	if configured.BrightnessPoints != nil {
+		p.BrightnessPoints = *configured.BrightnessPoints
+	}
+	return p
+}
+
+// Validate checks the metric name and value ranges
+func (p Profile) Validate() error {
+	switch p.metric() {
+	case MetricRGB, MetricHue, MetricCIEDE2000:
+	default:
+		return fmt.Errorf("unknown color metric %q (expected rgb, hue or ciede2000)", p.ColorMetric)
+	}
+	if p.BlinkPercent < 0 || p.ColorThreshold < 0 || p.BrightnessPoints < 0 {
+		return fmt.Errorf("tolerances must not be negative")
+	}
+	if p.OCRFuzziness < 0 || p.OCRFuzziness >= 1 {
+		return fmt.Errorf("ocr_fuzziness %v must be between 0 and 1", p.OCRFuzziness)
+	}
+	return nil
+}
+
+func (p Profile) metric() Metric {
+	if p.ColorMetric == "" {
+		return MetricRGB
+	}
+	return p.ColorMetric
+}
+
+// String summarises the profile, e.g. "blink ±10%, hue ≤ 15, ocr 20%"
+func (p Profile) String() string {
+	s := fmt.Sprintf("blink ±%s%%, %s ≤ %s", formatNumber(p.BlinkPercent), p.metric(), formatNumber(p.ColorThreshold))
+	if p.OCRFuzziness > 0 {
+		s += fmt.Sprintf(", ocr %s%%", formatNumber(p.OCRFuzziness*100))
+	}
+	return s
+}
+
+// BlinkMatches reports whether an observed blink rate is within tolerance of the expected one
+func (p Profile) BlinkMatches(expected, actual float64) bool {
+	return math.Abs(actual-expected) <= expected*p.BlinkPercent/100
+}
+
+// BrightnessMatches reports whether an observed brightness or duty cycle
+// (percent) is within tolerance of the expected one
+func (p Profile) BrightnessMatches(expected, actual float64) bool {
+	return math.Abs(actual-expected) <= p.BrightnessPoints
+}
+
+// ColorDistance measures how far apart two colors are under the profile's metric
+func (p Profile) ColorDistance(a, b core.RGB) float64 {
+	switch p.metric() {
+	case MetricHue:
+		return hueDistance(a, b)
+	case MetricCIEDE2000:
+		return DeltaE(a, b)
+	}
+	return rgbDistance(a, b)
+}
+
+// ColorsMatch reports whether two colors are within the profile's threshold
+func (p Profile) ColorsMatch(a, b core.RGB) bool {
+	return p.ColorDistance(a, b) <= p.ColorThreshold
+}
+
+// DescribeColorDistance explains a color comparison, e.g. "hue distance 23 > 15"
+func (p Profile) DescribeColorDistance(a, b core.RGB) string {
+	d := p.ColorDistance(a, b)
+	op := "≤"
+	if d > p.ColorThreshold {
+		op = ">"
+	}
+	return fmt.Sprintf("%s distance %s %s %s", p.metric(), formatNumber(math.Round(d*10)/10), op, formatNumber(p.ColorThreshold))
+}
+
+// TextEquals compares two readings of a whole display, allowing the profile's
+// share of characters to differ. Fuzzy profiles also fold confusable glyphs.
+func (p Profile) TextEquals(a, b string) bool {
+	if p.OCRFuzziness <= 0 {
+		return a == b
+	}
+	ra, rb := fold([]rune(a)), fold([]rune(b))
+	return editDistance(ra, rb) <= p.allowedEdits(max(len(ra), len(rb)))
+}
+
+// TextMatch describes the substring of a display reading closest to the
+// expected text
+type TextMatch struct {
+	Closest    string  // Substring of the reading that best matches the expected text
+	Distance   int     // Edits between Closest and the expected text (confusables folded when fuzzy)
+	Similarity float64 // 1 - Distance/len(expected), 0-1
+}
+
+// MatchText finds the closest match for expected in text and reports whether
+// it is within the profile's OCR fuzziness. Fuzzy profiles fold confusable
+// glyphs (0/O, 1/l, ...) first; exact profiles require a literal substring.
+func (p Profile) MatchText(text, expected string) (TextMatch, bool) {
+	pattern, runes := []rune(expected), []rune(text)
+	if len(pattern) == 0 {
+		return TextMatch{Similarity: 1}, true
+	}
+
+	folded, foldedText := pattern, runes
+	if p.OCRFuzziness > 0 {
+		folded, foldedText = fold(pattern), fold(runes)
+	}
+	distance, start, end := closestSubstring(folded, foldedText)
+	match := TextMatch{
+		Closest:    string(runes[start:end]),
+		Distance:   distance,
+		Similarity: math.Max(0, 1-float64(distance)/float64(len(pattern))),
+	}
+	return match, distance <= p.allowedEdits(len(pattern))
+}
+
+// allowedEdits is the number of misread characters tolerated in a text of
+// the given length. The epsilon keeps 0.2*5 from rounding down to 0.
+func (p Profile) allowedEdits(length int) int {
+	return int(math.Floor(p.OCRFuzziness*float64(length) + 1e-9))
+}
+
+// WithSimilarity returns a copy of the profile accepting text whose
+// similarity to the expected text is at least s (0-1]
+func (p Profile) WithSimilarity(s float64) Profile {
+	p.OCRFuzziness = 1 - s
+	return p
+}
+
+func formatNumber(v float64) string {
+	return strings.TrimSuffix(strings.TrimRight(fmt.Sprintf("%.2f", v), "0"), ".")
+}
@@ -0,0 +1,255 @@
+package tolerance
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/perceptumx/percepta/internal/core"
+)
+
+// value is a configured tolerance
+func value(v float64) *float64 {
+	return &v
+}
+
+func TestMerge(t *testing.T) {
+	p := Merge(Overrides{ColorMetric: "HUE", OCRFuzziness: value(0.2)})
+	if p.BlinkPercent != 10 || p.ColorMetric != MetricHue || p.ColorThreshold != 15 || p.OCRFuzziness != 0.2 {
+		t.Errorf("Expected defaults with hue metric, got %+v", p)
+	}
+
+	p = Merge(Overrides{BlinkPercent: value(25), ColorThreshold: value(40)})
+	if p.BlinkPercent != 25 || p.ColorMetric != MetricRGB || p.ColorThreshold != 40 {
+		t.Errorf("Expected configured blink/threshold, got %+v", p)
+	}
+
+	if p := Merge(Overrides{BrightnessPoints: value(20)}); p.BrightnessPoints != 20 {
+		t.Errorf("Expected configured brightness tolerance, got %+v", p)
+	}
+
+	// An explicit 0 is an exact match, not a fallback to the default
+	p = Merge(Overrides{BlinkPercent: value(0), ColorMetric: MetricHue, ColorThreshold: value(0), BrightnessPoints: value(0)})
+	if p.BlinkPercent != 0 || p.ColorThreshold != 0 || p.BrightnessPoints != 0 {
+		t.Errorf("Expected configured zeros to be kept, got %+v", p)
+	}
+
+	if Merge(Overrides{}) != Default() {
+		t.Error("Expected empty config to yield the default profile")
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		profile Profile
+		wantErr string
+	}{
+		{Default(), ""},
+		{Exact(), ""},
+		{Profile{ColorMetric: "lab"}, "unknown color metric"},
+		{Profile{BlinkPercent: -1}, "must not be negative"},
+		{Profile{BrightnessPoints: -5}, "must not be negative"},
+		{Profile{OCRFuzziness: 1.5}, "between 0 and 1"},
+	}
+
+	for _, tt := range tests {
+		err := tt.profile.Validate()
+		if tt.wantErr == "" && err != nil {
+			t.Errorf("Expected %+v to be valid, got %v", tt.profile, err)
+		}
+		if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+			t.Errorf("Expected error containing %q for %+v, got %v", tt.wantErr, tt.profile, err)
+		}
+	}
+}
+
+func TestBlinkMatches(t *testing.T) {
+	tests := []struct {
+		name     string
+		profile  Profile
+		expected float64
+		actual   float64
+		want     bool
+	}{
+		{"within 10%", Default(), 2.0, 2.15, true},
+		{"outside 10%", Default(), 2.0, 2.5, false},
+		{"wider profile", Profile{BlinkPercent: 30}, 2.0, 2.5, true},
+		{"exact", Exact(), 2.0, 2.15, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.profile.BlinkMatches(tt.expected, tt.actual); got != tt.want {
+				t.Errorf("BlinkMatches(%v, %v) = %v, want %v", tt.expected, tt.actual, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBrightnessMatches(t *testing.T) {
+	if !Default().BrightnessMatches(50, 58) {
+		t.Error("Expected 58% to match 50% within 10 points")
+	}
+	if Default().BrightnessMatches(50, 65) {
+		t.Error("Expected 65% not to match 50% within 10 points")
+	}
+	if Exact().BrightnessMatches(50, 51) {
+		t.Error("Expected the exact profile to reject any deviation")
+	}
+}
+
+func TestColorsMatch(t *testing.T) {
+	blue := core.RGB{B: 255}
+	webcamBlue := core.RGB{R: 40, G: 60, B: 230} // White balance drift
+	cyan := core.RGB{G: 255, B: 255}
+
+	tests := []struct {
+		name    string
+		profile Profile
+		a, b    core.RGB
+		want    bool
+	}{
+		{"rgb within 5", Default(), blue, core.RGB{R: 3, G: 2, B: 251}, true},
+		{"rgb drift fails", Default(), blue, webcamBlue, false},
+		{"hue drift passes", Merge(Overrides{ColorMetric: MetricHue}), blue, webcamBlue, true},
+		{"hue rejects cyan", Merge(Overrides{ColorMetric: MetricHue}), blue, cyan, false},
+		{"hue rejects grey vs blue", Merge(Overrides{ColorMetric: MetricHue}), blue, core.RGB{R: 200, G: 200, B: 205}, false},
+		{"ciede2000 drift", Merge(Overrides{ColorMetric: MetricCIEDE2000, ColorThreshold: value(15)}), blue, webcamBlue, true},
+		{"ciede2000 rejects cyan", Merge(Overrides{ColorMetric: MetricCIEDE2000}), blue, cyan, false},
+		{"exact", Exact(), blue, core.RGB{B: 254}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.profile.ColorsMatch(tt.a, tt.b); got != tt.want {
+				t.Errorf("ColorsMatch(%v, %v) = %v (%s), want %v", tt.a, tt.b, got, tt.profile.DescribeColorDistance(tt.a, tt.b), tt.want)
+			}
+		})
+	}
+}
+
+func TestCIEDE2000_ReferenceData(t *testing.T) {
+	// Pairs from Sharma, Wu & Dalal (2005), "The CIEDE2000 color-difference formula"
+	tests := []struct {
+		a, b lab
+		want float64
+	}{
+		{lab{50, 2.6772, -79.7751}, lab{50, 0, -82.7485}, 2.0425},
+		{lab{50, -1.3802, -84.2814}, lab{50, 0, -82.7485}, 1.0},
+		{lab{50, 2.5, 0}, lab{73, 25, -18}, 27.1492},
+		{lab{60.2574, -34.0099, 36.2677}, lab{60.4626, -34.1751, 39.4387}, 1.2644},
+	}
+
+	for _, tt := range tests {
+		if got := ciede2000(tt.a, tt.b); math.Abs(got-tt.want) > 1e-4 {
+			t.Errorf("ciede2000(%v, %v) = %.4f, want %.4f", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestHSV(t *testing.T) {
+	h, s, v := HSV(core.RGB{R: 255, G: 191})
+	if math.Abs(h-45) > 0.5 || s != 1 || v != 1 {
+		t.Errorf("Expected amber hue 45°, got h=%.1f s=%.2f v=%.2f", h, s, v)
+	}
+}
+
+// matches reports whether MatchText finds expected in text
+func matches(p Profile, text, expected string) bool {
+	_, ok := p.MatchText(text, expected)
+	return ok
+}
+
+func TestTextMatching(t *testing.T) {
+	fuzzy := Profile{OCRFuzziness: 0.2}
+
+	if !matches(fuzzy, "Temp: 23.5C READY", "READY") {
+		t.Error("Expected exact substring to match")
+	}
+	if !matches(fuzzy, "Temp: 23.5C REA0Y", "READY") {
+		t.Error("Expected one misread character in five to match at 20%")
+	}
+	if matches(fuzzy, "Temp: 23.5C RE4D0", "READY") {
+		t.Error("Expected three misread characters in five not to match at 20%")
+	}
+	if matches(Exact(), "REA0Y", "READY") {
+		t.Error("Expected exact profile to require an exact substring")
+	}
+
+	if !fuzzy.TextEquals("Connecting", "Connectinq") || fuzzy.TextEquals("Connecting", "Connected") {
+		t.Error("Unexpected whole-text fuzzy comparison")
+	}
+	if Exact().TextEquals("a", "b") || !Exact().TextEquals("a", "a") {
+		t.Error("Unexpected exact whole-text comparison")
+	}
+}
+
+func TestMatchText(t *testing.T) {
+	tests := []struct {
+		name           string
+		profile        Profile
+		text, expected string
+		wantClosest    string
+		wantSimilarity float64
+		wantOK         bool
+	}{
+		{"exact substring", Exact(), "Status: Ready", "Ready", "Ready", 1, true},
+		{"one misread", Profile{OCRFuzziness: 0.2}, "Status: Readv", "Ready", "Readv", 0.8, true},
+		{"exact reports closest", Exact(), "Status: Readv", "Ready", "Readv", 0.8, false},
+		{"confusables folded", Profile{OCRFuzziness: 0.1}, "B00T 1oaded", "BOOT loaded", "B00T 1oaded", 1, true},
+		{"exact keeps confusables", Exact(), "B00T", "BOOT", "B00T", 0.5, false},
+		{"too different", Profile{OCRFuzziness: 0.2}, "Status: Rxxdy", "Ready", "Rxxdy", 0.6, false},
+		{"empty expected", Exact(), "anything", "", "", 1, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			match, ok := tt.profile.MatchText(tt.text, tt.expected)
+			if ok != tt.wantOK {
+				t.Errorf("MatchText(%q, %q) ok = %v, want %v", tt.text, tt.expected, ok, tt.wantOK)
+			}
+			if match.Closest != tt.wantClosest {
+				t.Errorf("Expected closest %q, got %q", tt.wantClosest, match.Closest)
+			}
+			if math.Abs(match.Similarity-tt.wantSimilarity) > 1e-9 {
+				t.Errorf("Expected similarity %.2f, got %.2f", tt.wantSimilarity, match.Similarity)
+			}
+		})
+	}
+}
+
+func TestEditDistance(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"BLINK", "BLINK", 0},
+		{"BLINKS", "BLINK", 1},
+		{"gren", "green", 1},
+		{"EVENTUALY", "EVENTUALLY", 1},
+		{"", "ON", 2},
+		{"°C", "C", 1},
+	}
+	for _, tt := range tests {
+		if got := EditDistance(tt.a, tt.b); got != tt.want {
+			t.Errorf("EditDistance(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestWithSimilarity(t *testing.T) {
+	p := Default().WithSimilarity(0.8)
+	if !matches(p, "Readv", "Ready") || matches(p, "Rexdv", "Ready") {
+		t.Errorf("Expected similarity 0.8 to allow one edit in five, got %+v", p)
+	}
+	if p.BlinkPercent != 10 {
+		t.Error("Expected other tolerances to be kept")
+	}
+}
+
+func TestProfileString(t *testing.T) {
+	p := Merge(Overrides{ColorMetric: MetricHue, OCRFuzziness: value(0.2)})
+	if got := p.String(); got != "blink ±10%, hue ≤ 15, ocr 20%" {
+		t.Errorf("Unexpected String(): %q", got)
+	}
+}
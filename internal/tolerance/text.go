@@ -0,0 +1,105 @@
+package tolerance
+
+// confusables folds glyphs that OCR commonly reads as one another, so that
+// "B00T" matches "BOOT" without spending any of the edit budget
+var confusables = map[rune]rune{
+	'0': 'O', 'o': 'O',
+	'1': 'l', 'I': 'l', '|': 'l',
+	'5': 'S',
+	'8': 'B',
+	'2': 'Z',
+}
+
+// fold maps confusable glyphs to one representative, rune for rune
+func fold(s []rune) []rune {
+	out := make([]rune, len(s))
+	for i, r := range s {
+		if f, ok := confusables[r]; ok {
+			r = f
+		}
+		out[i] = r
+	}
+	return out
+}
+
+// EditDistance is the Levenshtein distance between a and b, in runes
+func EditDistance(a, b string) int {
+	return editDistance([]rune(a), []rune(b))
+}
+
+// editDistance is the Levenshtein distance between a and b
+func editDistance(a, b []rune) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
+
+// closestSubstring finds the substring text[start:end] with the smallest edit
+// distance to pattern (Sellers' algorithm: matching may start and end
+// anywhere). Ties go to the substring closest in length to pattern, then the
+// earliest one.
+func closestSubstring(pattern, text []rune) (distance, start, end int) {
+	// prev[j] is the best distance of pattern[:i] ending at text[j];
+	// prevFrom[j] is where that alignment starts in text
+	prev := make([]int, len(text)+1) // Row 0 is all zeros: free start position
+	curr := make([]int, len(text)+1)
+	prevFrom := make([]int, len(text)+1)
+	currFrom := make([]int, len(text)+1)
+	for j := range prevFrom {
+		prevFrom[j] = j
+	}
+
+	for i := 1; i <= len(pattern); i++ {
+		curr[0], currFrom[0] = i, 0
+		for j := 1; j <= len(text); j++ {
+			cost := 1
+			if pattern[i-1] == text[j-1] {
+				cost = 0
+			}
+			// Prefer the diagonal so matched characters stay inside the substring
+			curr[j], currFrom[j] = prev[j-1]+cost, prevFrom[j-1]
+			if d := prev[j] + 1; d < curr[j] {
+				curr[j], currFrom[j] = d, prevFrom[j]
+			}
+			if d := curr[j-1] + 1; d < curr[j] {
+				curr[j], currFrom[j] = d, currFrom[j-1]
+			}
+		}
+		prev, curr = curr, prev
+		prevFrom, currFrom = currFrom, prevFrom
+	}
+
+	distance, start, end = prev[0], 0, 0
+	for j := 1; j <= len(text); j++ {
+		better := prev[j] < distance
+		if prev[j] == distance {
+			better = abs(j-prevFrom[j]-len(pattern)) < abs(end-start-len(pattern))
+		}
+		if better {
+			distance, start, end = prev[j], prevFrom[j], j
+		}
+	}
+	return distance, start, end
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,172 @@
+package vision
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/jpeg"
+	"sort"
+
+	"github.com/perceptumx/percepta/internal/core"
+)
+
+const (
+	// minBlobArea is the fewest bright pixels that make a candidate LED rather than noise
+	minBlobArea = 4
+
+	// maxBlobShare is the largest share of the frame a candidate LED may cover;
+	// bigger bright areas are backlights, displays or reflections
+	maxBlobShare = 0.01
+
+	// maxBlobs caps the candidates proposed for one frame, keeping the largest
+	maxBlobs = 24
+)
+
+// Blob is a connected patch of bright pixels in a frame, a candidate LED
+type Blob struct {
+	Bounds     image.Rectangle // Bright pixels only
+	Region     image.Rectangle // Area to measure: Bounds with a margin for the glow and slight camera shifts
+	Area       int             // Number of bright pixels
+	Color      core.RGB        // Color of the glow, as the local parser measures it
+	ColorName  string
+	Brightness uint8 // Peak brightness, percent
+}
+
+// FindBlobs proposes candidate LEDs in a JPEG frame: patches of pixels bright
+// enough to read as a lit LED, neither single-pixel noise nor large lit
+// areas. Blobs are in reading order, top to bottom, then left to right.
+// Unlit LEDs are not found.
+func FindBlobs(frame []byte) ([]Blob, error) {
+	img, err := jpeg.Decode(bytes.NewReader(frame))
+	if err != nil {
+		return nil, fmt.Errorf("frame decode failed: %w", err)
+	}
+	return findBlobs(img), nil
+}
+
+func findBlobs(img image.Image) []Blob {
+	b := img.Bounds()
+	w, h := b.Dx(), b.Dy()
+	bright := make([]bool, w*h)
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			bright[y*w+x] = pixelLevel(img, b.Min.X+x, b.Min.Y+y) >= litLevel
+		}
+	}
+
+	var blobs []Blob
+	for _, c := range components(bright, w, h) {
+		blobs = append(blobs, Blob{Bounds: c.bounds.Add(b.Min), Area: c.area})
+	}
+	sort.SliceStable(blobs, func(i, j int) bool { return blobs[i].Area > blobs[j].Area })
+	if len(blobs) > maxBlobs {
+		blobs = blobs[:maxBlobs]
+	}
+	for i := range blobs {
+		blobs[i].Region = blobRegion(blobs[i].Bounds, b)
+		led := measureLED(img, "", blobs[i].Region)
+		blobs[i].Color, blobs[i].ColorName, blobs[i].Brightness = led.Color, led.ColorName, led.Brightness
+	}
+	sort.SliceStable(blobs, func(i, j int) bool { return readingOrder(blobs[i].Bounds, blobs[j].Bounds) })
+	return blobs
+}
+
+// pixelLevel is a pixel's brightness (0-255) as the local parser measures it:
+// its largest channel
+func pixelLevel(img image.Image, x, y int) float64 {
+	c := color.RGBAModel.Convert(img.At(x, y)).(color.RGBA)
+	return float64(max(c.R, c.G, c.B))
+}
+
+// component is a connected patch of a pixel mask
+type component struct {
+	bounds image.Rectangle
+	area   int
+}
+
+// components labels the 8-connected patches of a w×h mask with a flood fill,
+// keeping those large enough not to be noise and small enough to be an LED
+func components(mask []bool, w, h int) []component {
+	maxArea := int(float64(w*h) * maxBlobShare)
+	seen := make([]bool, w*h)
+	var found []component
+	var stack []int
+	for start := range mask {
+		if !mask[start] || seen[start] {
+			continue
+		}
+		seen[start] = true
+		stack = append(stack[:0], start)
+		area := 0
+		bounds := image.Rect(start%w, start/w, start%w+1, start/w+1)
+		for len(stack) > 0 {
+			i := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			area++
+			x, y := i%w, i/w
+			bounds = bounds.Union(image.Rect(x, y, x+1, y+1))
+			for dy := -1; dy <= 1; dy++ {
+				for dx := -1; dx <= 1; dx++ {
+					nx, ny := x+dx, y+dy
+					if nx < 0 || ny < 0 || nx >= w || ny >= h {
+						continue
+					}
+					if j := ny*w + nx; mask[j] && !seen[j] {
+						seen[j] = true
+						stack = append(stack, j)
+					}
+				}
+			}
+		}
+		if area >= minBlobArea && area <= maxArea {
+			found = append(found, component{bounds: bounds, area: area})
+		}
+	}
+	return found
+}
+
+// blobRegion is the area to measure for a blob: its bounds with a margin for
+// the glow and slight camera shifts, within the frame
+func blobRegion(bounds, frame image.Rectangle) image.Rectangle {
+	margin := max(2, bounds.Dx()/2, bounds.Dy()/2)
+	return bounds.Inset(-margin).Intersect(frame)
+}
+
+// readingOrder reports whether a comes before b top to bottom, then left to
+// right; rectangles whose vertical extents overlap are on the same row
+func readingOrder(a, b image.Rectangle) bool {
+	if a.Max.Y <= b.Min.Y || b.Max.Y <= a.Min.Y {
+		return a.Min.Y < b.Min.Y
+	}
+	return a.Min.X < b.Min.X
+}
+
+// DrawBoxes outlines rectangles on a copy of a JPEG frame, for showing
+// proposed regions to the user
+func DrawBoxes(frame []byte, boxes []image.Rectangle, c color.Color) ([]byte, error) {
+	src, err := jpeg.Decode(bytes.NewReader(frame))
+	if err != nil {
+		return nil, fmt.Errorf("frame decode failed: %w", err)
+	}
+	img := image.NewRGBA(src.Bounds())
+	draw.Draw(img, img.Bounds(), src, src.Bounds().Min, draw.Src)
+
+	for _, r := range boxes {
+		for x := r.Min.X; x < r.Max.X; x++ {
+			img.Set(x, r.Min.Y, c)
+			img.Set(x, r.Max.Y-1, c)
+		}
+		for y := r.Min.Y; y < r.Max.Y; y++ {
+			img.Set(r.Min.X, y, c)
+			img.Set(r.Max.X-1, y, c)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: 90}); err != nil {
+		return nil, fmt.Errorf("frame encode failed: %w", err)
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,108 @@
+package vision
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"testing"
+)
+
+// blobFrame renders a dark 320x240 frame with a filled rectangle per light
+func blobFrame(t *testing.T, lights map[image.Rectangle]color.RGBA) []byte {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, 320, 240))
+	for py := 0; py < 240; py++ {
+		for px := 0; px < 320; px++ {
+			img.Set(px, py, color.RGBA{R: 20, G: 20, B: 20, A: 255})
+		}
+	}
+	for rect, c := range lights {
+		for py := rect.Min.Y; py < rect.Max.Y; py++ {
+			for px := rect.Min.X; px < rect.Max.X; px++ {
+				img.Set(px, py, c)
+			}
+		}
+	}
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: 95}); err != nil {
+		t.Fatalf("jpeg encode failed: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestFindBlobs(t *testing.T) {
+	green := color.RGBA{G: 230, B: 40, A: 255}
+	blue := color.RGBA{R: 20, G: 40, B: 240, A: 255}
+	frame := blobFrame(t, map[image.Rectangle]color.RGBA{
+		image.Rect(200, 40, 210, 50):   green,                            // Top row, right
+		image.Rect(40, 44, 48, 52):     blue,                             // Top row, left, slightly lower
+		image.Rect(100, 150, 112, 162): green,                            // Second row
+		image.Rect(300, 10, 301, 11):   {R: 255, G: 255, B: 255, A: 255}, // Hot pixel
+		image.Rect(0, 180, 320, 240):   {R: 200, G: 200, B: 200, A: 255}, // Backlit panel
+	})
+
+	blobs, err := FindBlobs(frame)
+	if err != nil {
+		t.Fatalf("FindBlobs failed: %v", err)
+	}
+	if len(blobs) != 3 {
+		t.Fatalf("Expected 3 candidate LEDs without the hot pixel and the panel, got %+v", blobs)
+	}
+
+	// Reading order: the two top-row LEDs left to right, then the second row
+	if blobs[0].ColorName != "blue" || blobs[1].ColorName != "green" || blobs[2].Bounds.Min.Y < 140 {
+		t.Errorf("Expected blue, green, then the lower green LED, got %+v", blobs)
+	}
+	for _, b := range blobs {
+		if !b.Bounds.In(b.Region) || b.Region.Eq(b.Bounds) {
+			t.Errorf("Expected the region to add a margin around %v, got %v", b.Bounds, b.Region)
+		}
+		if b.Brightness < 80 {
+			t.Errorf("Expected a bright LED, got %d%%", b.Brightness)
+		}
+	}
+	if b := blobs[1]; b.Area < 80 || b.Bounds.Dx() < 9 || b.Bounds.Dx() > 12 {
+		t.Errorf("Expected a 10x10 blob, got %v with area %d", b.Bounds, b.Area)
+	}
+
+	if _, err := FindBlobs([]byte("not a jpeg")); err == nil {
+		t.Error("Expected an error for an undecodable frame")
+	}
+}
+
+func TestFindBlobs_EdgeRegion(t *testing.T) {
+	frame := blobFrame(t, map[image.Rectangle]color.RGBA{
+		image.Rect(0, 0, 6, 6): {R: 240, G: 30, B: 30, A: 255},
+	})
+	blobs, err := FindBlobs(frame)
+	if err != nil || len(blobs) != 1 {
+		t.Fatalf("Expected one blob, got %+v (%v)", blobs, err)
+	}
+	if r := blobs[0].Region; r.Min != (image.Point{}) || !r.In(image.Rect(0, 0, 320, 240)) {
+		t.Errorf("Expected the region clipped to the frame, got %v", r)
+	}
+}
+
+func TestDrawBoxes(t *testing.T) {
+	frame := blobFrame(t, nil)
+	box := image.Rect(50, 50, 90, 80)
+
+	out, err := DrawBoxes(frame, []image.Rectangle{box}, color.RGBA{R: 255, A: 255})
+	if err != nil {
+		t.Fatalf("DrawBoxes failed: %v", err)
+	}
+	img, err := jpeg.Decode(bytes.NewReader(out))
+	if err != nil {
+		t.Fatalf("Expected a JPEG, got %v", err)
+	}
+	if img.Bounds() != image.Rect(0, 0, 320, 240) {
+		t.Errorf("Expected the frame size to be kept, got %v", img.Bounds())
+	}
+	if r, _, _, _ := img.At(70, 50).RGBA(); r>>8 < 120 {
+		t.Errorf("Expected a red outline at the box edge, got red %d", r>>8)
+	}
+	if r, _, _, _ := img.At(70, 65).RGBA(); r>>8 > 60 {
+		t.Errorf("Expected the box inside to be unchanged, got red %d", r>>8)
+	}
+}
@@ -0,0 +1,247 @@
+package vision
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/perceptumx/percepta/internal/core"
+)
+
+// bootSettle is the stretch at the end of a boot capture whose brightness
+// levels define the steady state; blinking that stays within them counts as
+// steady, so it must span at least one period of any heartbeat LED
+const bootSettle = 2 * time.Second
+
+// BootCapture times a boot sequence from a start trigger. Like TimelineCapture
+// it measures brightness locally in every frame to keep up with the camera;
+// only the search for the first text calls the vision model, on a handful of
+// the recorded frames.
+type BootCapture struct {
+	camera   core.CameraDriver
+	parser   SignalParser  // Finds the first frame with display text; nil skips first_text
+	interval time.Duration // Time between frames
+	duration time.Duration // Total capture length
+	settle   time.Duration // End-of-capture window that defines the steady state
+}
+
+func NewBootCapture(camera core.CameraDriver, parser SignalParser, fps int, duration time.Duration) *BootCapture {
+	if fps <= 0 {
+		fps = 20
+	}
+	if duration <= 0 {
+		duration = 15 * time.Second
+	}
+	return &BootCapture{
+		camera:   camera,
+		parser:   parser,
+		interval: time.Second / time.Duration(fps),
+		duration: duration,
+		settle:   bootSettle,
+	}
+}
+
+// Capture calls trigger to power on or reset the device, then records frames
+// for the configured duration and detects the boot milestones. Offsets count
+// from the moment trigger returns. regions maps LED names to pixel areas whose
+// first lighting up becomes a "led.<name>" milestone.
+// The camera must already be open.
+func (c *BootCapture) Capture(trigger func() error, regions map[string]image.Rectangle) (core.BootTimingSignal, error) {
+	// The first frame after opening can take much longer than the rest
+	if _, err := c.camera.CaptureFrame(); err != nil {
+		return core.BootTimingSignal{}, fmt.Errorf("warm-up frame capture failed: %w", err)
+	}
+	if err := trigger(); err != nil {
+		return core.BootTimingSignal{}, fmt.Errorf("start trigger failed: %w", err)
+	}
+
+	var frames [][]byte
+	var offsets []int64
+	var grids [][]float64
+	levels := make(map[string][]float64, len(regions))
+
+	start := time.Now()
+	for frameStart := start; time.Since(start) < c.duration || len(offsets) == 0; {
+		frame, err := c.camera.CaptureFrame()
+		if err != nil {
+			return core.BootTimingSignal{}, fmt.Errorf("frame %d capture failed: %w", len(offsets), err)
+		}
+		offset := time.Since(start).Milliseconds()
+
+		img, err := jpeg.Decode(bytes.NewReader(frame))
+		if err != nil {
+			return core.BootTimingSignal{}, fmt.Errorf("frame %d decode failed: %w", len(offsets), err)
+		}
+		for name, rect := range regions {
+			levels[name] = append(levels[name], meanLuminance(img, rect))
+		}
+		grids = append(grids, cellLuminance(img, locateCellSize))
+		frames = append(frames, frame)
+		offsets = append(offsets, offset)
+
+		if wait := c.interval - time.Since(frameStart); wait > 0 {
+			time.Sleep(wait)
+		}
+		frameStart = time.Now()
+	}
+
+	steady := steadyFrame(offsets, grids, c.settle.Milliseconds())
+	if steady == 0 {
+		return core.BootTimingSignal{}, fmt.Errorf("the picture did not change after the start trigger; check that the camera shows the device and that it restarted")
+	}
+
+	signal := core.BootTimingSignal{
+		DurationMs: offsets[len(offsets)-1],
+		Confidence: bootConfidence(grids),
+		Incomplete: steady < 0,
+	}
+	if !signal.Incomplete {
+		signal.DurationMs = offsets[steady]
+		signal.Milestones = append(signal.Milestones, core.BootMilestone{Name: core.MilestoneSteady, OffsetMs: offsets[steady]})
+	}
+	if i := firstLit(grids); i >= 0 {
+		signal.Milestones = append(signal.Milestones, core.BootMilestone{Name: core.MilestoneFirstLED, OffsetMs: offsets[i]})
+	}
+	for name, series := range levels {
+		if i := firstRise(series); i >= 0 {
+			signal.Milestones = append(signal.Milestones, core.BootMilestone{Name: "led." + name, OffsetMs: offsets[i]})
+		}
+	}
+	if c.parser != nil {
+		i, err := firstTextFrame(frames, c.parser)
+		if err != nil {
+			return core.BootTimingSignal{}, err
+		}
+		if i >= 0 {
+			signal.Milestones = append(signal.Milestones, core.BootMilestone{Name: core.MilestoneFirstText, OffsetMs: offsets[i]})
+		}
+	}
+
+	sort.SliceStable(signal.Milestones, func(i, j int) bool {
+		a, b := signal.Milestones[i], signal.Milestones[j]
+		if a.OffsetMs != b.OffsetMs {
+			return a.OffsetMs < b.OffsetMs
+		}
+		return a.Name < b.Name
+	})
+	return signal, nil
+}
+
+// firstRise is the first frame whose level is at least minOnOffContrast above
+// the first frame's, or -1
+func firstRise(series []float64) int {
+	for i, level := range series {
+		if level-series[0] >= minOnOffContrast {
+			return i
+		}
+	}
+	return -1
+}
+
+// firstLit is the first frame in which any grid cell lit up, or -1
+func firstLit(grids [][]float64) int {
+	first := -1
+	for cell := range grids[0] {
+		series := make([]float64, len(grids))
+		for i, grid := range grids {
+			series[i] = grid[cell]
+		}
+		if i := firstRise(series); i >= 0 && (first < 0 || i < first) {
+			first = i
+		}
+	}
+	return first
+}
+
+// steadyFrame is the first frame from which every cell stays within the
+// brightness range it shows during the last settleMs of the capture. It is -1
+// when the capture is too short to tell: the range must already have held for
+// half the settle window before it.
+func steadyFrame(offsets []int64, grids [][]float64, settleMs int64) int {
+	last := offsets[len(offsets)-1]
+	ref := sort.Search(len(offsets), func(i int) bool { return offsets[i] >= last-settleMs })
+	if ref == 0 {
+		return -1
+	}
+
+	lo := make([]float64, len(grids[0]))
+	hi := make([]float64, len(grids[0]))
+	for cell := range lo {
+		lo[cell], hi[cell] = math.Inf(1), math.Inf(-1)
+		for _, grid := range grids[ref:] {
+			lo[cell] = math.Min(lo[cell], grid[cell])
+			hi[cell] = math.Max(hi[cell], grid[cell])
+		}
+	}
+
+	within := func(grid []float64) bool {
+		for cell, level := range grid {
+			if level < lo[cell]-minOnOffContrast/2 || level > hi[cell]+minOnOffContrast/2 {
+				return false
+			}
+		}
+		return true
+	}
+
+	steady := ref
+	for steady > 0 && within(grids[steady-1]) {
+		steady--
+	}
+	if steady > 0 && offsets[ref]-offsets[steady] < settleMs/2 {
+		return -1
+	}
+	return steady
+}
+
+// bootConfidence rates how clearly the boot stood out from the first frame:
+// the largest brightness change of any cell against confidentContrast
+func bootConfidence(grids [][]float64) float64 {
+	var change float64
+	for _, grid := range grids[1:] {
+		for cell, level := range grid {
+			change = math.Max(change, math.Abs(level-grids[0][cell]))
+		}
+	}
+	return math.Min(1, change/confidentContrast)
+}
+
+// firstTextFrame binary-searches the frames for the first one in which the
+// parser reads display text, assuming text stays once it appears. It returns
+// -1 when the last frame shows no text.
+func firstTextFrame(frames [][]byte, parser SignalParser) (int, error) {
+	hasText := func(i int) (bool, error) {
+		signals, err := parser.Parse(frames[i])
+		if err != nil {
+			return false, fmt.Errorf("frame %d analysis failed: %w", i, err)
+		}
+		for _, signal := range signals {
+			if display, ok := signal.(core.DisplaySignal); ok && strings.TrimSpace(display.Text) != "" {
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+
+	lo, hi := 0, len(frames)-1
+	if ok, err := hasText(hi); err != nil || !ok {
+		return -1, err
+	}
+	for lo < hi {
+		mid := (lo + hi) / 2
+		ok, err := hasText(mid)
+		if err != nil {
+			return -1, err
+		}
+		if ok {
+			hi = mid
+		} else {
+			lo = mid + 1
+		}
+	}
+	return lo, nil
+}
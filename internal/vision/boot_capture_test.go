@@ -0,0 +1,198 @@
+package vision
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/perceptumx/percepta/internal/core"
+)
+
+// textParser reports display text for the frames in withText
+type textParser struct {
+	withText map[string]bool
+	calls    int
+}
+
+func (p *textParser) Parse(frame []byte) ([]core.Signal, error) {
+	p.calls++
+	if p.withText[string(frame)] {
+		return []core.Signal{core.DisplaySignal{Name: "lcd", Text: "READY", Confidence: 0.9}}, nil
+	}
+	return []core.Signal{core.DisplaySignal{Name: "lcd", Confidence: 0.9}}, nil
+}
+
+// bootFrame renders a dark 64x48 frame with the given 8x8 squares lit
+func bootFrame(t *testing.T, lit ...image.Point) []byte {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, 64, 48))
+	for py := 0; py < 48; py++ {
+		for px := 0; px < 64; px++ {
+			img.Set(px, py, color.RGBA{R: 20, G: 20, B: 20, A: 255})
+		}
+	}
+	for _, p := range lit {
+		for py := p.Y; py < p.Y+8; py++ {
+			for px := p.X; px < p.X+8; px++ {
+				img.Set(px, py, color.RGBA{R: 255, G: 255, B: 255, A: 255})
+			}
+		}
+	}
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: 90}); err != nil {
+		t.Fatalf("jpeg encode failed: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestBootCapture_Milestones(t *testing.T) {
+	dark := bootFrame(t)
+	power := bootFrame(t, image.Pt(16, 16))
+	ready := bootFrame(t, image.Pt(16, 16), image.Pt(40, 24))
+
+	cam := &frameCamera{frames: [][]byte{dark}} // Warm-up frame
+	for i := 0; i < 5; i++ {
+		cam.frames = append(cam.frames, dark)
+	}
+	for i := 0; i < 5; i++ {
+		cam.frames = append(cam.frames, power)
+	}
+	cam.frames = append(cam.frames, ready) // Repeated until the end
+
+	parser := &textParser{withText: map[string]bool{string(ready): true}}
+	capture := &BootCapture{camera: cam, parser: parser, interval: 2 * time.Millisecond, duration: 200 * time.Millisecond, settle: 40 * time.Millisecond}
+
+	triggered := 0
+	signal, err := capture.Capture(func() error {
+		triggered++
+		if cam.next != 1 {
+			t.Errorf("Expected the trigger after the warm-up frame, got %d frames before it", cam.next)
+		}
+		return nil
+	}, map[string]image.Rectangle{"power": image.Rect(16, 16, 24, 24)})
+	if err != nil {
+		t.Fatalf("Capture failed: %v", err)
+	}
+	if triggered != 1 {
+		t.Errorf("Expected the trigger to be called once, got %d", triggered)
+	}
+	if signal.Incomplete {
+		t.Fatalf("Expected the boot to settle, got %+v", signal)
+	}
+
+	offset := func(name string) int64 {
+		m, ok := signal.Milestone(name)
+		if !ok {
+			t.Fatalf("Expected milestone %q, got %+v", name, signal.Milestones)
+		}
+		return m.OffsetMs
+	}
+	firstLED, led, text, steady := offset(core.MilestoneFirstLED), offset("led.power"), offset(core.MilestoneFirstText), offset(core.MilestoneSteady)
+	if led != firstLED {
+		t.Errorf("Expected led.power (%dms) to be the first light (%dms)", led, firstLED)
+	}
+	if firstLED >= text || text != steady || signal.DurationMs != steady {
+		t.Errorf("Expected first_led < first_text = steady = duration, got %+v", signal)
+	}
+	for i := 1; i < len(signal.Milestones); i++ {
+		if signal.Milestones[i].OffsetMs < signal.Milestones[i-1].OffsetMs {
+			t.Errorf("Expected milestones in order of offset, got %+v", signal.Milestones)
+		}
+	}
+	if signal.Confidence < 0.5 || signal.Confidence > 1 {
+		t.Errorf("Expected a clear change for a lit 8x8 LED, got confidence %.2f", signal.Confidence)
+	}
+	if parser.calls > 12 {
+		t.Errorf("Expected a binary search for the first text, got %d vision calls", parser.calls)
+	}
+}
+
+func TestBootCapture_Errors(t *testing.T) {
+	cam := &frameCamera{frames: [][]byte{bootFrame(t)}}
+	capture := &BootCapture{camera: cam, interval: time.Millisecond, duration: 50 * time.Millisecond, settle: 10 * time.Millisecond}
+
+	if _, err := capture.Capture(func() error { return errors.New("no such port") }, nil); err == nil || !strings.Contains(err.Error(), "no such port") {
+		t.Errorf("Expected the trigger error, got %v", err)
+	}
+	if _, err := capture.Capture(func() error { return nil }, nil); err == nil || !strings.Contains(err.Error(), "did not change") {
+		t.Errorf("Expected an unchanged picture to be an error, got %v", err)
+	}
+}
+
+func TestSteadyFrame(t *testing.T) {
+	// Frames 100ms apart over 10s, one cell
+	series := func(level func(ms int64) float64) ([]int64, [][]float64) {
+		var offsets []int64
+		var grids [][]float64
+		for ms := int64(0); ms <= 10000; ms += 100 {
+			offsets = append(offsets, ms)
+			grids = append(grids, []float64{level(ms)})
+		}
+		return offsets, grids
+	}
+
+	// Dark, booting, then a heartbeat LED blinking every 500ms from 3s
+	offsets, grids := series(func(ms int64) float64 {
+		switch {
+		case ms < 1000:
+			return 20
+		case ms < 3000:
+			return 150
+		case (ms/500)%2 == 0:
+			return 100
+		}
+		return 20
+	})
+	if i := steadyFrame(offsets, grids, 2000); i < 0 || offsets[i] != 3000 {
+		t.Errorf("Expected steady at 3000ms despite the heartbeat, got frame %d", i)
+	}
+
+	// Brightening until the end never settles
+	offsets, grids = series(func(ms int64) float64 { return float64(ms) / 40 })
+	if i := steadyFrame(offsets, grids, 2000); i != -1 {
+		t.Errorf("Expected no steady state while still changing, got frame %d", i)
+	}
+
+	// Settling just before the end has not held long enough
+	offsets, grids = series(func(ms int64) float64 {
+		if ms < 7800 {
+			return 20
+		}
+		return 200
+	})
+	if i := steadyFrame(offsets, grids, 2000); i != -1 {
+		t.Errorf("Expected a state held for only 2.2s not to count, got frame %d", i)
+	}
+
+	// A capture shorter than the settle window cannot tell
+	if i := steadyFrame([]int64{0, 100}, [][]float64{{20}, {200}}, 2000); i != -1 {
+		t.Errorf("Expected -1 for a short capture, got %d", i)
+	}
+}
+
+func TestFirstTextFrame(t *testing.T) {
+	frames := make([][]byte, 100)
+	parser := &textParser{withText: map[string]bool{}}
+	for i := range frames {
+		frames[i] = []byte{byte(i)}
+		if i >= 37 {
+			parser.withText[string(frames[i])] = true
+		}
+	}
+	i, err := firstTextFrame(frames, parser)
+	if err != nil || i != 37 {
+		t.Errorf("Expected frame 37, got %d (%v)", i, err)
+	}
+	if parser.calls > 8 {
+		t.Errorf("Expected at most 8 vision calls for 100 frames, got %d", parser.calls)
+	}
+
+	if i, _ := firstTextFrame(frames[:30], &textParser{}); i != -1 {
+		t.Errorf("Expected -1 when no frame shows text, got %d", i)
+	}
+}
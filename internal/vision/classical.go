@@ -0,0 +1,339 @@
+package vision
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/perceptumx/percepta/internal/core"
+	"github.com/perceptumx/percepta/internal/tolerance"
+)
+
+// Vision providers, selected with vision.provider in the config
+const (
+	ProviderClaude = "claude" // Claude reads every frame (default)
+	ProviderLocal  = "local"  // Classical CV on the device's LED regions; no network
+	ProviderHybrid = "hybrid" // LEDs measured locally; Claude only when the rest of the picture changed
+)
+
+const (
+	// litLevel is the peak brightness (0-255) from which a region shows a lit LED
+	litLevel = 160.0
+
+	// confidentMargin is the distance of the peak from litLevel that earns full confidence
+	confidentMargin = 60.0
+
+	// peakPercentile of a region's pixel brightness is its peak: the LED itself
+	// when it covers at least 2% of the region, ignoring single hot pixels
+	peakPercentile = 0.98
+
+	// clippedLevel is the channel value from which a pixel is saturated and
+	// reads white whatever the LED color
+	clippedLevel = 250
+
+	// sceneChange is the mean brightness change (0-255) of a grid cell outside
+	// the LED regions from which the hybrid provider asks Claude again
+	sceneChange = 8.0
+)
+
+// Driver is a vision backend: it observes single frames, parses the frames of
+// multi-frame captures and answers free-form questions
+type Driver interface {
+	core.VisionDriver
+	GetParser() SignalParser
+	GetAnswerer() QuestionAnswerer // nil when the backend cannot answer questions
+}
+
+// NewDriver returns the driver for a vision provider. The signal map's LED
+// regions are the pixel areas the local measurement reads; the local provider
+// needs at least one, the hybrid provider measures none without them. Claude
+// is told the map's names and positions.
+func NewDriver(provider string, signals SignalMap) (Driver, error) {
+	switch strings.ToLower(provider) {
+	case "", ProviderClaude:
+		claude, err := NewClaudeVision()
+		if err != nil {
+			return nil, err
+		}
+		claude.UseSignalMap(signals)
+		return claude, nil
+	case ProviderLocal:
+		return NewClassicalVision(signals.LEDs)
+	case ProviderHybrid:
+		claude, err := NewClaudeVision()
+		if err != nil {
+			return nil, err
+		}
+		claude.UseSignalMap(signals)
+		return &HybridVision{claude: claude, parser: &prefilterParser{local: NewClassicalParser(signals.LEDs), remote: claude.GetParser()}}, nil
+	}
+	return nil, fmt.Errorf("unknown vision provider %q (expected %s, %s or %s)", provider, ProviderClaude, ProviderLocal, ProviderHybrid)
+}
+
+// ClassicalParser measures LEDs in fixed pixel regions of the frame: a region
+// is lit when its peak brightness is high enough, and its color is the mean
+// of the glow around the peak. Brightness is a pixel's largest channel (HSV
+// value) rather than its luminance, so that blue and red LEDs, which have
+// little luminance, are not missed. It needs no network and gives the same
+// signals for the same frame every time, but reads no display text.
+type ClassicalParser struct {
+	regions map[string]image.Rectangle
+}
+
+func NewClassicalParser(regions map[string]image.Rectangle) *ClassicalParser {
+	return &ClassicalParser{regions: regions}
+}
+
+// Parse decodes a JPEG frame and returns an LEDSignal per region, sorted by name
+func (p *ClassicalParser) Parse(frame []byte) ([]core.Signal, error) {
+	img, err := jpeg.Decode(bytes.NewReader(frame))
+	if err != nil {
+		return nil, fmt.Errorf("frame decode failed: %w", err)
+	}
+	return p.measure(img), nil
+}
+
+func (p *ClassicalParser) measure(img image.Image) []core.Signal {
+	names := make([]string, 0, len(p.regions))
+	for name := range p.regions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	signals := make([]core.Signal, 0, len(names))
+	for _, name := range names {
+		signals = append(signals, measureLED(img, name, p.regions[name]))
+	}
+	return signals
+}
+
+// measureLED reads the LED in rect. A region outside the frame reads as an
+// unlit LED with no confidence.
+func measureLED(img image.Image, name string, rect image.Rectangle) core.LEDSignal {
+	rect = rect.Intersect(img.Bounds())
+	if rect.Empty() {
+		return core.LEDSignal{Name: name}
+	}
+
+	levels, peak, background := regionLevels(img, rect)
+
+	led := core.LEDSignal{
+		Name:       name,
+		On:         peak >= litLevel,
+		Confidence: 0.5 + 0.5*math.Min(math.Abs(peak-litLevel)/confidentMargin, 1),
+	}
+	if !led.On {
+		return led
+	}
+
+	led.Brightness = max(luminancePercent(peak), 1)
+	led.Color = glowColor(img, rect, levels, (peak+background)/2)
+	led.ColorName = tolerance.NameOf(led.Color)
+	return led
+}
+
+// regionLevels returns the brightness of every pixel of rect, which must lie
+// in the frame, in row order, with their peak and median (the background)
+func regionLevels(img image.Image, rect image.Rectangle) (levels []float64, peak, background float64) {
+	levels = make([]float64, 0, rect.Dx()*rect.Dy())
+	for y := rect.Min.Y; y < rect.Max.Y; y++ {
+		for x := rect.Min.X; x < rect.Max.X; x++ {
+			levels = append(levels, pixelLevel(img, x, y))
+		}
+	}
+	sorted := append([]float64(nil), levels...)
+	sort.Float64s(sorted)
+	return levels, sorted[int(float64(len(sorted)-1)*peakPercentile)], sorted[len(sorted)/2]
+}
+
+// glowColor averages the pixels of rect at least as bright as level, leaving
+// out saturated ones, which read white whatever the LED color, unless every
+// bright pixel is saturated
+func glowColor(img image.Image, rect image.Rectangle, levels []float64, level float64) core.RGB {
+	var glow, clipped [3]float64
+	var nGlow, nClipped float64
+	i := 0
+	for y := rect.Min.Y; y < rect.Max.Y; y++ {
+		for x := rect.Min.X; x < rect.Max.X; x++ {
+			bright := levels[i] >= level
+			i++
+			if !bright {
+				continue
+			}
+			c := color.RGBAModel.Convert(img.At(x, y)).(color.RGBA)
+			px := [3]float64{float64(c.R), float64(c.G), float64(c.B)}
+			if c.R >= clippedLevel && c.G >= clippedLevel && c.B >= clippedLevel {
+				for j := range px {
+					clipped[j] += px[j]
+				}
+				nClipped++
+				continue
+			}
+			for j := range px {
+				glow[j] += px[j]
+			}
+			nGlow++
+		}
+	}
+	if nGlow == 0 {
+		glow, nGlow = clipped, nClipped
+	}
+	if nGlow == 0 {
+		return core.RGB{}
+	}
+	return core.RGB{
+		R: uint8(math.Round(glow[0] / nGlow)),
+		G: uint8(math.Round(glow[1] / nGlow)),
+		B: uint8(math.Round(glow[2] / nGlow)),
+	}
+}
+
+// ClassicalVision implements core.VisionDriver with the ClassicalParser alone
+type ClassicalVision struct {
+	parser *ClassicalParser
+}
+
+func NewClassicalVision(regions map[string]image.Rectangle) (*ClassicalVision, error) {
+	if len(regions) == 0 {
+		return nil, fmt.Errorf("the %s vision provider measures configured LED regions, and the device has none", ProviderLocal)
+	}
+	return &ClassicalVision{parser: NewClassicalParser(regions)}, nil
+}
+
+func (v *ClassicalVision) Observe(deviceID string, frame []byte) (*core.Observation, error) {
+	signals, err := v.parser.Parse(frame)
+	if err != nil {
+		return nil, err
+	}
+	return &core.Observation{
+		ID:        core.GenerateID(),
+		DeviceID:  deviceID,
+		Timestamp: time.Now(),
+		Signals:   signals,
+	}, nil
+}
+
+func (v *ClassicalVision) GetParser() SignalParser {
+	return v.parser
+}
+
+// GetAnswerer returns nil: free-form questions need a vision model
+func (v *ClassicalVision) GetAnswerer() QuestionAnswerer {
+	return nil
+}
+
+// HybridVision measures the configured LEDs locally in every frame and asks
+// Claude for everything else (displays, other LEDs) only when the picture
+// outside the LED regions changed since its last answer
+type HybridVision struct {
+	claude *ClaudeVision
+	parser *prefilterParser
+}
+
+func (v *HybridVision) Observe(deviceID string, frame []byte) (*core.Observation, error) {
+	signals, err := v.parser.Parse(frame)
+	if err != nil {
+		return nil, err
+	}
+	return &core.Observation{
+		ID:        core.GenerateID(),
+		DeviceID:  deviceID,
+		Timestamp: time.Now(),
+		Signals:   signals,
+	}, nil
+}
+
+func (v *HybridVision) GetParser() SignalParser {
+	return v.parser
+}
+
+func (v *HybridVision) GetAnswerer() QuestionAnswerer {
+	return v.claude.GetAnswerer()
+}
+
+// prefilterParser runs the local measurement on every frame and the remote
+// parser only when the scene outside the LED regions changed, reusing its
+// last signals otherwise. Measured LEDs replace remote ones of the same name.
+type prefilterParser struct {
+	local  *ClassicalParser
+	remote SignalParser
+
+	haveRemote  bool          // The remote parser has answered at least once
+	lastScene   []float64     // Cell brightness of the frame the remote parser last read
+	lastSignals []core.Signal // What it returned, possibly nothing
+}
+
+func (p *prefilterParser) Parse(frame []byte) ([]core.Signal, error) {
+	img, err := jpeg.Decode(bytes.NewReader(frame))
+	if err != nil {
+		return nil, fmt.Errorf("frame decode failed: %w", err)
+	}
+	measured := p.local.measure(img)
+
+	scene := p.scene(img)
+	if !p.haveRemote || sceneChanged(p.lastScene, scene) {
+		remote, err := p.remote.Parse(frame)
+		if err != nil {
+			if len(measured) > 0 {
+				return measured, nil
+			}
+			return nil, err
+		}
+		p.haveRemote, p.lastScene, p.lastSignals = true, scene, remote
+	}
+
+	signals := append([]core.Signal(nil), measured...)
+	for _, s := range p.lastSignals {
+		if led, ok := s.(core.LEDSignal); ok && p.measures(led.Name) {
+			continue
+		}
+		signals = append(signals, s)
+	}
+	return signals, nil
+}
+
+// measures reports whether the LED is one of the locally measured regions
+func (p *prefilterParser) measures(name string) bool {
+	for region := range p.local.regions {
+		if strings.EqualFold(region, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// scene is the mean brightness of every grid cell, with cells that overlap an
+// LED region set to -1 so that blinking LEDs do not count as a change
+func (p *prefilterParser) scene(img image.Image) []float64 {
+	cells := cellLuminance(img, locateCellSize)
+	for i := range cells {
+		cell := cellRect(img.Bounds(), locateCellSize, i)
+		for _, rect := range p.local.regions {
+			if cell.Overlaps(rect) {
+				cells[i] = -1
+				break
+			}
+		}
+	}
+	return cells
+}
+
+// sceneChanged reports whether any cell's brightness moved by sceneChange or
+// more; frames of a different size always count as changed
+func sceneChanged(before, after []float64) bool {
+	if len(before) != len(after) {
+		return true
+	}
+	for i := range before {
+		if math.Abs(after[i]-before[i]) >= sceneChange {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,194 @@
+package vision
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"testing"
+
+	"github.com/perceptumx/percepta/internal/core"
+)
+
+// spot is an LED drawn by colorFrame: a saturated white core in a colored glow
+type spot struct {
+	x, y int
+	glow color.RGBA
+}
+
+// colorFrame renders a dark 64x48 frame with a 10x10 glow and a 4x4 white
+// core at each spot, and the background shifted by shade
+func colorFrame(t *testing.T, shade uint8, spots ...spot) []byte {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, 64, 48))
+	for py := 0; py < 48; py++ {
+		for px := 0; px < 64; px++ {
+			img.Set(px, py, color.RGBA{R: 20 + shade, G: 20 + shade, B: 20 + shade, A: 255})
+		}
+	}
+	for _, s := range spots {
+		for py := s.y; py < s.y+10; py++ {
+			for px := s.x; px < s.x+10; px++ {
+				c := s.glow
+				if px >= s.x+3 && px < s.x+7 && py >= s.y+3 && py < s.y+7 {
+					c = color.RGBA{R: 255, G: 255, B: 255, A: 255}
+				}
+				img.Set(px, py, c)
+			}
+		}
+	}
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: 95}); err != nil {
+		t.Fatalf("jpeg encode failed: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestClassicalParser(t *testing.T) {
+	green := color.RGBA{G: 220, B: 40, A: 255}
+	frame := colorFrame(t, 0, spot{x: 4, y: 4, glow: green})
+	parser := NewClassicalParser(map[string]image.Rectangle{
+		"power":  image.Rect(2, 2, 16, 16),
+		"status": image.Rect(40, 20, 54, 34),
+	})
+
+	signals, err := parser.Parse(frame)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if len(signals) != 2 {
+		t.Fatalf("Expected one signal per region, got %d", len(signals))
+	}
+
+	power := signals[0].(core.LEDSignal)
+	if power.Name != "power" || !power.On || power.ColorName != "green" || power.Confidence < 0.9 {
+		t.Errorf("Expected a confidently lit green 'power' LED, got %+v", power)
+	}
+	if power.Brightness < 90 {
+		t.Errorf("Expected a saturated LED to read near full brightness, got %d%%", power.Brightness)
+	}
+
+	status := signals[1].(core.LEDSignal)
+	if status.Name != "status" || status.On || status.HasColor() || status.Brightness != 0 || status.Confidence < 0.9 {
+		t.Errorf("Expected a confidently dark 'status' LED, got %+v", status)
+	}
+
+	// The same frame always gives the same signals
+	again, _ := parser.Parse(frame)
+	if fmt.Sprint(again) != fmt.Sprint(signals) {
+		t.Errorf("Expected deterministic signals, got %v then %v", signals, again)
+	}
+}
+
+func TestClassicalParser_Regions(t *testing.T) {
+	frame := colorFrame(t, 0, spot{x: 4, y: 4, glow: color.RGBA{R: 230, G: 30, B: 30, A: 255}})
+
+	// A region off the frame reads unlit with no confidence
+	signals, err := NewClassicalParser(map[string]image.Rectangle{"gone": image.Rect(100, 100, 110, 110)}).Parse(frame)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if led := signals[0].(core.LEDSignal); led.On || led.Confidence != 0 {
+		t.Errorf("Expected an unlit, unconfident LED, got %+v", led)
+	}
+
+	// A region that is all saturated core still reports a color
+	signals, _ = NewClassicalParser(map[string]image.Rectangle{"core": image.Rect(7, 7, 11, 11)}).Parse(frame)
+	if led := signals[0].(core.LEDSignal); !led.On || led.ColorName != "white" {
+		t.Errorf("Expected a white core, got %+v", led)
+	}
+
+	if _, err := NewClassicalParser(nil).Parse([]byte("not a jpeg")); err == nil {
+		t.Error("Expected an error for an undecodable frame")
+	}
+}
+
+func TestNewDriver(t *testing.T) {
+	regions := SignalMap{LEDs: map[string]image.Rectangle{"power": image.Rect(2, 2, 16, 16)}}
+
+	driver, err := NewDriver("LOCAL", regions)
+	if err != nil {
+		t.Fatalf("NewDriver failed: %v", err)
+	}
+	if driver.GetAnswerer() != nil {
+		t.Error("Expected the local provider to have no question answerer")
+	}
+	obs, err := driver.Observe("board", colorFrame(t, 0, spot{x: 4, y: 4, glow: color.RGBA{B: 230, A: 255}}))
+	if err != nil {
+		t.Fatalf("Observe failed: %v", err)
+	}
+	if led := obs.Signals[0].(core.LEDSignal); obs.DeviceID != "board" || !led.On || led.ColorName != "blue" {
+		t.Errorf("Unexpected observation: %+v", obs)
+	}
+
+	if _, err := NewDriver(ProviderLocal, SignalMap{}); err == nil {
+		t.Error("Expected the local provider to need LED regions")
+	}
+	if _, err := NewDriver("openai", regions); err == nil {
+		t.Error("Expected an unknown provider to be rejected")
+	}
+}
+
+// countingParser returns fixed signals and counts its calls
+type countingParser struct {
+	signals []core.Signal
+	err     error
+	calls   int
+}
+
+func (p *countingParser) Parse(frame []byte) ([]core.Signal, error) {
+	p.calls++
+	return p.signals, p.err
+}
+
+func TestPrefilterParser(t *testing.T) {
+	remote := &countingParser{signals: []core.Signal{
+		core.LEDSignal{Name: "Power", On: false, Confidence: 0.6},
+		core.LEDSignal{Name: "LED2", On: true, Confidence: 0.8},
+		core.DisplaySignal{Name: "lcd", Text: "Ready", Confidence: 0.9},
+	}}
+	parser := &prefilterParser{
+		local:  NewClassicalParser(map[string]image.Rectangle{"power": image.Rect(2, 2, 16, 16)}),
+		remote: remote,
+	}
+	green := spot{x: 4, y: 4, glow: color.RGBA{G: 220, A: 255}}
+
+	signals, err := parser.Parse(colorFrame(t, 0, green))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if len(signals) != 3 || remote.calls != 1 {
+		t.Fatalf("Expected the measured LED plus the remote LED2 and display after one call, got %v (%d calls)", signals, remote.calls)
+	}
+	if led := signals[0].(core.LEDSignal); led.Name != "power" || !led.On {
+		t.Errorf("Expected the measured LED to replace the remote one, got %+v", led)
+	}
+
+	// The LED region changing does not count as a scene change...
+	if _, err := parser.Parse(colorFrame(t, 0)); err != nil || remote.calls != 1 {
+		t.Errorf("Expected the remote answer to be reused, got %d calls (%v)", remote.calls, err)
+	}
+	// ...but the rest of the picture changing does
+	if _, err := parser.Parse(colorFrame(t, 40, green)); err != nil || remote.calls != 2 {
+		t.Errorf("Expected a changed scene to be sent to the remote parser, got %d calls (%v)", remote.calls, err)
+	}
+
+	// An empty remote answer is reused like any other
+	empty := &countingParser{}
+	quiet := &prefilterParser{local: parser.local, remote: empty}
+	for i := 0; i < 2; i++ {
+		if _, err := quiet.Parse(colorFrame(t, 0, green)); err != nil {
+			t.Fatalf("Parse failed: %v", err)
+		}
+	}
+	if empty.calls != 1 {
+		t.Errorf("Expected an empty remote answer to be reused, got %d calls", empty.calls)
+	}
+
+	// A remote failure still returns the measured LEDs
+	failing := &prefilterParser{local: parser.local, remote: &countingParser{err: fmt.Errorf("API call failed")}}
+	if signals, err := failing.Parse(colorFrame(t, 0, green)); err != nil || len(signals) != 1 {
+		t.Errorf("Expected the measured LED despite the remote failure, got %v (%v)", signals, err)
+	}
+}
@@ -83,6 +83,12 @@ func (v *ClaudeVision) Observe(deviceID string, frame []byte) (*core.Observation
 	}, nil
 }
 
+// UseSignalMap has the structured parser report signals under the device's
+// calibrated names
+func (v *ClaudeVision) UseSignalMap(signals SignalMap) {
+	v.structuredParser = &StructuredParser{client: v.client, signals: signals}
+}
+
 // GetParser returns a parser that tries structured first, then falls back to regex
 func (v *ClaudeVision) GetParser() SignalParser {
 	return &fallbackParser{
@@ -91,6 +97,12 @@ func (v *ClaudeVision) GetParser() SignalParser {
 	}
 }
 
+// GetAnswerer returns the answerer for free-form questions about a frame.
+// There is no regex fallback: a question needs the tool's structured answer.
+func (v *ClaudeVision) GetAnswerer() QuestionAnswerer {
+	return NewStructuredParser(v.client)
+}
+
 // fallbackParser tries primary parser first, then falls back to secondary
 type fallbackParser struct {
 	primary  SignalParser
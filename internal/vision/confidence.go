@@ -29,7 +29,7 @@ func (c *ConfidenceCalibrator) CalibrateLED(led core.LEDSignal, detectionRate fl
 
 	// Color detection boost
 	colorBoost := 0.0
-	if led.Color != (core.RGB{}) {
+	if led.HasColor() {
 		colorBoost = 0.05 // Color detected → +0.05
 	}
 
@@ -0,0 +1,284 @@
+package vision
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/perceptumx/percepta/internal/core"
+	"github.com/perceptumx/percepta/internal/tolerance"
+)
+
+const (
+	// toggleRange is the brightness swing (0-255) across a burst from which a
+	// pixel counts as toggling, like a blinking LED
+	toggleRange = 60.0
+
+	// toggleFloor is the brightness a toggling pixel must reach when on, so
+	// that dark noise does not count
+	toggleFloor = 100.0
+)
+
+// DiscoveredLED is an LED found by comparing the frames of a burst: a small
+// bright patch, or one whose brightness toggles, at a stable position
+type DiscoveredLED struct {
+	Name      string          // Proposed name, LED1, LED2... in reading order
+	Region    image.Rectangle // Pixel area to measure it in
+	Color     core.RGB        // Mean glow color over the frames it is lit in
+	ColorName string
+	LitFrames int    // Frames it reads lit in
+	Frames    int    // Frames compared
+	Reported  string // Name of the vision model's LED it matched, set by CheckLEDCount
+}
+
+// Toggling reports whether the LED was lit in some frames but not all
+func (d DiscoveredLED) Toggling() bool {
+	return d.LitFrames > 0 && d.LitFrames < d.Frames
+}
+
+// candidate is a bright or toggling patch of one frame, or a cluster of them
+type candidate struct {
+	region    image.Rectangle
+	color     [3]float64 // Sum of member colors
+	colored   int        // Members with a color
+	colorName string     // Color of the first colored member
+}
+
+// DiscoverLEDs finds the LEDs in a burst of JPEG frames of the same size. It
+// collects the small saturated blobs of every frame and the patches whose
+// brightness toggles between frames, which finds blinking LEDs too dim to
+// saturate, and clusters them by position and color. A bi-color LED gives one
+// LED per color. LEDs unlit in every frame are not found.
+func DiscoverLEDs(frames [][]byte) ([]DiscoveredLED, error) {
+	var imgs []image.Image
+	for i, frame := range frames {
+		img, err := jpeg.Decode(bytes.NewReader(frame))
+		if err != nil {
+			return nil, fmt.Errorf("frame %d decode failed: %w", i, err)
+		}
+		if len(imgs) > 0 && img.Bounds() != imgs[0].Bounds() {
+			return nil, fmt.Errorf("frame %d is %v, expected %v", i, img.Bounds().Size(), imgs[0].Bounds().Size())
+		}
+		imgs = append(imgs, img)
+	}
+	if len(imgs) == 0 {
+		return nil, nil
+	}
+
+	var candidates []candidate
+	for _, img := range imgs {
+		for _, b := range findBlobs(img) {
+			candidates = append(candidates, newCandidate(b.Region, b.Color, b.ColorName))
+		}
+	}
+	candidates = append(candidates, toggling(imgs)...)
+
+	clusters := cluster(candidates)
+	leds := make([]DiscoveredLED, 0, len(clusters))
+	for _, c := range clusters {
+		led := DiscoveredLED{Region: c.region, ColorName: c.colorName, Frames: len(imgs)}
+		if c.colored > 0 {
+			led.Color = core.RGB{
+				R: uint8(math.Round(c.color[0] / float64(c.colored))),
+				G: uint8(math.Round(c.color[1] / float64(c.colored))),
+				B: uint8(math.Round(c.color[2] / float64(c.colored))),
+			}
+			led.ColorName = tolerance.NameOf(led.Color)
+		}
+		led.LitFrames = litFrames(imgs, c.region)
+		leds = append(leds, led)
+	}
+
+	sort.SliceStable(leds, func(i, j int) bool { return readingOrder(leds[i].Region, leds[j].Region) })
+	if len(leds) > maxBlobs {
+		leds = leds[:maxBlobs]
+	}
+	for i := range leds {
+		leds[i].Name = fmt.Sprintf("LED%d", i+1)
+	}
+	return leds, nil
+}
+
+func newCandidate(region image.Rectangle, rgb core.RGB, colorName string) candidate {
+	c := candidate{region: region, colorName: colorName}
+	if colorName != "" {
+		c.color = [3]float64{float64(rgb.R), float64(rgb.G), float64(rgb.B)}
+		c.colored = 1
+	}
+	return c
+}
+
+// toggling finds the patches whose brightness swings by toggleRange or more
+// across the frames. Their color is read in the frame they are brightest in,
+// and is unknown when they never saturate enough to read lit.
+func toggling(imgs []image.Image) []candidate {
+	b := imgs[0].Bounds()
+	w, h := b.Dx(), b.Dy()
+	low := make([]float64, w*h)
+	high := make([]float64, w*h)
+	for i := range low {
+		low[i] = math.Inf(1)
+	}
+	for _, img := range imgs {
+		for y := 0; y < h; y++ {
+			for x := 0; x < w; x++ {
+				v := pixelLevel(img, b.Min.X+x, b.Min.Y+y)
+				low[y*w+x] = math.Min(low[y*w+x], v)
+				high[y*w+x] = math.Max(high[y*w+x], v)
+			}
+		}
+	}
+	mask := make([]bool, w*h)
+	for i := range mask {
+		mask[i] = high[i]-low[i] >= toggleRange && high[i] >= toggleFloor
+	}
+
+	var found []candidate
+	for _, comp := range components(mask, w, h) {
+		region := blobRegion(comp.bounds.Add(b.Min), b)
+		brightest, best := imgs[0], -1.0
+		for _, img := range imgs {
+			if _, peak, _ := regionLevels(img, region); peak > best {
+				brightest, best = img, peak
+			}
+		}
+		led := measureLED(brightest, "", region)
+		found = append(found, newCandidate(region, led.Color, led.ColorName))
+	}
+	return found
+}
+
+// cluster merges candidates that overlap and have the same color (or no known
+// color), growing each cluster's region to cover its members
+func cluster(candidates []candidate) []candidate {
+	var clusters []candidate
+	for _, c := range candidates {
+		merged := false
+		for i := range clusters {
+			k := &clusters[i]
+			if !k.region.Overlaps(c.region) || !sameColor(k.colorName, c.colorName) {
+				continue
+			}
+			k.region = k.region.Union(c.region)
+			for j := range k.color {
+				k.color[j] += c.color[j]
+			}
+			k.colored += c.colored
+			if k.colorName == "" {
+				k.colorName = c.colorName
+			}
+			merged = true
+			break
+		}
+		if !merged {
+			clusters = append(clusters, c)
+		}
+	}
+	return clusters
+}
+
+func sameColor(a, b string) bool {
+	return a == "" || b == "" || strings.EqualFold(a, b)
+}
+
+// litFrames counts the frames the region reads lit in. A region whose peak
+// swings by toggleRange or more is lit in the frames above the middle of its
+// swing, whatever its absolute brightness; a steady one is lit throughout
+// when its peak reaches litLevel.
+func litFrames(imgs []image.Image, region image.Rectangle) int {
+	peaks := make([]float64, len(imgs))
+	low, high := math.Inf(1), math.Inf(-1)
+	for i, img := range imgs {
+		_, peaks[i], _ = regionLevels(img, region)
+		low, high = math.Min(low, peaks[i]), math.Max(high, peaks[i])
+	}
+	threshold := litLevel
+	if high-low >= toggleRange {
+		threshold = (low + high) / 2
+	}
+	lit := 0
+	for _, p := range peaks {
+		if p >= threshold {
+			lit++
+		}
+	}
+	return lit
+}
+
+// LEDCountCheck compares the LEDs found locally with those the vision model
+// reported, to catch LEDs it missed and LEDs it may have hallucinated
+type LEDCountCheck struct {
+	LEDs        []DiscoveredLED  // Every discovered LED, with Reported set where matched
+	Missed      []DiscoveredLED  // Found locally, but matching no reported LED
+	Unconfirmed []core.LEDSignal // Reported lit or blinking, but not found locally
+}
+
+// OK reports whether every discovered LED and every reported lit LED matched
+func (c LEDCountCheck) OK() bool {
+	return len(c.Missed) == 0 && len(c.Unconfirmed) == 0
+}
+
+// CheckLEDCount matches the model's lit or blinking LEDs with the discovered
+// ones, first by color name and then in any order. The model gives no
+// positions, so only the counts are checked, per color where it can be.
+// Unlit reported LEDs are not checked: discovery cannot see them.
+func CheckLEDCount(discovered []DiscoveredLED, reported []core.LEDSignal) LEDCountCheck {
+	check := LEDCountCheck{LEDs: append([]DiscoveredLED(nil), discovered...)}
+	var lit []core.LEDSignal
+	for _, led := range reported {
+		if led.On || led.BlinkHz > 0 {
+			lit = append(lit, led)
+		}
+	}
+
+	matched := make([]bool, len(lit))
+	match := func(sameColorOnly bool) {
+		for i := range check.LEDs {
+			if check.LEDs[i].Reported != "" {
+				continue
+			}
+			for j, led := range lit {
+				if matched[j] || (sameColorOnly && !strings.EqualFold(led.ColorName, check.LEDs[i].ColorName)) {
+					continue
+				}
+				matched[j] = true
+				check.LEDs[i].Reported = led.Name
+				break
+			}
+		}
+	}
+	match(true)
+	match(false)
+
+	for _, led := range check.LEDs {
+		if led.Reported == "" {
+			check.Missed = append(check.Missed, led)
+		}
+	}
+	for j, led := range lit {
+		if !matched[j] {
+			check.Unconfirmed = append(check.Unconfirmed, led)
+		}
+	}
+	return check
+}
+
+func (d DiscoveredLED) colorString() string {
+	if d.ColorName == "" {
+		return "unknown color"
+	}
+	return d.ColorName
+}
+
+// String describes the LED, e.g. "LED1 at 112,84 (18x18), green, blinking"
+func (d DiscoveredLED) String() string {
+	state := "steady"
+	if d.Toggling() {
+		state = fmt.Sprintf("blinking (lit in %d of %d frames)", d.LitFrames, d.Frames)
+	}
+	return fmt.Sprintf("%s at %d,%d (%dx%d), %s, %s", d.Name, d.Region.Min.X, d.Region.Min.Y, d.Region.Dx(), d.Region.Dy(), d.colorString(), state)
+}
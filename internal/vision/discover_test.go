@@ -0,0 +1,106 @@
+package vision
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/perceptumx/percepta/internal/core"
+)
+
+// discoveryBurst renders four frames of a steady green LED, a blinking blue
+// one on the same row, and a dim red one below that toggles too weakly to
+// saturate
+func discoveryBurst(t *testing.T) [][]byte {
+	t.Helper()
+	var frames [][]byte
+	for i := 0; i < 4; i++ {
+		lights := map[image.Rectangle]color.RGBA{
+			image.Rect(40, 40, 50, 50): {G: 230, B: 40, A: 255},
+		}
+		if i%2 == 0 {
+			lights[image.Rect(200, 42, 210, 52)] = color.RGBA{R: 20, G: 40, B: 240, A: 255}
+			lights[image.Rect(100, 150, 108, 158)] = color.RGBA{R: 130, G: 30, B: 30, A: 255}
+		}
+		frames = append(frames, blobFrame(t, lights))
+	}
+	return frames
+}
+
+func TestDiscoverLEDs(t *testing.T) {
+	leds, err := DiscoverLEDs(discoveryBurst(t))
+	if err != nil {
+		t.Fatalf("DiscoverLEDs failed: %v", err)
+	}
+	if len(leds) != 3 {
+		t.Fatalf("Expected 3 LEDs, got %v", leds)
+	}
+
+	green, blue, dim := leds[0], leds[1], leds[2]
+	if green.Name != "LED1" || green.ColorName != "green" || green.Toggling() || green.LitFrames != 4 {
+		t.Errorf("Expected a steady green LED1, got %v", green)
+	}
+	if !image.Rect(40, 40, 50, 50).In(green.Region) {
+		t.Errorf("Expected LED1's region to cover it, got %v", green.Region)
+	}
+	if blue.Name != "LED2" || blue.ColorName != "blue" || !blue.Toggling() || blue.LitFrames != 2 || blue.Frames != 4 {
+		t.Errorf("Expected a blinking blue LED2, got %v", blue)
+	}
+	if dim.Name != "LED3" || dim.ColorName != "" || dim.LitFrames != 2 {
+		t.Errorf("Expected a dim toggling LED3 of unknown color, got %v", dim)
+	}
+	if got := dim.String(); got != "LED3 at 96,146 (16x16), unknown color, blinking (lit in 2 of 4 frames)" {
+		t.Errorf("Unexpected description: %s", got)
+	}
+}
+
+func TestDiscoverLEDs_Errors(t *testing.T) {
+	if leds, err := DiscoverLEDs(nil); err != nil || leds != nil {
+		t.Errorf("Expected nothing from no frames, got %v (%v)", leds, err)
+	}
+	if _, err := DiscoverLEDs([][]byte{[]byte("not a jpeg")}); err == nil {
+		t.Error("Expected an error for an undecodable frame")
+	}
+	if _, err := DiscoverLEDs([][]byte{blobFrame(t, nil), colorFrame(t, 0)}); err == nil {
+		t.Error("Expected an error for frames of different sizes")
+	}
+}
+
+func TestCheckLEDCount(t *testing.T) {
+	discovered, err := DiscoverLEDs(discoveryBurst(t))
+	if err != nil {
+		t.Fatalf("DiscoverLEDs failed: %v", err)
+	}
+
+	// The model saw the blinking blue LED and the green one, and an unlit one
+	// discovery cannot check, but missed the dim LED
+	check := CheckLEDCount(discovered, []core.LEDSignal{
+		{Name: "status", BlinkHz: 1, ColorName: "blue"},
+		{Name: "power", On: true, ColorName: "green"},
+		{Name: "wifi"},
+	})
+	if check.OK() || len(check.Missed) != 1 || check.Missed[0].Name != "LED3" || len(check.Unconfirmed) != 0 {
+		t.Errorf("Expected LED3 to be missed, got %+v", check)
+	}
+	if check.LEDs[0].Reported != "power" || check.LEDs[1].Reported != "status" {
+		t.Errorf("Expected matches by color, got %v and %v", check.LEDs[0].Reported, check.LEDs[1].Reported)
+	}
+	if discovered[0].Reported != "" {
+		t.Error("Expected the discovered LEDs to be left unchanged")
+	}
+
+	// Four lit LEDs where three are found: one is unconfirmed
+	check = CheckLEDCount(discovered, []core.LEDSignal{
+		{Name: "power", On: true, ColorName: "green"},
+		{Name: "LED2", On: true, ColorName: "red"},
+		{Name: "LED3", On: true, ColorName: "red"},
+		{Name: "LED4", On: true, ColorName: "red"},
+	})
+	if len(check.Missed) != 0 || len(check.Unconfirmed) != 1 || check.Unconfirmed[0].Name != "LED4" {
+		t.Errorf("Expected LED4 to be unconfirmed, got %+v", check)
+	}
+
+	if !CheckLEDCount(nil, []core.LEDSignal{{Name: "off"}}).OK() {
+		t.Error("Expected unlit LEDs alone to pass")
+	}
+}
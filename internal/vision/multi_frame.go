@@ -1,10 +1,13 @@
 package vision
 
 import (
+	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/perceptumx/percepta/internal/core"
+	"github.com/perceptumx/percepta/internal/timeline"
 )
 
 // MultiFrameCapture captures multiple frames and aggregates LED detections
@@ -36,6 +39,7 @@ func NewMultiFrameCaptureWithOptions(camera core.CameraDriver, parser SignalPars
 type FrameResult struct {
 	Signals    []core.Signal
 	CapturedAt time.Time
+	Frame      []byte // JPEG as captured, for local analysis such as DiscoverLEDs
 }
 
 func (m *MultiFrameCapture) Capture() ([]FrameResult, error) {
@@ -48,6 +52,8 @@ func (m *MultiFrameCapture) Capture() ([]FrameResult, error) {
 		if err != nil {
 			return nil, fmt.Errorf("frame %d capture failed: %w", i, err)
 		}
+		// Timestamp the frame itself: parsing can take seconds and varies
+		capturedAt := time.Now()
 
 		// Parse signals
 		signals, err := m.parser.Parse(frame)
@@ -58,7 +64,8 @@ func (m *MultiFrameCapture) Capture() ([]FrameResult, error) {
 
 		results = append(results, FrameResult{
 			Signals:    signals,
-			CapturedAt: time.Now(),
+			CapturedAt: capturedAt,
+			Frame:      frame,
 		})
 
 		// Wait before next frame (except last)
@@ -80,18 +87,21 @@ func AggregateLEDs(frames []FrameResult) []core.LEDSignal {
 	// Map LED name → aggregated state
 	ledMap := make(map[string]*ledAggregator)
 	calibrator := NewConfidenceCalibrator()
+	var baseTime time.Time
+	if len(frames) > 0 {
+		baseTime = frames[0].CapturedAt
+	}
 
 	for _, frame := range frames {
+		offsetMs := frame.CapturedAt.Sub(baseTime).Milliseconds()
 		for _, signal := range frame.Signals {
 			if led, ok := signal.(core.LEDSignal); ok {
-				if agg, exists := ledMap[led.Name]; exists {
-					agg.addObservation(led)
-				} else {
-					ledMap[led.Name] = &ledAggregator{
-						name:         led.Name,
-						observations: []core.LEDSignal{led},
-					}
+				agg, exists := ledMap[led.Name]
+				if !exists {
+					agg = &ledAggregator{name: led.Name}
+					ledMap[led.Name] = agg
 				}
+				agg.addFrame(led, offsetMs)
 			}
 		}
 	}
@@ -120,6 +130,7 @@ func AggregateDisplays(frames []FrameResult) []core.DisplaySignal {
 		text       string
 		confidence float64
 		offsetMs   int64
+		values     map[string]core.DisplayValue
 	}
 
 	displayMap := make(map[string][]displayObs)
@@ -136,6 +147,7 @@ func AggregateDisplays(frames []FrameResult) []core.DisplaySignal {
 					text:       d.Text,
 					confidence: d.Confidence,
 					offsetMs:   offsetMs,
+					values:     d.Values,
 				})
 			}
 		}
@@ -180,6 +192,7 @@ func AggregateDisplays(frames []FrameResult) []core.DisplaySignal {
 			Text:       latest.text,
 			Confidence: avgConf,
 			Changed:    changed,
+			Values:     latest.values,
 		}
 		if changed {
 			display.History = transitions
@@ -194,12 +207,19 @@ func AggregateDisplays(frames []FrameResult) []core.DisplaySignal {
 type ledAggregator struct {
 	name         string
 	observations []core.LEDSignal
+	offsets      []int64 // Frame offset of each observation, when known
 }
 
 func (a *ledAggregator) addObservation(led core.LEDSignal) {
 	a.observations = append(a.observations, led)
 }
 
+// addFrame records an observation together with its frame's offset
+func (a *ledAggregator) addFrame(led core.LEDSignal, offsetMs int64) {
+	a.addObservation(led)
+	a.offsets = append(a.offsets, offsetMs)
+}
+
 func (a *ledAggregator) aggregate() core.LEDSignal {
 	if len(a.observations) == 0 {
 		return core.LEDSignal{}
@@ -218,6 +238,14 @@ func (a *ledAggregator) aggregate() core.LEDSignal {
 	led := a.observations[0] // Start with first observation
 	led.Name = a.name
 
+	// An LED that is off in the first frame has no color yet: take the first one seen
+	for _, obs := range a.observations {
+		if obs.HasColor() {
+			led.Color, led.ColorName = obs.Color, obs.ColorName
+			break
+		}
+	}
+
 	if onCount > 0 && offCount > 0 {
 		// Blinking detected (transitions between on/off)
 		// Estimate frequency: transitions per second
@@ -240,6 +268,14 @@ func (a *ledAggregator) aggregate() core.LEDSignal {
 		led.BlinkHz = 0
 	}
 
+	// Brightness is the mean of the lit frames that reported one; the duty
+	// cycle of a blinking LED is its share of lit frames
+	led.Brightness = meanBrightness(a.observations)
+	led.DutyCycle = 0
+	if led.BlinkHz > 0 {
+		led.DutyCycle = float64(onCount) * 100 / float64(len(a.observations))
+	}
+
 	// Aggregate confidence (average)
 	totalConf := 0.0
 	for _, obs := range a.observations {
@@ -247,5 +283,59 @@ func (a *ledAggregator) aggregate() core.LEDSignal {
 	}
 	led.Confidence = totalConf / float64(len(a.observations))
 
+	// Keep the per-frame states so LEDs can be compared frame by frame
+	led.Frames = nil
+	if len(a.offsets) == len(a.observations) {
+		led.Frames = make([]core.LEDSample, len(a.observations))
+		for i, obs := range a.observations {
+			led.Frames[i] = core.LEDSample{OffsetMs: a.offsets[i], On: obs.On, Brightness: obs.Brightness}
+		}
+		if led.BlinkHz > 0 {
+			led.DutyCycle, _ = timeline.DutyCycle(led.Frames)
+			measureBlink(&led)
+		}
+	}
+
 	return led
 }
+
+// measureBlink replaces the transition count of a blinking LED with a rate
+// and duty cycle measured from its frame timestamps. When the frames span
+// less than one full period, the rate is the transitions per second over the
+// span instead. Frames without time between them leave the LED unchanged.
+func measureBlink(led *core.LEDSignal) {
+	blink, err := timeline.EstimateBlink(led.Frames)
+	switch {
+	case err == nil && blink.Hz > 0:
+		led.BlinkHz, led.DutyCycle = blink.Hz, blink.DutyCycle
+		led.BlinkWarning = strings.Join(blink.Warnings, "; ")
+
+	case errors.Is(err, timeline.ErrTooFewPeriods):
+		first, last := led.Frames[0].OffsetMs, led.Frames[len(led.Frames)-1].OffsetMs
+		if last <= first {
+			return
+		}
+		transitions := timeline.Transitions(led.Frames)
+		led.BlinkHz = float64(transitions) / 2 / (float64(last-first) / 1000)
+		warnings := append(blink.Warnings, fmt.Sprintf("less than one full period captured: rate estimated from %d transitions in %d ms; capture more frames", transitions, last-first))
+		if blink.Hz = led.BlinkHz; blink.Aliased() {
+			warnings = append(warnings, fmt.Sprintf("%.2f Hz is close to the %.2f Hz Nyquist limit of %.1f fps: the LED may blink faster", blink.Hz, blink.NyquistHz(), blink.FrameHz))
+		}
+		led.BlinkWarning = strings.Join(warnings, "; ")
+	}
+}
+
+// meanBrightness averages the brightness of lit frames that reported one
+func meanBrightness(observations []core.LEDSignal) uint8 {
+	sum, n := 0, 0
+	for _, obs := range observations {
+		if obs.On && obs.Brightness > 0 {
+			sum += int(obs.Brightness)
+			n++
+		}
+	}
+	if n == 0 {
+		return 0
+	}
+	return uint8((sum + n/2) / n)
+}
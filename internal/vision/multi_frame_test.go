@@ -1,6 +1,9 @@
 package vision
 
 import (
+	"math"
+	"reflect"
+	"strings"
 	"testing"
 	"time"
 
@@ -355,3 +358,100 @@ func TestAggregateLEDs(t *testing.T) {
 		t.Errorf("LED2 should have BlinkHz 1.0, got %f", led2.BlinkHz)
 	}
 }
+
+func TestAggregateLEDs_Frames(t *testing.T) {
+	base := time.Now()
+	frames := []FrameResult{
+		{Signals: []core.Signal{core.LEDSignal{Name: "tx", On: true}, core.LEDSignal{Name: "rx", On: false}}, CapturedAt: base},
+		{Signals: []core.Signal{core.LEDSignal{Name: "tx", On: false}}, CapturedAt: base.Add(200 * time.Millisecond)},
+		{Signals: []core.Signal{core.LEDSignal{Name: "tx", On: true}, core.LEDSignal{Name: "rx", On: true}}, CapturedAt: base.Add(400 * time.Millisecond)},
+	}
+
+	want := map[string][]core.LEDSample{
+		"tx": {{OffsetMs: 0, On: true}, {OffsetMs: 200, On: false}, {OffsetMs: 400, On: true}},
+		"rx": {{OffsetMs: 0, On: false}, {OffsetMs: 400, On: true}}, // Missed in the middle frame
+	}
+	for _, led := range AggregateLEDs(frames) {
+		if !reflect.DeepEqual(led.Frames, want[led.Name]) {
+			t.Errorf("LED %s: expected frames %+v, got %+v", led.Name, want[led.Name], led.Frames)
+		}
+	}
+}
+
+func TestLEDAggregator_BrightnessAndDutyCycle(t *testing.T) {
+	agg := &ledAggregator{name: "backlight"}
+	for _, b := range []uint8{40, 60, 0, 50} {
+		agg.addObservation(core.LEDSignal{Name: "backlight", On: true, Brightness: b, Confidence: 0.9})
+	}
+	if led := agg.aggregate(); led.Brightness != 50 || led.DutyCycle != 0 {
+		t.Errorf("expected mean brightness of reporting frames and no duty cycle, got %d%% duty %.0f%%", led.Brightness, led.DutyCycle)
+	}
+
+	// A 1-in-4 pulse, with and without frame timing
+	agg = &ledAggregator{name: "status"}
+	for i, on := range []bool{true, false, false, false, true, false, false, false} {
+		agg.addFrame(core.LEDSignal{Name: "status", On: on, Brightness: map[bool]uint8{true: 80}[on], Confidence: 0.9}, int64(i)*100)
+	}
+	led := agg.aggregate()
+	if led.Brightness != 80 || led.DutyCycle < 20 || led.DutyCycle > 30 {
+		t.Errorf("expected 80%% brightness at ~25%% duty, got %d%% duty %.1f%%", led.Brightness, led.DutyCycle)
+	}
+	if led.Frames[0].Brightness != 80 || led.Frames[1].Brightness != 0 {
+		t.Errorf("expected per-frame brightness, got %+v", led.Frames)
+	}
+}
+
+func TestLEDAggregator_MeasuredBlinkRate(t *testing.T) {
+	// 2 Hz at 40% duty over 20 frames 100 ms apart: lit two frames of five
+	agg := &ledAggregator{name: "status"}
+	for i := 0; i < 20; i++ {
+		agg.addFrame(core.LEDSignal{Name: "status", On: i%5 < 2, BlinkHz: 1, Confidence: 0.9}, int64(i)*100)
+	}
+	led := agg.aggregate()
+	if math.Abs(led.BlinkHz-2) > 0.01 || math.Abs(led.DutyCycle-40) > 0.5 || led.BlinkWarning != "" {
+		t.Errorf("expected a measured 2 Hz at 40%% duty, got %.2f Hz at %.1f%% (%s)", led.BlinkHz, led.DutyCycle, led.BlinkWarning)
+	}
+
+	// Frames every 100 ms alternating: 5 Hz is the Nyquist limit of 10 fps
+	agg = &ledAggregator{name: "status"}
+	for i := 0; i < 10; i++ {
+		agg.addFrame(core.LEDSignal{Name: "status", On: i%2 == 0, Confidence: 0.9}, int64(i)*100)
+	}
+	if led := agg.aggregate(); led.BlinkHz != 5 || !strings.Contains(led.BlinkWarning, "Nyquist") {
+		t.Errorf("expected 5 Hz with an aliasing warning, got %.2f Hz (%s)", led.BlinkHz, led.BlinkWarning)
+	}
+
+	// One pulse: less than a period, rate from the transitions over the span
+	agg = &ledAggregator{name: "status"}
+	for i, on := range []bool{false, true, true, false, false} {
+		agg.addFrame(core.LEDSignal{Name: "status", On: on, Confidence: 0.9}, int64(i)*200)
+	}
+	if led := agg.aggregate(); led.BlinkHz != 1.25 || !strings.Contains(led.BlinkWarning, "less than one full period") {
+		t.Errorf("expected 1.25 Hz with a short-capture warning, got %.2f Hz (%s)", led.BlinkHz, led.BlinkWarning)
+	}
+}
+
+func TestLEDAggregator_ColorFromLaterFrame(t *testing.T) {
+	agg := &ledAggregator{name: "status"}
+	agg.addObservation(core.LEDSignal{Name: "status", On: false, Confidence: 0.9})
+	agg.addObservation(core.LEDSignal{Name: "status", On: true, ColorName: "amber", Color: core.RGB{R: 255, G: 191}, Confidence: 0.9})
+
+	led := agg.aggregate()
+	if led.ColorName != "amber" || led.Color != (core.RGB{R: 255, G: 191}) {
+		t.Errorf("expected color of the first lit frame, got %q %+v", led.ColorName, led.Color)
+	}
+}
+
+func TestAggregateDisplays_LatestValues(t *testing.T) {
+	now := time.Now()
+	first, latest := 21.0, 22.5
+	frames := []FrameResult{
+		{Signals: []core.Signal{core.DisplaySignal{Name: "LCD", Text: "T 21C", Values: map[string]core.DisplayValue{"t": {Text: "21", Number: &first}}}}, CapturedAt: now},
+		{Signals: []core.Signal{core.DisplaySignal{Name: "LCD", Text: "T 22.5C", Values: map[string]core.DisplayValue{"t": {Text: "22.5", Number: &latest}}}}, CapturedAt: now.Add(200 * time.Millisecond)},
+	}
+
+	displays := AggregateDisplays(frames)
+	if len(displays) != 1 || *displays[0].Values["t"].Number != 22.5 {
+		t.Errorf("expected values of the latest frame, got %+v", displays)
+	}
+}
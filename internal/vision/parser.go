@@ -12,6 +12,7 @@ import (
 	"github.com/anthropics/anthropic-sdk-go"
 	"github.com/anthropics/anthropic-sdk-go/option"
 	"github.com/perceptumx/percepta/internal/core"
+	"github.com/perceptumx/percepta/internal/tolerance"
 )
 
 // RegexParser uses regex to extract signals from unstructured text
@@ -95,23 +96,11 @@ func (p *RegexParser) parseText(text string) []core.Signal {
 		// Extract color from matched LED segment only (not entire response)
 		// This prevents false positives on multi-LED boards
 		segment := strings.ToLower(match[0])
-		colors := []string{"red", "green", "blue", "yellow", "white", "orange"}
-		for _, color := range colors {
-			if strings.Contains(segment, color) {
-				switch color {
-				case "red":
-					led.Color = core.RGB{R: 255, G: 0, B: 0}
-				case "green":
-					led.Color = core.RGB{R: 0, G: 255, B: 0}
-				case "blue":
-					led.Color = core.RGB{R: 0, G: 0, B: 255}
-				case "yellow":
-					led.Color = core.RGB{R: 255, G: 255, B: 0}
-				case "white":
-					led.Color = core.RGB{R: 255, G: 255, B: 255}
-				case "orange":
-					led.Color = core.RGB{R: 255, G: 165, B: 0}
-				}
+		for _, name := range tolerance.ColorNames() {
+			if strings.Contains(segment, name) {
+				named, _ := tolerance.LookupColor(name)
+				led.ColorName = name
+				led.Color = named.RGB
 				break
 			}
 		}
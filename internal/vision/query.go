@@ -0,0 +1,103 @@
+package vision
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/anthropics/anthropic-sdk-go"
+	"github.com/perceptumx/percepta/internal/core"
+)
+
+const answerQuestionToolName = "answer_visual_question"
+
+// QuestionAnswerer answers free-form questions about a frame, for checks that
+// do not fit the LED, display and boot signals
+type QuestionAnswerer interface {
+	Ask(frame []byte, question string) (core.QuerySignal, error)
+}
+
+func answerQuestionTool() anthropic.ToolParam {
+	return anthropic.ToolParam{
+		Name:        answerQuestionToolName,
+		Description: anthropic.String("Answer a question about what the image of the hardware shows"),
+		InputSchema: anthropic.ToolInputSchemaParam{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"answer":     map[string]string{"type": "string", "description": "\"yes\" or \"no\" for yes/no questions, otherwise a short answer (a few words, text exactly as shown); \"unknown\" if the image does not show enough to tell"},
+				"reasoning":  map[string]string{"type": "string", "description": "One sentence on what in the image supports the answer"},
+				"confidence": map[string]string{"type": "number", "description": "Confidence 0-1 in the answer"},
+			},
+			Required: []string{"answer", "confidence"},
+		},
+	}
+}
+
+// Ask sends the frame and the question to the vision model, which must answer
+// through the answer_visual_question tool
+func (p *StructuredParser) Ask(frame []byte, question string) (core.QuerySignal, error) {
+	tool := answerQuestionTool()
+
+	message, err := p.client.Messages.New(context.Background(), anthropic.MessageNewParams{
+		MaxTokens:  512,
+		Model:      anthropic.ModelClaudeSonnet4_5_20250929,
+		Tools:      []anthropic.ToolUnionParam{{OfTool: &tool}},
+		ToolChoice: anthropic.ToolChoiceParamOfTool(answerQuestionToolName),
+		Messages: []anthropic.MessageParam{
+			anthropic.NewUserMessage(
+				anthropic.NewImageBlockBase64(
+					string(anthropic.Base64ImageSourceMediaTypeImageJPEG),
+					base64.StdEncoding.EncodeToString(frame),
+				),
+				anthropic.NewTextBlock(fmt.Sprintf(`This is a photo of an embedded hardware device under test. Answer the question below about what the photo shows, using the %s tool.
+Only answer from what is visible; answer "unknown" rather than guessing.
+
+Question: %s`, answerQuestionToolName, question)),
+			),
+		},
+	})
+	if err != nil {
+		return core.QuerySignal{}, fmt.Errorf("API call failed: %w", err)
+	}
+
+	for _, block := range message.Content {
+		if block.Type != "tool_use" || block.Name != answerQuestionToolName {
+			continue
+		}
+		var input map[string]interface{}
+		if err := json.Unmarshal(block.Input, &input); err != nil {
+			return core.QuerySignal{}, fmt.Errorf("invalid %s input: %w", answerQuestionToolName, err)
+		}
+		if signal, ok := parseQueryToolResponse(question, input); ok {
+			return signal, nil
+		}
+	}
+	return core.QuerySignal{}, fmt.Errorf("vision model did not answer %q", question)
+}
+
+// parseQueryToolResponse converts the tool input; ok is false without an answer
+func parseQueryToolResponse(question string, input interface{}) (core.QuerySignal, bool) {
+	inputMap, ok := input.(map[string]interface{})
+	if !ok {
+		return core.QuerySignal{}, false
+	}
+
+	answer := strings.TrimSpace(getString(inputMap, "answer"))
+	if answer == "" {
+		return core.QuerySignal{}, false
+	}
+	switch lower := strings.ToLower(strings.TrimRight(answer, ".!")); lower {
+	case "yes", "no", "unknown":
+		answer = lower
+	}
+
+	return core.QuerySignal{
+		Question:   question,
+		Answer:     answer,
+		Reasoning:  strings.TrimSpace(getString(inputMap, "reasoning")),
+		Confidence: math.Min(math.Max(getFloat(inputMap, "confidence"), 0), 1),
+	}, true
+}
@@ -0,0 +1,75 @@
+package vision
+
+import (
+	"testing"
+
+	"github.com/perceptumx/percepta/internal/core"
+)
+
+func TestAnswerQuestionTool_Schema(t *testing.T) {
+	tool := answerQuestionTool()
+
+	if tool.Name != "answer_visual_question" {
+		t.Errorf("expected tool name 'answer_visual_question', got '%s'", tool.Name)
+	}
+	for _, field := range []string{"answer", "reasoning", "confidence"} {
+		if _, ok := tool.InputSchema.Properties.(map[string]interface{})[field]; !ok {
+			t.Errorf("expected property %q in the schema", field)
+		}
+	}
+	if len(tool.InputSchema.Required) != 2 {
+		t.Errorf("expected answer and confidence to be required, got %v", tool.InputSchema.Required)
+	}
+}
+
+func TestParseQueryToolResponse(t *testing.T) {
+	const question = "Is the WiFi icon shown?"
+	tests := []struct {
+		name   string
+		input  interface{}
+		want   core.QuerySignal
+		wantOK bool
+	}{
+		{
+			name:   "yes answer",
+			input:  map[string]interface{}{"answer": "yes", "reasoning": "Top right corner", "confidence": 0.9},
+			want:   core.QuerySignal{Question: question, Answer: "yes", Reasoning: "Top right corner", Confidence: 0.9},
+			wantOK: true,
+		},
+		{
+			name:   "normalizes yes/no/unknown",
+			input:  map[string]interface{}{"answer": " No. ", "confidence": 0.7},
+			want:   core.QuerySignal{Question: question, Answer: "no", Confidence: 0.7},
+			wantOK: true,
+		},
+		{
+			name:   "keeps text answers as given",
+			input:  map[string]interface{}{"answer": "Connected!", "confidence": 0.8},
+			want:   core.QuerySignal{Question: question, Answer: "Connected!", Confidence: 0.8},
+			wantOK: true,
+		},
+		{
+			name:   "clamps confidence",
+			input:  map[string]interface{}{"answer": "unknown", "confidence": 1.5},
+			want:   core.QuerySignal{Question: question, Answer: "unknown", Confidence: 1},
+			wantOK: true,
+		},
+		{
+			name:  "missing answer",
+			input: map[string]interface{}{"confidence": 0.9},
+		},
+		{
+			name:  "invalid input",
+			input: "yes",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := parseQueryToolResponse(question, tt.input)
+			if ok != tt.wantOK || got != tt.want {
+				t.Errorf("expected %+v (%v), got %+v (%v)", tt.want, tt.wantOK, got, ok)
+			}
+		})
+	}
+}
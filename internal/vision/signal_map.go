@@ -0,0 +1,76 @@
+package vision
+
+import (
+	"fmt"
+	"image"
+	"sort"
+	"strings"
+
+	"github.com/perceptumx/percepta/internal/core"
+)
+
+// SignalMap names a device's LEDs and displays by where they are in the
+// frame, as calibrated with 'percepta device calibrate'. Parsers report these
+// names instead of inventing their own ("LED1" one run, "green led" the next).
+type SignalMap struct {
+	LEDs     map[string]image.Rectangle
+	Displays map[string]image.Rectangle
+}
+
+func (m SignalMap) Empty() bool {
+	return len(m.LEDs) == 0 && len(m.Displays) == 0
+}
+
+// prompt tells the vision model the calibrated names and where each signal is
+// in the width×height frame
+func (m SignalMap) prompt(width, height int) string {
+	if m.Empty() {
+		return ""
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "\n\nThis device is calibrated. Report these signals under exactly these names; pixel boxes are x,y,width,height in the %dx%d image:\n", width, height)
+	for _, name := range sortedNames(m.LEDs) {
+		r := m.LEDs[name]
+		fmt.Fprintf(&b, "- LED %q: %d,%d,%d,%d\n", name, r.Min.X, r.Min.Y, r.Dx(), r.Dy())
+	}
+	for _, name := range sortedNames(m.Displays) {
+		r := m.Displays[name]
+		fmt.Fprintf(&b, "- Display %q: %d,%d,%d,%d\n", name, r.Min.X, r.Min.Y, r.Dx(), r.Dy())
+	}
+	b.WriteString("Report a calibrated LED even when it is off. Name any other LED LED1, LED2, etc.")
+	return b.String()
+}
+
+// canonical renames signals that match a calibrated name in all but case to
+// that name
+func (m SignalMap) canonical(signals []core.Signal) []core.Signal {
+	for i, s := range signals {
+		switch t := s.(type) {
+		case core.LEDSignal:
+			t.Name = canonicalName(t.Name, m.LEDs)
+			signals[i] = t
+		case core.DisplaySignal:
+			t.Name = canonicalName(t.Name, m.Displays)
+			signals[i] = t
+		}
+	}
+	return signals
+}
+
+func canonicalName(name string, calibrated map[string]image.Rectangle) string {
+	for c := range calibrated {
+		if strings.EqualFold(c, strings.TrimSpace(name)) {
+			return c
+		}
+	}
+	return name
+}
+
+func sortedNames(regions map[string]image.Rectangle) []string {
+	names := make([]string, 0, len(regions))
+	for name := range regions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
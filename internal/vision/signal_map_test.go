@@ -0,0 +1,56 @@
+package vision
+
+import (
+	"image"
+	"strings"
+	"testing"
+
+	"github.com/perceptumx/percepta/internal/core"
+)
+
+func TestSignalMap_Prompt(t *testing.T) {
+	if got := (SignalMap{}).prompt(640, 480); got != "" {
+		t.Errorf("Expected no hint for an uncalibrated device, got %q", got)
+	}
+
+	signals := SignalMap{
+		LEDs: map[string]image.Rectangle{
+			"status": image.Rect(150, 84, 166, 100),
+			"power":  image.Rect(112, 84, 130, 102),
+		},
+		Displays: map[string]image.Rectangle{"lcd": image.Rect(180, 96, 420, 176)},
+	}
+	got := signals.prompt(640, 480)
+	for _, want := range []string{
+		"640x480 image",
+		`- LED "power": 112,84,18,18` + "\n" + `- LED "status": 150,84,16,16`,
+		`- Display "lcd": 180,96,240,80`,
+		"even when it is off",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Expected the hint to contain %q, got:\n%s", want, got)
+		}
+	}
+}
+
+func TestSignalMap_Canonical(t *testing.T) {
+	signals := SignalMap{
+		LEDs:     map[string]image.Rectangle{"power": image.Rect(0, 0, 8, 8)},
+		Displays: map[string]image.Rectangle{"lcd": image.Rect(0, 0, 80, 20)},
+	}
+	got := signals.canonical([]core.Signal{
+		core.LEDSignal{Name: "Power ", On: true},
+		core.LEDSignal{Name: "LED2"},
+		core.DisplaySignal{Name: "LCD", Text: "Ready"},
+	})
+
+	if led := got[0].(core.LEDSignal); led.Name != "power" || !led.On {
+		t.Errorf("Expected the calibrated name, got %+v", led)
+	}
+	if led := got[1].(core.LEDSignal); led.Name != "LED2" {
+		t.Errorf("Expected an uncalibrated LED to keep its name, got %+v", led)
+	}
+	if display := got[2].(core.DisplaySignal); display.Name != "lcd" || display.Text != "Ready" {
+		t.Errorf("Expected the calibrated display name, got %+v", display)
+	}
+}
@@ -1,18 +1,25 @@
 package vision
 
 import (
+	"bytes"
 	"context"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"image"
+	"math"
+	"strings"
 
 	"github.com/anthropics/anthropic-sdk-go"
 	"github.com/perceptumx/percepta/internal/core"
+	"github.com/perceptumx/percepta/internal/readings"
+	"github.com/perceptumx/percepta/internal/tolerance"
 )
 
 // StructuredParser uses Claude tool use for deterministic signal extraction
 type StructuredParser struct {
-	client *anthropic.Client
+	client  *anthropic.Client
+	signals SignalMap // Calibrated names to report signals under, if any
 }
 
 func NewStructuredParser(client *anthropic.Client) *StructuredParser {
@@ -23,7 +30,7 @@ func NewStructuredParser(client *anthropic.Client) *StructuredParser {
 func ledDetectionTool() anthropic.ToolParam {
 	return anthropic.ToolParam{
 		Name:        "report_led_signals",
-		Description: anthropic.String("Report all detected LED signals with state, color, and blink frequency"),
+		Description: anthropic.String("Report all detected LED signals with state, color, brightness and blink frequency"),
 		InputSchema: anthropic.ToolInputSchemaParam{
 			Type: "object",
 			Properties: map[string]interface{}{
@@ -34,7 +41,8 @@ func ledDetectionTool() anthropic.ToolParam {
 						"properties": map[string]interface{}{
 							"name":       map[string]string{"type": "string", "description": "LED identifier (LED1, LED2, etc)"},
 							"on":         map[string]string{"type": "boolean", "description": "True if LED is currently on"},
-							"color":      map[string]string{"type": "string", "description": "Color name if visible (red/green/blue/yellow/white/orange)"},
+							"color":      map[string]string{"type": "string", "description": "Color name if visible (" + strings.Join(tolerance.ColorNames(), "/") + ")"},
+							"brightness": map[string]string{"type": "number", "description": "If on, brightness 1-100 as percent of the LED's full intensity (dimmed by PWM or mid-fade LEDs are lower)"},
 							"blink_hz":   map[string]string{"type": "number", "description": "Blink frequency in Hz if blinking, 0 if steady"},
 							"confidence": map[string]string{"type": "number", "description": "Confidence 0-1 in detection"},
 						},
@@ -62,6 +70,19 @@ func displayDetectionTool() anthropic.ToolParam {
 							"name":       map[string]string{"type": "string", "description": "Display type (OLED/LCD/Display)"},
 							"text":       map[string]string{"type": "string", "description": "Exact text shown on display"},
 							"confidence": map[string]string{"type": "number", "description": "OCR confidence 0-1"},
+							"values": map[string]interface{}{
+								"type":        "array",
+								"description": "Labelled readings shown on the display, e.g. TEMP 23.4C → key temp, value 23.4, unit C",
+								"items": map[string]interface{}{
+									"type": "object",
+									"properties": map[string]interface{}{
+										"key":   map[string]string{"type": "string", "description": "Reading label in lower case (temp, v, ip)"},
+										"value": map[string]string{"type": "string", "description": "Value exactly as shown, without the unit"},
+										"unit":  map[string]string{"type": "string", "description": "Unit if shown (C, V, %)"},
+									},
+									"required": []string{"key", "value"},
+								},
+							},
 						},
 						"required": []string{"name", "text", "confidence"},
 					},
@@ -76,6 +97,17 @@ func (p *StructuredParser) Parse(frame []byte) ([]core.Signal, error) {
 	// Encode frame to base64
 	base64Frame := base64.StdEncoding.EncodeToString(frame)
 
+	prompt := `Analyze this embedded hardware device. Use the tools to report:
+1. All detected LEDs (use report_led_signals tool)
+2. All display content (use report_display_content tool)
+
+Be precise with measurements.`
+	if !p.signals.Empty() {
+		if cfg, _, err := image.DecodeConfig(bytes.NewReader(frame)); err == nil {
+			prompt += p.signals.prompt(cfg.Width, cfg.Height)
+		}
+	}
+
 	// Create tools
 	ledTool := ledDetectionTool()
 	displayTool := displayDetectionTool()
@@ -94,11 +126,7 @@ func (p *StructuredParser) Parse(frame []byte) ([]core.Signal, error) {
 					string(anthropic.Base64ImageSourceMediaTypeImageJPEG),
 					base64Frame,
 				),
-				anthropic.NewTextBlock(`Analyze this embedded hardware device. Use the tools to report:
-1. All detected LEDs (use report_led_signals tool)
-2. All display content (use report_display_content tool)
-
-Be precise with measurements.`),
+				anthropic.NewTextBlock(prompt),
 			),
 		},
 	})
@@ -123,7 +151,7 @@ Be precise with measurements.`),
 		}
 	}
 
-	return signals, nil
+	return p.signals.canonical(signals), nil
 }
 
 func parseLEDToolResponse(input interface{}) []core.Signal {
@@ -152,9 +180,14 @@ func parseLEDToolResponse(input interface{}) []core.Signal {
 		}
 
 		if colorStr := getString(led, "color"); colorStr != "" {
+			signal.ColorName = strings.ToLower(strings.TrimSpace(colorStr))
 			signal.Color = parseColor(colorStr)
 		}
 
+		if signal.On {
+			signal.Brightness = brightnessPercent(getFloat(led, "brightness"))
+		}
+
 		if blinkHz := getFloat(led, "blink_hz"); blinkHz > 0 {
 			signal.BlinkHz = blinkHz
 		}
@@ -188,29 +221,42 @@ func parseDisplayToolResponse(input interface{}) []core.Signal {
 			Name:       getString(display, "name"),
 			Text:       getString(display, "text"),
 			Confidence: getFloat(display, "confidence"),
+			Values:     parseDisplayValues(display["values"]),
 		})
 	}
 
 	return signals
 }
 
+// parseDisplayValues converts the tool's labelled readings, keyed in lower case
+func parseDisplayValues(input interface{}) map[string]core.DisplayValue {
+	items, ok := input.([]interface{})
+	if !ok || len(items) == 0 {
+		return nil
+	}
+
+	values := make(map[string]core.DisplayValue, len(items))
+	for _, item := range items {
+		v, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		key := strings.ToLower(strings.TrimSpace(getString(v, "key")))
+		if key == "" {
+			continue
+		}
+		values[key] = readings.NewValue(strings.TrimSpace(getString(v, "value")), strings.TrimSpace(getString(v, "unit")))
+	}
+	return values
+}
+
+// parseColor maps a reported color name to its representative RGB value.
+// Unknown names map to (0,0,0); the name itself is kept on the signal.
 func parseColor(colorStr string) core.RGB {
-	switch colorStr {
-	case "red":
-		return core.RGB{R: 255, G: 0, B: 0}
-	case "green":
-		return core.RGB{R: 0, G: 255, B: 0}
-	case "blue":
-		return core.RGB{R: 0, G: 0, B: 255}
-	case "yellow":
-		return core.RGB{R: 255, G: 255, B: 0}
-	case "white":
-		return core.RGB{R: 255, G: 255, B: 255}
-	case "orange":
-		return core.RGB{R: 255, G: 165, B: 0}
-	default:
-		return core.RGB{}
+	if named, ok := tolerance.LookupColor(colorStr); ok {
+		return named.RGB
 	}
+	return core.RGB{}
 }
 
 // Helper functions for safe type conversion
@@ -228,6 +274,15 @@ func getBool(m map[string]interface{}, key string) bool {
 	return false
 }
 
+// brightnessPercent rounds a reported brightness to a whole percent, clamped
+// to 1-100; 0 (not reported) stays 0
+func brightnessPercent(v float64) uint8 {
+	if v <= 0 {
+		return 0
+	}
+	return uint8(math.Round(math.Min(math.Max(v, 1), 100)))
+}
+
 func getFloat(m map[string]interface{}, key string) float64 {
 	// Handle both float64 and json.Number
 	switch v := m[key].(type) {
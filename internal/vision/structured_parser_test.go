@@ -155,6 +155,8 @@ func TestParseColor(t *testing.T) {
 		{"yellow", core.RGB{R: 255, G: 255, B: 0}},
 		{"white", core.RGB{R: 255, G: 255, B: 255}},
 		{"orange", core.RGB{R: 255, G: 165, B: 0}},
+		{"amber", core.RGB{R: 255, G: 191, B: 0}},
+		{"Blue", core.RGB{R: 0, G: 0, B: 255}},
 		{"unknown", core.RGB{R: 0, G: 0, B: 0}},
 	}
 
@@ -359,6 +361,25 @@ func TestParseLEDToolResponse_WithColor(t *testing.T) {
 	if led.Color.R != 255 || led.Color.G != 0 || led.Color.B != 0 {
 		t.Errorf("expected red color RGB(255,0,0), got RGB(%d,%d,%d)", led.Color.R, led.Color.G, led.Color.B)
 	}
+	if led.ColorName != "red" {
+		t.Errorf("expected color name 'red' to be kept, got %q", led.ColorName)
+	}
+}
+
+func TestParseLEDToolResponse_UnknownColorName(t *testing.T) {
+	input := map[string]interface{}{
+		"leds": []interface{}{
+			map[string]interface{}{"name": "RGB1", "on": true, "color": "Teal", "confidence": float64(0.9)},
+		},
+	}
+
+	led := parseLEDToolResponse(input)[0].(core.LEDSignal)
+	if led.ColorName != "teal" || led.Color != (core.RGB{}) {
+		t.Errorf("expected unknown name to be kept without RGB, got %q %+v", led.ColorName, led.Color)
+	}
+	if !led.HasColor() {
+		t.Error("expected a reported color name to count as color information")
+	}
 }
 
 func TestParseLEDToolResponse_WithBlinkHz(t *testing.T) {
@@ -626,3 +647,50 @@ func TestGetFloat_JSONNumber(t *testing.T) {
 		})
 	}
 }
+
+func TestParseDisplayToolResponse_Values(t *testing.T) {
+	input := map[string]interface{}{
+		"displays": []interface{}{
+			map[string]interface{}{
+				"name":       "LCD",
+				"text":       "TEMP 23.4C IP 10.0.0.7",
+				"confidence": float64(0.9),
+				"values": []interface{}{
+					map[string]interface{}{"key": "Temp", "value": "23.4", "unit": "C"},
+					map[string]interface{}{"key": "ip", "value": "10.0.0.7"},
+					map[string]interface{}{"value": "no key"},
+				},
+			},
+		},
+	}
+
+	display := parseDisplayToolResponse(input)[0].(core.DisplaySignal)
+	if len(display.Values) != 2 {
+		t.Fatalf("expected 2 values, got %+v", display.Values)
+	}
+	if temp := display.Values["temp"]; temp.Number == nil || *temp.Number != 23.4 || temp.Unit != "C" {
+		t.Errorf("expected temp 23.4 C, got %+v", temp)
+	}
+	if ip := display.Values["ip"]; ip.Text != "10.0.0.7" || ip.Number != nil {
+		t.Errorf("expected non-numeric ip value, got %+v", ip)
+	}
+}
+
+func TestParseLEDToolResponse_Brightness(t *testing.T) {
+	input := map[string]interface{}{
+		"leds": []interface{}{
+			map[string]interface{}{"name": "backlight", "on": true, "brightness": float64(48.6), "confidence": float64(0.9)},
+			map[string]interface{}{"name": "glare", "on": true, "brightness": float64(140), "confidence": float64(0.9)},
+			map[string]interface{}{"name": "standby", "on": false, "brightness": float64(30), "confidence": float64(0.9)},
+			map[string]interface{}{"name": "power", "on": true, "confidence": float64(0.9)},
+		},
+	}
+
+	want := map[string]uint8{"backlight": 49, "glare": 100, "standby": 0, "power": 0}
+	for _, sig := range parseLEDToolResponse(input) {
+		led := sig.(core.LEDSignal)
+		if led.Brightness != want[led.Name] {
+			t.Errorf("%s: expected brightness %d, got %d", led.Name, want[led.Name], led.Brightness)
+		}
+	}
+}
@@ -0,0 +1,302 @@
+package vision
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/perceptumx/percepta/internal/core"
+)
+
+const (
+	// locateCellSize is the grid cell (pixels) used to find an LED automatically
+	locateCellSize = 16
+
+	// minOnOffContrast is the brightness difference (0-255) below which an LED is
+	// considered steady: on and off levels cannot be told apart
+	minOnOffContrast = 20.0
+
+	// confidentContrast is the on/off brightness difference that earns full confidence
+	confidentContrast = 80.0
+)
+
+// TimelineCapture records per-frame on/off timelines for LEDs by measuring
+// brightness locally in every frame. No vision model is called, so it keeps up
+// with the camera frame rate, which decoding blink codes needs.
+type TimelineCapture struct {
+	camera   core.CameraDriver
+	interval time.Duration // Time between frames
+	duration time.Duration // Total capture length
+}
+
+func NewTimelineCapture(camera core.CameraDriver, fps int, duration time.Duration) *TimelineCapture {
+	if fps <= 0 {
+		fps = 20
+	}
+	return &TimelineCapture{
+		camera:   camera,
+		interval: time.Second / time.Duration(fps),
+		duration: duration,
+	}
+}
+
+// LEDTimeline is the per-frame history of one LED
+type LEDTimeline struct {
+	Samples  []core.LEDSample
+	Region   image.Rectangle // Pixel area that was measured
+	Contrast float64         // Brightness difference between on and off levels (0-255)
+	Peak     float64         // Median peak brightness (0-255) of the lit frames, or of all frames when steady; 0 when the region was located automatically
+}
+
+// Steady reports that on and off levels could not be told apart
+func (t LEDTimeline) Steady() bool {
+	return t.Contrast < minOnOffContrast
+}
+
+// Lit reports whether the LED was on during the capture. A blinking LED is;
+// a steady one is judged by its peak brightness, as in a single frame.
+func (t LEDTimeline) Lit() bool {
+	if t.Steady() {
+		return t.Peak >= litLevel
+	}
+	for _, s := range t.Samples {
+		if s.On {
+			return true
+		}
+	}
+	return false
+}
+
+// Confidence rates how clearly on and off frames could be told apart, or for
+// a steady LED how clearly its peak brightness reads lit or unlit. A steady LED
+// located automatically has no peak and cannot be judged.
+func (t LEDTimeline) Confidence() float64 {
+	if t.Steady() {
+		if t.Peak == 0 {
+			return 0
+		}
+		return 0.5 + 0.5*math.Min(math.Abs(t.Peak-litLevel)/confidentMargin, 1)
+	}
+	if t.Contrast >= confidentContrast {
+		return 1
+	}
+	return t.Contrast / confidentContrast
+}
+
+// Brightness is the LED's brightness (percent of full intensity) while lit,
+// read from the region's peak as in a single frame so that it does not depend
+// on how much of the region the LED fills. It is 0 when the region was located
+// automatically and has no peak.
+func (t LEDTimeline) Brightness() uint8 {
+	if t.Peak == 0 {
+		return 0
+	}
+	return max(luminancePercent(t.Peak), 1)
+}
+
+// Capture samples frames for the configured duration. regions maps LED names to
+// the pixel area to measure; an empty rectangle locates the LED automatically as
+// the area whose brightness varies most, which only works for one LED at a time.
+// Each sample's brightness is the region's mean luminance in percent.
+// The camera must already be open.
+func (c *TimelineCapture) Capture(regions map[string]image.Rectangle) (map[string]LEDTimeline, error) {
+	var locate string
+	for name, rect := range regions {
+		if !rect.Empty() {
+			continue
+		}
+		if locate != "" {
+			return nil, fmt.Errorf("cannot locate LEDs %q and %q automatically at the same time; configure their regions", locate, name)
+		}
+		locate = name
+	}
+
+	levels := make(map[string][]float64, len(regions))
+	peaks := make(map[string][]float64, len(regions))
+	var grids [][]float64
+	var offsets []int64
+	var bounds image.Rectangle
+
+	start := time.Now()
+	for frameStart := start; time.Since(start) < c.duration || len(offsets) == 0; {
+		frame, err := c.camera.CaptureFrame()
+		if err != nil {
+			return nil, fmt.Errorf("frame %d capture failed: %w", len(offsets), err)
+		}
+		offset := time.Since(start).Milliseconds()
+
+		img, err := jpeg.Decode(bytes.NewReader(frame))
+		if err != nil {
+			return nil, fmt.Errorf("frame %d decode failed: %w", len(offsets), err)
+		}
+		bounds = img.Bounds()
+
+		for name, rect := range regions {
+			if !rect.Empty() {
+				levels[name] = append(levels[name], meanLuminance(img, rect))
+				peaks[name] = append(peaks[name], regionPeak(img, rect))
+			}
+		}
+		if locate != "" {
+			grids = append(grids, cellLuminance(img, locateCellSize))
+		}
+		offsets = append(offsets, offset)
+
+		if wait := c.interval - time.Since(frameStart); wait > 0 {
+			time.Sleep(wait)
+		}
+		frameStart = time.Now()
+	}
+
+	timelines := make(map[string]LEDTimeline, len(regions))
+	for name, rect := range regions {
+		series := levels[name]
+		if name == locate {
+			cell := mostVaryingCell(grids)
+			rect = cellRect(bounds, locateCellSize, cell)
+			series = make([]float64, len(grids))
+			for i, grid := range grids {
+				series[i] = grid[cell]
+			}
+		}
+
+		states, contrast := threshold(series)
+		samples := make([]core.LEDSample, len(states))
+		for i, on := range states {
+			samples[i] = core.LEDSample{OffsetMs: offsets[i], On: on, Brightness: luminancePercent(series[i])}
+		}
+		timelines[name] = LEDTimeline{Samples: samples, Region: rect, Contrast: contrast, Peak: litPeak(peaks[name], states, contrast)}
+	}
+
+	return timelines, nil
+}
+
+// meanLuminance averages the brightness (0-255) of the pixels in rect
+func meanLuminance(img image.Image, rect image.Rectangle) float64 {
+	rect = rect.Intersect(img.Bounds())
+	if rect.Empty() {
+		return 0
+	}
+
+	var sum float64
+	if ycc, ok := img.(*image.YCbCr); ok {
+		// JPEG frames decode to YCbCr: the Y plane is the luminance
+		for y := rect.Min.Y; y < rect.Max.Y; y++ {
+			for x := rect.Min.X; x < rect.Max.X; x++ {
+				sum += float64(ycc.Y[ycc.YOffset(x, y)])
+			}
+		}
+	} else {
+		for y := rect.Min.Y; y < rect.Max.Y; y++ {
+			for x := rect.Min.X; x < rect.Max.X; x++ {
+				sum += float64(color.GrayModel.Convert(img.At(x, y)).(color.Gray).Y)
+			}
+		}
+	}
+	return sum / float64(rect.Dx()*rect.Dy())
+}
+
+// regionPeak is the peak brightness (0-255) of rect, 0 when it lies outside the frame
+func regionPeak(img image.Image, rect image.Rectangle) float64 {
+	rect = rect.Intersect(img.Bounds())
+	if rect.Empty() {
+		return 0
+	}
+	_, peak, _ := regionLevels(img, rect)
+	return peak
+}
+
+// litPeak is the median of the peaks of the lit frames, or of every frame when
+// on and off cannot be told apart
+func litPeak(peaks []float64, states []bool, contrast float64) float64 {
+	var lit []float64
+	for i, peak := range peaks {
+		if states[i] || contrast < minOnOffContrast {
+			lit = append(lit, peak)
+		}
+	}
+	if len(lit) == 0 {
+		return 0
+	}
+	sort.Float64s(lit)
+	return lit[len(lit)/2]
+}
+
+// luminancePercent converts a mean luminance (0-255) to a brightness percent
+func luminancePercent(level float64) uint8 {
+	return uint8(math.Round(level * 100 / 255))
+}
+
+// cellLuminance returns the mean brightness of every cell×cell block, row by row
+func cellLuminance(img image.Image, cell int) []float64 {
+	b := img.Bounds()
+	cols, rows := (b.Dx()+cell-1)/cell, (b.Dy()+cell-1)/cell
+	out := make([]float64, 0, cols*rows)
+	for r := 0; r < rows; r++ {
+		for c := 0; c < cols; c++ {
+			out = append(out, meanLuminance(img, cellRect(b, cell, r*cols+c)))
+		}
+	}
+	return out
+}
+
+// cellRect is the pixel rectangle of grid cell index i
+func cellRect(bounds image.Rectangle, cell, i int) image.Rectangle {
+	cols := (bounds.Dx() + cell - 1) / cell
+	min := bounds.Min.Add(image.Pt((i%cols)*cell, (i/cols)*cell))
+	return image.Rectangle{Min: min, Max: min.Add(image.Pt(cell, cell))}.Intersect(bounds)
+}
+
+// mostVaryingCell picks the grid cell whose brightness changes most over time
+func mostVaryingCell(grids [][]float64) int {
+	if len(grids) == 0 {
+		return 0
+	}
+	best, bestVar := 0, -1.0
+	for cell := range grids[0] {
+		var sum, sumSq float64
+		for _, grid := range grids {
+			sum += grid[cell]
+			sumSq += grid[cell] * grid[cell]
+		}
+		n := float64(len(grids))
+		variance := sumSq/n - (sum/n)*(sum/n)
+		if variance > bestVar {
+			best, bestVar = cell, variance
+		}
+	}
+	return best
+}
+
+// threshold classifies brightness levels as on or off around the midpoint of
+// the dim and bright levels (2nd and 98th percentiles, so short pulses still count but single-frame glitches do not).
+// Without enough contrast every frame is reported off: the LED is steady and
+// its state is judged from the region's peak brightness instead (see Lit).
+func threshold(levels []float64) ([]bool, float64) {
+	states := make([]bool, len(levels))
+	if len(levels) == 0 {
+		return states, 0
+	}
+
+	sorted := make([]float64, len(levels))
+	copy(sorted, levels)
+	sort.Float64s(sorted)
+	lo := sorted[len(sorted)/50]
+	hi := sorted[len(sorted)-1-len(sorted)/50]
+
+	contrast := hi - lo
+	if contrast < minOnOffContrast {
+		return states, contrast
+	}
+
+	mid := (lo + hi) / 2
+	for i, level := range levels {
+		states[i] = level > mid
+	}
+	return states, contrast
+}
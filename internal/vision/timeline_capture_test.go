@@ -0,0 +1,170 @@
+package vision
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"testing"
+	"time"
+
+	"github.com/perceptumx/percepta/internal/core"
+)
+
+// frameCamera replays JPEG frames, repeating the last one
+type frameCamera struct {
+	frames [][]byte
+	next   int
+}
+
+func (c *frameCamera) Open() error  { return nil }
+func (c *frameCamera) Close() error { return nil }
+func (c *frameCamera) CaptureFrame() ([]byte, error) {
+	if len(c.frames) == 0 {
+		return nil, fmt.Errorf("no frames")
+	}
+	i := c.next
+	if i >= len(c.frames) {
+		i = len(c.frames) - 1
+	}
+	c.next++
+	return c.frames[i], nil
+}
+
+// ledFrame renders a dark 64x48 frame with a bright 8x8 square at (x,y) when lit
+func ledFrame(t *testing.T, x, y int, lit bool) []byte {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, 64, 48))
+	for py := 0; py < 48; py++ {
+		for px := 0; px < 64; px++ {
+			c := color.RGBA{R: 20, G: 20, B: 20, A: 255}
+			if lit && px >= x && px < x+8 && py >= y && py < y+8 {
+				c = color.RGBA{R: 255, G: 255, B: 255, A: 255}
+			}
+			img.Set(px, py, c)
+		}
+	}
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: 90}); err != nil {
+		t.Fatalf("jpeg encode failed: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func blinkingCamera(t *testing.T, x, y int, pattern ...bool) *frameCamera {
+	cam := &frameCamera{}
+	for _, lit := range pattern {
+		cam.frames = append(cam.frames, ledFrame(t, x, y, lit))
+	}
+	return cam
+}
+
+func TestTimelineCapture_ConfiguredRegion(t *testing.T) {
+	pattern := []bool{false, true, true, false, true, false, false, true}
+	cam := blinkingCamera(t, 16, 16, pattern...)
+	capture := &TimelineCapture{camera: cam, interval: time.Millisecond, duration: 100 * time.Millisecond}
+
+	timelines, err := capture.Capture(map[string]image.Rectangle{"err": image.Rect(16, 16, 24, 24)})
+	if err != nil {
+		t.Fatalf("Capture failed: %v", err)
+	}
+	tl := timelines["err"]
+	if tl.Region != image.Rect(16, 16, 24, 24) {
+		t.Errorf("Expected configured region to be kept, got %v", tl.Region)
+	}
+	if len(tl.Samples) < len(pattern) {
+		t.Fatalf("Expected at least %d samples, got %d", len(pattern), len(tl.Samples))
+	}
+	for i, want := range pattern {
+		if tl.Samples[i].On != want {
+			t.Errorf("Frame %d: expected on=%v, got %v", i, want, tl.Samples[i].On)
+		}
+		// Lit frames are white (100%), dark ones 20/255 (8%)
+		if b := tl.Samples[i].Brightness; (want && b < 95) || (!want && (b < 5 || b > 12)) {
+			t.Errorf("Frame %d: unexpected brightness %d%%", i, b)
+		}
+		if i > 0 && tl.Samples[i].OffsetMs < tl.Samples[i-1].OffsetMs {
+			t.Errorf("Expected increasing offsets, got %d after %d", tl.Samples[i].OffsetMs, tl.Samples[i-1].OffsetMs)
+		}
+	}
+}
+
+func TestTimelineCapture_LocatesLED(t *testing.T) {
+	pattern := []bool{false, true, false, true, false, true}
+	cam := blinkingCamera(t, 32, 16, pattern...)
+	capture := &TimelineCapture{camera: cam, interval: time.Millisecond, duration: 100 * time.Millisecond}
+
+	timelines, err := capture.Capture(map[string]image.Rectangle{"err": {}})
+	if err != nil {
+		t.Fatalf("Capture failed: %v", err)
+	}
+	tl := timelines["err"]
+	if !tl.Region.Overlaps(image.Rect(32, 16, 40, 24)) {
+		t.Errorf("Expected located region to overlap the LED, got %v", tl.Region)
+	}
+	if tl.Steady() || tl.Confidence() <= 0.5 {
+		t.Errorf("Expected a confident blinking timeline, got contrast %.1f", tl.Contrast)
+	}
+	if len(tl.Samples) < len(pattern) {
+		t.Fatalf("Expected at least %d samples, got %d", len(pattern), len(tl.Samples))
+	}
+	for i, want := range pattern {
+		if tl.Samples[i].On != want {
+			t.Errorf("Frame %d: expected on=%v, got %v", i, want, tl.Samples[i].On)
+		}
+	}
+}
+
+func TestTimelineCapture_SteadyLED(t *testing.T) {
+	states, contrast := threshold([]float64{200, 201, 199, 200})
+	if contrast >= minOnOffContrast {
+		t.Errorf("Expected low contrast, got %.1f", contrast)
+	}
+	for _, on := range states {
+		if on {
+			t.Error("Expected steady LED frames to be reported off")
+		}
+	}
+	if !(LEDTimeline{Contrast: contrast}).Steady() {
+		t.Error("Expected Steady() for low contrast")
+	}
+
+	// A steadily lit LED is judged by its peak brightness, like a single frame
+	for _, lit := range []bool{true, false} {
+		cam := blinkingCamera(t, 16, 16, lit, lit, lit)
+		capture := &TimelineCapture{camera: cam, interval: time.Millisecond, duration: 10 * time.Millisecond}
+		timelines, err := capture.Capture(map[string]image.Rectangle{"power": image.Rect(12, 12, 28, 28)})
+		if err != nil {
+			t.Fatalf("Capture failed: %v", err)
+		}
+		tl := timelines["power"]
+		if !tl.Steady() || tl.Lit() != lit || tl.Confidence() < 0.9 {
+			t.Errorf("Expected a confident steady lit=%v LED, got lit=%v peak %.0f confidence %.2f", lit, tl.Lit(), tl.Peak, tl.Confidence())
+		}
+	}
+
+	// Located automatically there is no peak to judge by
+	if c := (LEDTimeline{Contrast: contrast}).Confidence(); c != 0 {
+		t.Errorf("Expected no confidence without a peak, got %.2f", c)
+	}
+}
+
+func TestLEDTimeline_Brightness(t *testing.T) {
+	// Samples hold the region's mean, which a small LED keeps low
+	blinking := LEDTimeline{Contrast: 100, Peak: 204, Samples: []core.LEDSample{{On: true, Brightness: 30}, {Brightness: 5}}}
+	if got := blinking.Brightness(); got != 80 {
+		t.Errorf("Expected the peak brightness (80%%), got %d%%", got)
+	}
+	if got := (LEDTimeline{Contrast: 100}).Brightness(); got != 0 {
+		t.Errorf("Expected no brightness without a peak, got %d%%", got)
+	}
+}
+
+func TestTimelineCapture_TwoUnlocatedLEDs(t *testing.T) {
+	capture := NewTimelineCapture(&frameCamera{}, 20, time.Second)
+	_, err := capture.Capture(map[string]image.Rectangle{"a": {}, "b": {}})
+	if err == nil {
+		t.Fatal("Expected an error when two LEDs need locating")
+	}
+}
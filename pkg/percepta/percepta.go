@@ -1,40 +1,51 @@
-//go:build linux || darwin
-
 package percepta
 
 import (
 	"fmt"
+	"image"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/perceptumx/percepta/internal/camera"
 	"github.com/perceptumx/percepta/internal/core"
 	"github.com/perceptumx/percepta/internal/filter"
+	"github.com/perceptumx/percepta/internal/timeline"
 	"github.com/perceptumx/percepta/internal/vision"
 )
 
 type Core struct {
 	camera   core.CameraDriver
-	vision   *vision.ClaudeVision
+	vision   vision.Driver
 	storage  core.StorageDriver
 	smoother *filter.TemporalSmoother
 }
 
 func NewCore(cameraPath string, storage core.StorageDriver) (*Core, error) {
-	// Initialize camera driver (platform-specific)
-	cameraDriver := camera.NewCamera(cameraPath)
-
 	// Initialize vision driver
 	visionDriver, err := vision.NewClaudeVision()
 	if err != nil {
 		return nil, fmt.Errorf("vision init failed: %w", err)
 	}
 
+	return NewCoreWithVision(cameraPath, visionDriver, storage), nil
+}
+
+// NewCoreWithVision is NewCore with another vision backend, e.g. the local
+// one from vision.NewDriver for machines without network access
+func NewCoreWithVision(cameraPath string, visionDriver vision.Driver, storage core.StorageDriver) *Core {
 	return &Core{
-		camera:   cameraDriver,
+		camera:   camera.NewCamera(cameraPath), // Platform-specific
 		vision:   visionDriver,
 		storage:  storage,
 		smoother: filter.NewTemporalSmoother(storage),
-	}, nil
+	}
+}
+
+// RecordTo saves every frame the core captures from now on to dir, as a
+// session a file:// camera replays with the original timing
+func (c *Core) RecordTo(dir string) {
+	c.camera = camera.NewRecorder(c.camera, dir)
 }
 
 func (c *Core) Observe(deviceID string) (*core.Observation, error) {
@@ -46,9 +57,35 @@ func (c *Core) ObserveWithOptions(deviceID string, frameCount int, interval time
 }
 
 func (c *Core) observe(deviceID string, frameCount int, interval time.Duration) (*core.Observation, error) {
+	obs, _, err := c.observeFrames(deviceID, frameCount, interval)
+	return obs, err
+}
+
+// ObserveDiscovering observes like ObserveWithOptions and also discovers the
+// LEDs in the captured frames locally, for proposing LED regions and checking
+// the vision model's LED count with vision.CheckLEDCount
+func (c *Core) ObserveDiscovering(deviceID string, frameCount int, interval time.Duration) (*core.Observation, []vision.DiscoveredLED, error) {
+	obs, frames, err := c.observeFrames(deviceID, frameCount, interval)
+	if err != nil {
+		return nil, nil, err
+	}
+	burst := make([][]byte, len(frames))
+	for i, frame := range frames {
+		burst[i] = frame.Frame
+	}
+	discovered, err := vision.DiscoverLEDs(burst)
+	if err != nil {
+		return nil, nil, fmt.Errorf("LED discovery failed: %w", err)
+	}
+	return obs, discovered, nil
+}
+
+// observeFrames captures and aggregates a multi-frame observation, returning
+// the frames too
+func (c *Core) observeFrames(deviceID string, frameCount int, interval time.Duration) (*core.Observation, []vision.FrameResult, error) {
 	// Open camera
 	if err := c.camera.Open(); err != nil {
-		return nil, fmt.Errorf("camera open failed: %w", err)
+		return nil, nil, fmt.Errorf("camera open failed: %w", err)
 	}
 	defer c.camera.Close()
 
@@ -61,11 +98,11 @@ func (c *Core) observe(deviceID string, frameCount int, interval time.Duration)
 	}
 	frames, err := multiFrame.Capture()
 	if err != nil {
-		return nil, fmt.Errorf("multi-frame capture failed: %w", err)
+		return nil, nil, fmt.Errorf("multi-frame capture failed: %w", err)
 	}
 
 	if len(frames) == 0 {
-		return nil, fmt.Errorf("no frames captured")
+		return nil, nil, fmt.Errorf("no frames captured")
 	}
 
 	// Aggregate LED detections across frames
@@ -95,10 +132,211 @@ func (c *Core) observe(deviceID string, frameCount int, interval time.Duration)
 	smoothedObs, err := c.smoother.Smooth(obs)
 	if err != nil {
 		// Log but don't fail observation (graceful degradation)
-		return obs, nil
+		return obs, frames, nil
+	}
+
+	return smoothedObs, frames, nil
+}
+
+// TimelineOptions configures a high-rate capture of per-frame LED timelines
+type TimelineOptions struct {
+	LEDs     map[string]image.Rectangle // LED name → region (empty = locate automatically)
+	FPS      int                        // Frames per second (default 20)
+	Duration time.Duration              // Capture length (default 10s)
+	Scheme   timeline.Scheme            // Blink-code scheme used to decode the Codes timelines
+	Codes    []string                   // LEDs whose blink code is decoded; the others only get rates measured
+}
+
+// ObserveWithTimelines observes like Observe, then records a per-frame on/off
+// timeline for each requested LED, measures the blink rate of those that blink
+// and decodes the blink code of those in Codes
+func (c *Core) ObserveWithTimelines(deviceID string, opts TimelineOptions) (*core.Observation, error) {
+	obs, err := c.observe(deviceID, 0, 0)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.recordTimelines(obs, opts); err != nil {
+		return nil, err
+	}
+	return obs, nil
+}
+
+// recordTimelines captures the timelines opts asks for and applies them to obs
+func (c *Core) recordTimelines(obs *core.Observation, opts TimelineOptions) error {
+	if opts.Duration <= 0 {
+		opts.Duration = 10 * time.Second
+	}
+
+	if err := c.camera.Open(); err != nil {
+		return fmt.Errorf("camera open failed: %w", err)
+	}
+	defer c.camera.Close()
+
+	timelines, err := vision.NewTimelineCapture(c.camera, opts.FPS, opts.Duration).Capture(opts.LEDs)
+	if err != nil {
+		return fmt.Errorf("timeline capture failed: %w", err)
 	}
 
-	return smoothedObs, nil
+	applyTimelines(obs, timelines, opts)
+	return nil
+}
+
+// BootOptions configures a boot timing capture
+type BootOptions struct {
+	Trigger   func() error               // Powers on or resets the device; offsets count from its return
+	LEDs      map[string]image.Rectangle // LED name → region whose first lighting up is a milestone
+	FPS       int                        // Frames per second (default 20)
+	Duration  time.Duration              // Capture length (default 15s)
+	Timelines *TimelineOptions           // Also record these timelines once booted, like ObserveWithTimelines
+}
+
+// ObserveBoot times the device's boot: it calls the trigger, records frames at
+// a high rate for the whole Duration, finds when the picture settled, and then
+// observes the booted device like Observe (or ObserveWithTimelines when
+// Timelines is set). The observation carries a BootTimingSignal with the
+// milestones.
+func (c *Core) ObserveBoot(deviceID string, opts BootOptions) (*core.Observation, error) {
+	if err := c.camera.Open(); err != nil {
+		return nil, fmt.Errorf("camera open failed: %w", err)
+	}
+	boot, err := vision.NewBootCapture(c.camera, c.vision.GetParser(), opts.FPS, opts.Duration).Capture(opts.Trigger, opts.LEDs)
+	c.camera.Close()
+	if err != nil {
+		return nil, fmt.Errorf("boot capture failed: %w", err)
+	}
+
+	obs, err := c.observe(deviceID, 0, 0)
+	if err != nil {
+		return nil, err
+	}
+	if opts.Timelines != nil {
+		if err := c.recordTimelines(obs, *opts.Timelines); err != nil {
+			return nil, err
+		}
+	}
+	obs.Signals = append(obs.Signals, boot)
+	return obs, nil
+}
+
+// AnswerQuestions captures a frame and has the vision model answer each
+// free-form question about it, appending the answers to the observation as
+// QuerySignals
+func (c *Core) AnswerQuestions(obs *core.Observation, questions []string) error {
+	if len(questions) == 0 {
+		return nil
+	}
+	answerer := c.vision.GetAnswerer()
+	if answerer == nil {
+		return fmt.Errorf("vision questions need a vision model; set vision.provider to %s or %s", vision.ProviderClaude, vision.ProviderHybrid)
+	}
+
+	if err := c.camera.Open(); err != nil {
+		return fmt.Errorf("camera open failed: %w", err)
+	}
+	frame, err := c.camera.CaptureFrame()
+	c.camera.Close()
+	if err != nil {
+		return fmt.Errorf("frame capture failed: %w", err)
+	}
+
+	for _, question := range questions {
+		answer, err := answerer.Ask(frame, question)
+		if err != nil {
+			return fmt.Errorf("vision question failed: %w", err)
+		}
+		obs.Signals = append(obs.Signals, answer)
+	}
+	return nil
+}
+
+// applyTimelines attaches timelines, decoded blink codes and measured duty
+// cycles to the observation's LED signals, adding signals for LEDs the vision
+// model did not report. Measured brightness fills in where vision gave none.
+// Every blinking LED gets its blink rate measured from the timeline, replacing
+// the vision model's estimate. Blink codes are decoded only for opts.Codes:
+// a slow regular blink also reads as code "1". Decode failures leave
+// BlinkCode empty; the timeline is kept for inspection.
+func applyTimelines(obs *core.Observation, timelines map[string]vision.LEDTimeline, opts TimelineOptions) {
+	names := make([]string, 0, len(timelines))
+	for name := range timelines {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		tl := timelines[name]
+		confidence := tl.Confidence()
+		code := ""
+		var blink timeline.Blink
+		if !tl.Steady() {
+			if containsFold(opts.Codes, name) {
+				if decoded, err := timeline.Decode(tl.Samples, opts.Scheme); err == nil {
+					code = decoded.String()
+					confidence *= decoded.Confidence
+				}
+			}
+			if measured, err := timeline.EstimateBlink(tl.Samples); err == nil {
+				blink = measured
+			}
+		}
+
+		found := false
+		for i, sig := range obs.Signals {
+			led, ok := sig.(core.LEDSignal)
+			if !ok || !strings.EqualFold(led.Name, name) {
+				continue
+			}
+			led.Timeline = tl.Samples
+			led.BlinkCode = code
+			if duty, ok := timeline.DutyCycle(tl.Samples); ok && !tl.Steady() {
+				led.DutyCycle = duty
+			}
+			if blink.Hz > 0 {
+				led.BlinkHz, led.DutyCycle = blink.Hz, blink.DutyCycle
+				led.BlinkWarning = strings.Join(blink.Warnings, "; ")
+			}
+			if led.On && led.Brightness == 0 {
+				led.Brightness = tl.Brightness()
+			}
+			if confidence < led.Confidence {
+				led.Confidence = confidence
+			}
+			obs.Signals[i] = led
+			found = true
+			break
+		}
+
+		if !found {
+			led := core.LEDSignal{
+				Name:       name,
+				On:         tl.Lit(),
+				Timeline:   tl.Samples,
+				BlinkCode:  code,
+				Confidence: confidence,
+			}
+			if led.On {
+				led.Brightness = tl.Brightness()
+			}
+			if !tl.Steady() {
+				led.DutyCycle, _ = timeline.DutyCycle(tl.Samples)
+			}
+			if blink.Hz > 0 {
+				led.BlinkHz, led.DutyCycle = blink.Hz, blink.DutyCycle
+				led.BlinkWarning = strings.Join(blink.Warnings, "; ")
+			}
+			obs.Signals = append(obs.Signals, led)
+		}
+	}
+}
+
+// containsFold reports whether names holds name, in any case
+func containsFold(names []string, name string) bool {
+	for _, n := range names {
+		if strings.EqualFold(n, name) {
+			return true
+		}
+	}
+	return false
 }
 
 func (c *Core) ObservationCount() int {
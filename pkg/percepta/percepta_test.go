@@ -5,11 +5,14 @@ package percepta
 import (
 	"errors"
 	"fmt"
+	"math"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/perceptumx/percepta/internal/core"
+	"github.com/perceptumx/percepta/internal/timeline"
+	"github.com/perceptumx/percepta/internal/vision"
 )
 
 // Mock implementations
@@ -752,3 +755,137 @@ func TestCore_EdgeCase_ZeroObservations(t *testing.T) {
 		t.Errorf("Expected 0 results, got %d", len(results))
 	}
 }
+
+// blinkTimeline renders alternating off/on durations (starting with off) at 20fps
+func blinkTimeline(durations ...int64) []core.LEDSample {
+	var samples []core.LEDSample
+	var offset int64
+	on := false
+	for _, d := range durations {
+		for end := offset + d; offset < end; offset += 50 {
+			samples = append(samples, core.LEDSample{OffsetMs: offset, On: on})
+		}
+		on = !on
+	}
+	return samples
+}
+
+func TestApplyTimelines(t *testing.T) {
+	code := []int64{250, 250, 250, 250, 250, 1000, 250, 250, 250} // 3-2
+	var durations []int64
+	durations = append(durations, 2000)
+	for i := 0; i < 2; i++ {
+		durations = append(durations, code...)
+		durations = append(durations, 2000)
+	}
+
+	obs := &core.Observation{Signals: []core.Signal{
+		core.LEDSignal{Name: "ERR", On: true, BlinkHz: 2, Confidence: 0.9},
+		core.DisplaySignal{Name: "lcd", Text: "Fault"},
+	}}
+	timelines := map[string]vision.LEDTimeline{
+		"err":   {Samples: blinkTimeline(durations...), Contrast: 120},
+		"power": {Samples: blinkTimeline(0, 3000), Contrast: 5},
+		"run":   {Samples: blinkTimeline(0, 3000), Contrast: 5, Peak: 240},
+	}
+
+	applyTimelines(obs, timelines, TimelineOptions{Scheme: timeline.DefaultScheme(), Codes: []string{"err"}})
+
+	if len(obs.Signals) != 4 {
+		t.Fatalf("Expected the steady LED to be added, got %d signals", len(obs.Signals))
+	}
+	led := obs.Signals[0].(core.LEDSignal)
+	if led.BlinkCode != "3-2" || len(led.Timeline) == 0 {
+		t.Errorf("Expected code 3-2 with timeline on the existing LED, got %q (%d frames)", led.BlinkCode, len(led.Timeline))
+	}
+	if led.Confidence != 0.9 || led.BlinkHz == 2 || led.BlinkHz == 0 {
+		t.Errorf("Expected the confidence kept and the rate measured, got %+v", led)
+	}
+
+	power := obs.Signals[2].(core.LEDSignal)
+	if power.Name != "power" || power.BlinkCode != "" {
+		t.Errorf("Expected steady LED without code, got %+v", power)
+	}
+	if power.On || power.Confidence >= 0.1 {
+		t.Errorf("Expected a steady LED without a peak to be unjudged, got %+v", power)
+	}
+	if run := obs.Signals[3].(core.LEDSignal); !run.On || run.Confidence < 0.9 || run.DutyCycle != 0 {
+		t.Errorf("Expected a bright steady LED to read lit, got %+v", run)
+	}
+}
+
+func TestApplyTimelines_BlinkRate(t *testing.T) {
+	// 4 Hz at 20% duty for 3s: vision guessed 1 Hz from the stills
+	var durations []int64
+	for i := 0; i < 12; i++ {
+		durations = append(durations, 200, 50)
+	}
+	obs := &core.Observation{Signals: []core.Signal{
+		core.LEDSignal{Name: "status", On: true, BlinkHz: 1, Confidence: 0.9},
+	}}
+	timelines := map[string]vision.LEDTimeline{
+		"status": {Samples: blinkTimeline(durations...), Contrast: 120},
+		"tx":     {Samples: blinkTimeline(durations...), Contrast: 120},
+	}
+	applyTimelines(obs, timelines, TimelineOptions{Scheme: timeline.DefaultScheme()})
+
+	status := obs.Signals[0].(core.LEDSignal)
+	if status.BlinkHz != 4 || status.DutyCycle != 20 || status.BlinkCode != "" {
+		t.Errorf("Expected a measured 4 Hz at 20%% duty, got %.2f Hz at %.1f%%", status.BlinkHz, status.DutyCycle)
+	}
+	if !strings.Contains(status.BlinkWarning, "single frame") {
+		t.Errorf("Expected a warning about single-frame pulses, got %q", status.BlinkWarning)
+	}
+	if tx := obs.Signals[1].(core.LEDSignal); tx.Name != "tx" || tx.BlinkHz != 4 {
+		t.Errorf("Expected the added LED to be measured too, got %+v", tx)
+	}
+}
+
+func TestApplyTimelines_SlowBlink(t *testing.T) {
+	// 0.25 Hz at 50% duty, asked for with --blink-rate: its long off time would
+	// also decode as code "1", which only --blink-code asks for
+	var durations []int64
+	for i := 0; i < 4; i++ {
+		durations = append(durations, 2000, 2000)
+	}
+	obs := &core.Observation{Signals: []core.Signal{
+		core.LEDSignal{Name: "status", On: true, BlinkHz: 1, Confidence: 0.9},
+	}}
+	timelines := map[string]vision.LEDTimeline{
+		"status": {Samples: blinkTimeline(durations...), Contrast: 120},
+	}
+	applyTimelines(obs, timelines, TimelineOptions{Scheme: timeline.DefaultScheme()})
+
+	status := obs.Signals[0].(core.LEDSignal)
+	if status.BlinkCode != "" {
+		t.Errorf("Expected no blink code for a rate measurement, got %q", status.BlinkCode)
+	}
+	if math.Abs(status.BlinkHz-0.25) > 0.01 || math.Abs(status.DutyCycle-50) > 1 {
+		t.Errorf("Expected a measured 0.25 Hz at 50%% duty, got %.3f Hz at %.1f%%", status.BlinkHz, status.DutyCycle)
+	}
+}
+
+func TestApplyTimelines_BrightnessAndDutyCycle(t *testing.T) {
+	samples := blinkTimeline(500, 500, 500, 500)
+	for i := range samples {
+		samples[i].Brightness = map[bool]uint8{true: 70, false: 10}[samples[i].On]
+	}
+
+	obs := &core.Observation{Signals: []core.Signal{
+		core.LEDSignal{Name: "status", On: true, BlinkHz: 1, Confidence: 0.9},
+		core.LEDSignal{Name: "backlight", On: true, Brightness: 55, Confidence: 0.9},
+	}}
+	timelines := map[string]vision.LEDTimeline{
+		"status":    {Samples: samples, Contrast: 120, Peak: 178},
+		"backlight": {Samples: samples, Contrast: 120, Peak: 178},
+	}
+	applyTimelines(obs, timelines, TimelineOptions{Scheme: timeline.DefaultScheme()})
+
+	status := obs.Signals[0].(core.LEDSignal)
+	if status.Brightness != 70 || status.DutyCycle < 45 || status.DutyCycle > 55 {
+		t.Errorf("Expected measured 70%% brightness at ~50%% duty, got %d%% duty %.1f%%", status.Brightness, status.DutyCycle)
+	}
+	if backlight := obs.Signals[1].(core.LEDSignal); backlight.Brightness != 55 {
+		t.Errorf("Expected the vision-reported brightness to be kept, got %d%%", backlight.Brightness)
+	}
+}
@@ -4,11 +4,13 @@
 #
 # Exercises percepta exactly as a real Linux user would — from building the
 # binary to running every CLI command. Uses the real camera (/dev/video0) and
-# the real Anthropic API.
+# the real Anthropic API. Set PERCEPTA_E2E_CAMERA to a file:// URL of recorded
+# frames to run without a webcam, e.g. in CI.
 #
 # Usage:
 #   chmod +x test/e2e/user_journey_test.sh
 #   ./test/e2e/user_journey_test.sh
+#   PERCEPTA_E2E_CAMERA=file:///path/to/session ./test/e2e/user_journey_test.sh
 # =============================================================================
 
 set -euo pipefail
@@ -18,7 +20,7 @@ set -euo pipefail
 # ---------------------------------------------------------------------------
 
 SOURCE_DIR="/home/utkarsh/Work/hacks/PerceptumX/percepta"
-CAMERA="/dev/video0"
+CAMERA="${PERCEPTA_E2E_CAMERA:-/dev/video0}"
 
 # ANTHROPIC_API_KEY must be set in the environment before running this script
 if [[ -z "${ANTHROPIC_API_KEY:-}" ]]; then